@@ -0,0 +1,47 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ory/kratos/driver"
+)
+
+// Cleanup deletes expired records from the database in batches according to the database cleanup configuration.
+//
+// Reaching the configured maximum duration is not an error; the remaining records are deleted in the next run.
+func Cleanup(ctx context.Context, r driver.Registry) error {
+	c := r.Config(ctx).DatabaseCleanup()
+	if c.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.MaxDuration)
+		defer cancel()
+	}
+
+	if err := r.Persister().CleanupDatabase(ctx, c.Sleep, c.OlderThan, c.BatchSize); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			r.Logger().Infof("Stopped the database cleanup because it took longer than %s.", c.MaxDuration)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Janitor periodically cleans up the database until the context is done.
+func Janitor(ctx context.Context, r driver.Registry) {
+	r.Logger().Println("Database cleanup janitor started.")
+	for {
+		select {
+		case <-ctx.Done():
+			r.Logger().Println("Database cleanup janitor was shutdown gracefully.")
+			return
+		case <-time.After(r.Config(ctx).DatabaseCleanup().JanitorInterval):
+		}
+
+		if err := Cleanup(ctx, r); err != nil && ctx.Err() == nil {
+			r.Logger().WithError(err).Error("Unable to clean up expired records.")
+		}
+	}
+}
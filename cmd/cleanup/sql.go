@@ -0,0 +1,90 @@
+package cleanup
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/configx"
+	"github.com/ory/x/flagx"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+)
+
+// NewCleanupSQLCmd represents the cleanup sql command
+func NewCleanupSQLCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sql <database-url>",
+		Short: "Delete expired flows, sessions, tokens, codes and continuity containers",
+		Long: `Deletes expired login, registration, settings, recovery and verification flows, expired or revoked sessions,
+expired or used recovery and verification tokens, expired codes, expired continuity containers and delivered or abandoned web hooks
+from the database.
+
+Records are deleted in batches. Use the flags or the configuration keys below "database.cleanup" to control how old
+records must be, how many records are deleted per batch and how long the cleanup may take.
+
+It is recommended to run this command close to the SQL instance (e.g. same subnet) instead of over the public internet.
+
+You can read in the database URL using the -e flag, for example:
+	export DSN=...
+	kratos cleanup sql -e
+
+### WARNING ###
+
+Deleted records can not be restored. Create a back up before running this command for the first time!
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := []configx.OptionModifier{
+				configx.WithFlags(cmd.Flags()),
+				configx.SkipValidation(),
+			}
+			if !flagx.MustGetBool(cmd, "read-from-env") {
+				if len(args) != 1 {
+					fmt.Println(cmd.UsageString())
+					os.Exit(1)
+					return
+				}
+				opts = append(opts, configx.WithValue(config.ViperKeyDSN, args[0]))
+			}
+
+			for flag, key := range map[string]string{
+				"batch-size":   config.ViperKeyDatabaseCleanupBatchSize,
+				"older-than":   config.ViperKeyDatabaseCleanupOlderThan,
+				"max-duration": config.ViperKeyDatabaseCleanupMaxDuration,
+				"sleep":        config.ViperKeyDatabaseCleanupSleep,
+			} {
+				if cmd.Flags().Changed(flag) {
+					opts = append(opts, configx.WithValue(key, cmd.Flags().Lookup(flag).Value.String()))
+				}
+			}
+
+			d := driver.NewWithoutInit(cmd.Context(), cmd.ErrOrStderr(), opts...)
+			if len(d.Config(cmd.Context()).DSN()) == 0 {
+				fmt.Println(cmd.UsageString())
+				fmt.Println("")
+				fmt.Println("When using flag -e, environment variable DSN must be set")
+				os.Exit(1)
+				return
+			}
+
+			err := d.Init(cmd.Context())
+			cmdx.Must(err, "An error occurred initializing cleanup: %s", err)
+
+			err = Cleanup(cmd.Context(), d)
+			cmdx.Must(err, "An error occurred while cleaning up expired records: %s", err)
+			fmt.Println("Successfully cleaned up expired records!")
+		},
+	}
+
+	configx.RegisterFlags(c.PersistentFlags())
+	c.Flags().BoolP("read-from-env", "e", false, "If set, reads the database connection string from the environment variable DSN or config file key dsn.")
+	c.Flags().Int("batch-size", 100, "The number of records deleted per query. Overrides database.cleanup.batch_size.")
+	c.Flags().Duration("older-than", 24*time.Hour, "Only delete records which expired, were used or were revoked longer ago than this duration. Overrides database.cleanup.older_than.")
+	c.Flags().Duration("max-duration", 0, "Stop the cleanup after this duration, 0 means unlimited. Overrides database.cleanup.max_duration.")
+	c.Flags().Duration("sleep", 0, "How long to wait between two batches. Overrides database.cleanup.sleep.")
+	return c
+}
@@ -0,0 +1,78 @@
+package cleanup_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/cmd/cleanup"
+	"github.com/ory/kratos/corpx"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/session"
+)
+
+func init() {
+	corpx.RegisterFakes()
+}
+
+func TestCleanupSQLCmd(t *testing.T) {
+	ctx := context.Background()
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "db.sqlite") + "?_fk=true&mode=rwc"
+	conf, reg := internal.NewRegistryDefaultWithDSN(t, dsn)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stubs/identity.schema.json")
+	p := reg.Persister()
+
+	expired := time.Now().UTC().Add(-48 * time.Hour)
+	active := time.Now().UTC().Add(time.Hour)
+
+	newLoginFlow := func(t *testing.T, expiresAt time.Time) *login.Flow {
+		var f login.Flow
+		require.NoError(t, faker.FakeData(&f))
+		f.ExpiresAt = expiresAt
+		require.NoError(t, p.CreateLoginFlow(ctx, &f))
+		return &f
+	}
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	require.NoError(t, p.CreateIdentity(ctx, i))
+
+	newSession := func(t *testing.T, expiresAt time.Time) *session.Session {
+		s, err := session.NewActiveSession(i, conf, time.Now().UTC(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+		require.NoError(t, err)
+		s.ExpiresAt = expiresAt
+		require.NoError(t, p.UpsertSession(ctx, s))
+		return s
+	}
+
+	expiredLogin, activeLogin := newLoginFlow(t, expired), newLoginFlow(t, active)
+	expiredSession, activeSession := newSession(t, expired), newSession(t, active)
+
+	cmd := cleanup.NewCleanupSQLCmd()
+	stdOut := &bytes.Buffer{}
+	cmd.SetOut(stdOut)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dsn, "--older-than", "1h"})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	_, err := p.GetLoginFlow(ctx, expiredLogin.ID)
+	assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+	_, err = p.GetSession(ctx, expiredSession.ID)
+	assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+	_, err = p.GetLoginFlow(ctx, activeLogin.ID)
+	assert.NoError(t, err)
+	_, err = p.GetSession(ctx, activeSession.ID)
+	assert.NoError(t, err)
+}
@@ -100,6 +100,7 @@ func init() {
 		"NewInfoLoginWith":                                        text.NewInfoLoginWith("{provider}"),
 		"NewErrorValidationLoginFlowExpired":                      text.NewErrorValidationLoginFlowExpired(time.Second),
 		"NewErrorValidationLoginNoStrategyFound":                  text.NewErrorValidationLoginNoStrategyFound(),
+		"NewErrorValidationLoginLockedOut":                        text.NewErrorValidationLoginLockedOut(time.Minute * 15),
 		"NewErrorValidationRegistrationNoStrategyFound":           text.NewErrorValidationRegistrationNoStrategyFound(),
 		"NewErrorValidationSettingsNoStrategyFound":               text.NewErrorValidationSettingsNoStrategyFound(),
 		"NewErrorValidationRecoveryNoStrategyFound":               text.NewErrorValidationRecoveryNoStrategyFound(),
@@ -112,11 +113,15 @@ func init() {
 		"NewErrorValidationRecoveryFlowExpired":                   text.NewErrorValidationRecoveryFlowExpired(time.Second),
 		"NewRecoverySuccessful":                                   text.NewRecoverySuccessful(inAMinute),
 		"NewRecoveryEmailSent":                                    text.NewRecoveryEmailSent(),
+		"NewRecoveryPhoneSent":                                    text.NewRecoveryPhoneSent(),
 		"NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed":     text.NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed(),
 		"NewErrorValidationRecoveryRetrySuccess":                  text.NewErrorValidationRecoveryRetrySuccess(),
 		"NewErrorValidationRecoveryStateFailure":                  text.NewErrorValidationRecoveryStateFailure(),
 		"NewErrorValidationInvalidCode":                           text.NewErrorValidationInvalidCode(),
 		"NewErrorCodeSent":                                        text.NewErrorCodeSent(),
+		"NewErrorValidationCodeResendTooSoon":                     text.NewErrorValidationCodeResendTooSoon(time.Second),
+		"NewErrorValidationCodeSendLimitExceeded":                 text.NewErrorValidationCodeSendLimitExceeded(time.Hour),
+		"NewErrorValidationCodeFlowSendLimitExceeded":             text.NewErrorValidationCodeFlowSendLimitExceeded(),
 		"NewInfoNodeInputEmail":                                   text.NewInfoNodeInputEmail(),
 		"NewInfoSelfServiceSettingsRegisterWebAuthn":              text.NewInfoSelfServiceSettingsRegisterWebAuthn(),
 		"NewInfoLoginWebAuthnPasswordless":                        text.NewInfoLoginWebAuthnPasswordless(),
@@ -0,0 +1,70 @@
+package courier
+
+import (
+	"strconv"
+	"time"
+
+	kratos "github.com/ory/kratos-client-go"
+)
+
+type (
+	outputMessage           kratos.Message
+	outputMessageCollection struct {
+		messages []kratos.Message
+	}
+)
+
+func (_ *outputMessage) Header() []string {
+	return []string{"ID", "STATUS", "TYPE", "RECIPIENT", "TEMPLATE TYPE", "SUBJECT", "SEND COUNT", "LAST ERROR", "CREATED AT"}
+}
+
+func (m *outputMessage) Columns() []string {
+	lastError := ""
+	if l := len(m.Dispatches); l > 0 {
+		lastError = m.Dispatches[l-1].GetError()
+	}
+
+	return []string{
+		m.Id,
+		string(m.Status),
+		string(m.Type),
+		m.Recipient,
+		m.TemplateType,
+		m.Subject,
+		strconv.FormatInt(m.SendCount, 10),
+		lastError,
+		m.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (m *outputMessage) Interface() interface{} {
+	return m
+}
+
+func (_ *outputMessageCollection) Header() []string {
+	return []string{"ID", "STATUS", "TYPE", "RECIPIENT", "TEMPLATE TYPE", "SEND COUNT", "CREATED AT"}
+}
+
+func (c *outputMessageCollection) Table() [][]string {
+	rows := make([][]string, len(c.messages))
+	for i, m := range c.messages {
+		rows[i] = []string{
+			m.Id,
+			string(m.Status),
+			string(m.Type),
+			m.Recipient,
+			m.TemplateType,
+			strconv.FormatInt(m.SendCount, 10),
+			m.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return rows
+}
+
+func (c *outputMessageCollection) Interface() interface{} {
+	return c.messages
+}
+
+func (c *outputMessageCollection) Len() int {
+	return len(c.messages)
+}
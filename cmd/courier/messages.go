@@ -0,0 +1,31 @@
+package courier
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+// NewMessagesCmd represents the courier messages command
+func NewMessagesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "messages",
+		Short: "Tools to inspect, retry and cancel messages sent by the courier",
+	}
+
+	cliclient.RegisterClientFlags(c.PersistentFlags())
+	cmdx.RegisterFormatFlags(c.PersistentFlags())
+	return c
+}
+
+func registerMessagesCommandRecursive(parent *cobra.Command) {
+	c := NewMessagesCmd()
+	parent.AddCommand(c)
+
+	c.AddCommand(NewListMessagesCmd())
+	c.AddCommand(NewGetMessageCmd())
+	c.AddCommand(NewRetryMessageCmd())
+	c.AddCommand(NewCancelMessageCmd())
+}
@@ -0,0 +1,43 @@
+package courier
+
+import (
+	"github.com/spf13/cobra"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/x"
+)
+
+func NewCancelMessageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id-0 [id-1 ...]>",
+		Short: "Cancel queued messages",
+		Long:  "This command cancels messages which have not yet been picked up by the courier. Only queued messages can be cancelled.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			messages := make([]kratos.Message, 0, len(args))
+			failed := make(map[string]error)
+			for _, id := range args {
+				message, _, err := c.V0alpha2Api.AdminCancelCourierMessage(cmd.Context(), id).Execute()
+				if x.SDKError(err) != nil {
+					failed[id] = err
+					continue
+				}
+
+				messages = append(messages, *message)
+			}
+
+			printMessages(cmd, messages)
+			cmdx.PrintErrors(cmd, failed)
+
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package courier
+
+import (
+	"github.com/spf13/cobra"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/x"
+)
+
+func NewGetMessageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id-0 [id-1 ...]>",
+		Short: "Get one or more messages by ID",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			messages := make([]kratos.Message, 0, len(args))
+			failed := make(map[string]error)
+			for _, id := range args {
+				message, _, err := c.V0alpha2Api.AdminGetCourierMessage(cmd.Context(), id).Execute()
+				if x.SDKError(err) != nil {
+					failed[id] = err
+					continue
+				}
+
+				messages = append(messages, *message)
+			}
+
+			printMessages(cmd, messages)
+			cmdx.PrintErrors(cmd, failed)
+
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+}
+
+func printMessages(cmd *cobra.Command, messages []kratos.Message) {
+	if len(messages) == 1 {
+		cmdx.PrintRow(cmd, (*outputMessage)(&messages[0]))
+	} else if len(messages) > 1 {
+		cmdx.PrintTable(cmd, &outputMessageCollection{messages})
+	}
+}
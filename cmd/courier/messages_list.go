@@ -0,0 +1,114 @@
+package courier
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+)
+
+const (
+	FlagStatus        = "status"
+	FlagType          = "type"
+	FlagRecipient     = "recipient"
+	FlagCreatedAfter  = "created-after"
+	FlagCreatedBefore = "created-before"
+)
+
+func NewListMessagesCmd() *cobra.Command {
+	var (
+		status, messageType, recipient string
+		createdAfter, createdBefore    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [<page> <per-page>]",
+		Short: "List messages",
+		Long:  "List messages sent or queued by the courier (paginated), newest first.",
+		Example: `To list all messages which could not be delivered, run:
+
+	$ kratos courier messages list --status abandoned`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			// zero or exactly two args
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("expected zero or two args, got %d: %+v", len(args), args)
+			}
+			return nil
+		},
+		Aliases: []string{"ls"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+			req := c.V0alpha2Api.AdminListCourierMessages(cmd.Context())
+
+			if len(args) == 2 {
+				page, err := strconv.ParseInt(args[0], 0, 64)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse page argument\"%s\": %s", args[0], err)
+					return cmdx.FailSilently(cmd)
+				}
+				req = req.Page(page)
+
+				perPage, err := strconv.ParseInt(args[1], 0, 64)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse per-page argument\"%s\": %s", args[1], err)
+					return cmdx.FailSilently(cmd)
+				}
+				req = req.PerPage(perPage)
+			}
+
+			if status != "" {
+				req = req.Status(status)
+			}
+			if messageType != "" {
+				req = req.Type(messageType)
+			}
+			if recipient != "" {
+				req = req.Recipient(recipient)
+			}
+
+			for _, f := range []struct {
+				name  string
+				value string
+				set   func(t time.Time)
+			}{
+				{name: FlagCreatedAfter, value: createdAfter, set: func(t time.Time) { req = req.CreatedAfter(t) }},
+				{name: FlagCreatedBefore, value: createdBefore, set: func(t time.Time) { req = req.CreatedBefore(t) }},
+			} {
+				if f.value == "" {
+					continue
+				}
+				t, err := time.Parse(time.RFC3339, f.value)
+				if err != nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not parse --%s flag \"%s\" as an RFC3339 timestamp: %s\n", f.name, f.value, err)
+					return cmdx.FailSilently(cmd)
+				}
+				f.set(t)
+			}
+
+			messages, _, err := req.Execute()
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not get the messages: %+v\n", err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			cmdx.PrintTable(cmd, &outputMessageCollection{
+				messages: messages,
+			})
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&status, FlagStatus, "", "Only list messages with this status (queued, sent, processing, abandoned, rejected, cancelled).")
+	flags.StringVar(&messageType, FlagType, "", "Only list messages of this type (email, phone).")
+	flags.StringVar(&recipient, FlagRecipient, "", "Only list messages sent to this recipient.")
+	flags.StringVar(&createdAfter, FlagCreatedAfter, "", "Only list messages created at or after this RFC3339 timestamp.")
+	flags.StringVar(&createdBefore, FlagCreatedBefore, "", "Only list messages created before this RFC3339 timestamp.")
+	return cmd
+}
@@ -0,0 +1,46 @@
+package courier
+
+import (
+	"github.com/spf13/cobra"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/x"
+)
+
+func NewRetryMessageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "retry <id-0 [id-1 ...]>",
+		Short: "Queue abandoned, rejected or cancelled messages again",
+		Long:  "This command queues a copy of each given message so that the courier attempts to deliver it again. The original messages are left untouched and the copies are printed.",
+		Example: `To retry all abandoned messages, run:
+
+	$ kratos courier messages retry $(kratos courier messages list --status abandoned --format json | jq -r '.[].id')`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			messages := make([]kratos.Message, 0, len(args))
+			failed := make(map[string]error)
+			for _, id := range args {
+				message, _, err := c.V0alpha2Api.AdminRetryCourierMessage(cmd.Context(), id).Execute()
+				if x.SDKError(err) != nil {
+					failed[id] = err
+					continue
+				}
+
+				messages = append(messages, *message)
+			}
+
+			printMessages(cmd, messages)
+			cmdx.PrintErrors(cmd, failed)
+
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package courier_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	cmdcourier "github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/x"
+)
+
+func setup(t *testing.T, cmd *cobra.Command) driver.Registry {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	_, admin := testhelpers.NewKratosServerWithCSRF(t, reg)
+	cliclient.RegisterClientFlags(cmd.Flags())
+	cmdx.RegisterFormatFlags(cmd.Flags())
+	require.NoError(t, cmd.Flags().Set(cliclient.FlagEndpoint, admin.URL))
+	require.NoError(t, cmd.Flags().Set(cmdx.FlagFormat, string(cmdx.FormatJSON)))
+	return reg
+}
+
+func exec(cmd *cobra.Command, args ...string) (string, string, error) {
+	stdOut, stdErr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetErr(stdErr)
+	cmd.SetOut(stdOut)
+	if args == nil {
+		args = []string{}
+	}
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdOut.String(), stdErr.String(), err
+}
+
+func execNoErr(t *testing.T, cmd *cobra.Command, args ...string) string {
+	stdOut, stdErr, err := exec(cmd, args...)
+	require.NoError(t, err)
+	require.Len(t, stdErr, 0, stdOut)
+	return stdOut
+}
+
+func execErr(t *testing.T, cmd *cobra.Command, args ...string) string {
+	stdOut, stdErr, err := exec(cmd, args...)
+	require.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+	require.Len(t, stdOut, 0, stdErr)
+	return stdErr
+}
+
+func makeMessage(t *testing.T, reg driver.Registry, recipient string, status courier.MessageStatus) *courier.Message {
+	ctx := context.Background()
+	m := &courier.Message{
+		Type:         courier.MessageTypeEmail,
+		Recipient:    recipient,
+		Subject:      "Hello",
+		Body:         "Hello, " + recipient,
+		TemplateType: courier.TypeTestStub,
+	}
+	require.NoError(t, reg.Persister().AddMessage(ctx, m))
+	if status != courier.MessageStatusQueued {
+		require.NoError(t, reg.Persister().SetMessageStatus(ctx, m.ID, status))
+		m.Status = status
+	}
+	return m
+}
+
+func TestMessagesCmd(t *testing.T) {
+	t.Run("command=list", func(t *testing.T) {
+		c := cmdcourier.NewListMessagesCmd()
+		reg := setup(t, c)
+
+		queued := makeMessage(t, reg, "list-queued@ory.sh", courier.MessageStatusQueued)
+		abandoned := makeMessage(t, reg, "list-abandoned@ory.sh", courier.MessageStatusAbandoned)
+
+		t.Run("case=lists all messages", func(t *testing.T) {
+			stdOut := execNoErr(t, c)
+			assert.Contains(t, stdOut, queued.ID.String())
+			assert.Contains(t, stdOut, abandoned.ID.String())
+		})
+
+		t.Run("case=filters by status", func(t *testing.T) {
+			stdOut := execNoErr(t, c, "--status", "abandoned")
+			assert.NotContains(t, stdOut, queued.ID.String())
+			assert.Contains(t, stdOut, abandoned.ID.String())
+		})
+
+		t.Run("case=fails with invalid status", func(t *testing.T) {
+			stdErr := execErr(t, c, "--status", "unknown")
+			assert.Contains(t, stdErr, "400 Bad Request", stdErr)
+		})
+	})
+
+	t.Run("command=get", func(t *testing.T) {
+		c := cmdcourier.NewGetMessageCmd()
+		reg := setup(t, c)
+
+		t.Run("case=gets a single message", func(t *testing.T) {
+			m := makeMessage(t, reg, "get@ory.sh", courier.MessageStatusSent)
+
+			var actual courier.Message
+			require.NoError(t, json.Unmarshal([]byte(execNoErr(t, c, m.ID.String())), &actual))
+			assert.Equal(t, m.ID, actual.ID)
+			assert.Equal(t, courier.MessageStatusSent, actual.Status)
+			assert.Equal(t, m.Recipient, actual.Recipient)
+		})
+
+		t.Run("case=fails with unknown ID", func(t *testing.T) {
+			stdErr := execErr(t, c, x.NewUUID().String())
+			assert.Contains(t, stdErr, "404 Not Found", stdErr)
+		})
+	})
+
+	t.Run("command=retry", func(t *testing.T) {
+		c := cmdcourier.NewRetryMessageCmd()
+		reg := setup(t, c)
+
+		t.Run("case=queues a copy of an abandoned message", func(t *testing.T) {
+			m := makeMessage(t, reg, "retry@ory.sh", courier.MessageStatusAbandoned)
+
+			var actual courier.Message
+			require.NoError(t, json.Unmarshal([]byte(execNoErr(t, c, m.ID.String())), &actual))
+			assert.NotEqual(t, m.ID, actual.ID)
+			assert.Equal(t, courier.MessageStatusQueued, actual.Status)
+			assert.Equal(t, m.Recipient, actual.Recipient)
+			assert.Equal(t, m.Body, actual.Body)
+
+			original, err := reg.Persister().FetchMessage(context.Background(), m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusAbandoned, original.Status)
+		})
+
+		t.Run("case=fails for queued messages", func(t *testing.T) {
+			m := makeMessage(t, reg, "retry-queued@ory.sh", courier.MessageStatusQueued)
+			stdErr := execErr(t, c, m.ID.String())
+			assert.Contains(t, stdErr, "409 Conflict", stdErr)
+		})
+	})
+
+	t.Run("command=cancel", func(t *testing.T) {
+		c := cmdcourier.NewCancelMessageCmd()
+		reg := setup(t, c)
+
+		t.Run("case=cancels a queued message", func(t *testing.T) {
+			m := makeMessage(t, reg, "cancel@ory.sh", courier.MessageStatusQueued)
+
+			var actual courier.Message
+			require.NoError(t, json.Unmarshal([]byte(execNoErr(t, c, m.ID.String())), &actual))
+			assert.Equal(t, m.ID, actual.ID)
+			assert.Equal(t, courier.MessageStatusCancelled, actual.Status)
+		})
+
+		t.Run("case=fails for sent messages", func(t *testing.T) {
+			m := makeMessage(t, reg, "cancel-sent@ory.sh", courier.MessageStatusSent)
+			stdErr := execErr(t, c, m.ID.String())
+			assert.Contains(t, stdErr, "409 Conflict", stdErr)
+		})
+	})
+}
@@ -20,4 +20,5 @@ func RegisterCommandRecursive(parent *cobra.Command) {
 	c := NewCourierCmd()
 	parent.AddCommand(c)
 	c.AddCommand(NewWatchCmd())
+	registerMessagesCommandRecursive(c)
 }
@@ -11,7 +11,9 @@ import (
 
 	"github.com/ory/x/reqlog"
 
+	"github.com/ory/kratos/cmd/cleanup"
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/webhooks"
 	"github.com/ory/kratos/driver/config"
 
 	"github.com/rs/cors"
@@ -279,6 +281,14 @@ func bgTasks(d driver.Registry, wg *sync.WaitGroup, cmd *cobra.Command, args []s
 	if d.Config(ctx).IsBackgroundCourierEnabled() {
 		go courier.Watch(ctx, d)
 	}
+
+	if d.Config(ctx).DatabaseCleanup().JanitorEnabled {
+		go cleanup.Janitor(ctx, d)
+	}
+
+	if d.Config(ctx).IsBackgroundWebHookWorkerEnabled() {
+		go webhooks.Watch(ctx, d)
+	}
 }
 
 func ServeAll(d driver.Registry, opts ...Option) func(cmd *cobra.Command, args []string) {
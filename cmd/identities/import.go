@@ -13,11 +13,24 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/identity"
+)
+
+const (
+	FlagImportJSONL       = "jsonl"
+	FlagImportBatchSize   = "batch-size"
+	FlagImportConcurrency = "concurrency"
 )
 
 // NewImportCmd represents the import command
 func NewImportCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		jsonl       bool
+		batchSize   int
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
 		Use:   "import <file.json [file-2.json [file-3.json] ...]>",
 		Short: "Import identities from files or STD_IN",
 		Example: `$ cat > ./file.json <<EOF
@@ -31,15 +44,23 @@ EOF
 
 $ kratos identities import file.json
 # Alternatively:
-$ cat file.json | kratos identities import`,
+$ cat file.json | kratos identities import
+
+To import a large JSON Lines file with one identity per line, sending eight batches at a time, run:
+
+$ kratos identities import --jsonl --concurrency 8 identities.jsonl > imported-ids.txt`,
 		Long: `Import identities from files or STD_IN.
 
 Files can contain only a single or an array of identities. The validity of files can be tested beforehand using "... identities validate".
 
-WARNING: Importing credentials is not yet supported.`,
+With --jsonl, files and STD_IN are read as JSON Lines containing one identity per line. The identities are streamed to the server in batches and validated by the server only. The ID of each imported identity is printed on its own line, and identities which could not be imported are reported with their file and line number.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := cliclient.NewClient(cmd)
 
+			if jsonl {
+				return importJSONL(cmd, c, args, batchSize, concurrency)
+			}
+
 			imported := make([]kratos.Identity, 0, len(args))
 			failed := make(map[string]error)
 
@@ -84,4 +105,9 @@ WARNING: Importing credentials is not yet supported.`,
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&jsonl, FlagImportJSONL, false, "Read identities as JSON Lines, one identity per line, and import them in batches")
+	cmd.Flags().IntVar(&batchSize, FlagImportBatchSize, 500, fmt.Sprintf("The number of identities sent with one request when using --%s (at most %d)", FlagImportJSONL, identity.BatchCreateIdentitiesLimit))
+	cmd.Flags().IntVar(&concurrency, FlagImportConcurrency, 4, fmt.Sprintf("The number of batches sent at the same time when using --%s", FlagImportJSONL))
+	return cmd
 }
@@ -0,0 +1,213 @@
+package identities
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/cmdx"
+)
+
+type (
+	jsonlBatch struct {
+		sources    []string
+		identities []kratos.AdminCreateIdentityBody
+	}
+	jsonlResult struct {
+		source string
+		id     string
+		err    error
+	}
+)
+
+// importJSONL streams identities from JSON Lines files or STD_IN to the batch create endpoint. Lines are
+// grouped into batches of batchSize identities, and up to concurrency batches are sent at the same time.
+//
+// The ID of every created identity is printed to STD_OUT, while every identity which could not be created
+// is reported on STD_ERR together with its file and line number.
+func importJSONL(cmd *cobra.Command, c *kratos.APIClient, args []string, batchSize, concurrency int) error {
+	if batchSize < 1 || batchSize > identity.BatchCreateIdentitiesLimit {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "The batch size must be between 1 and %d but is %d.\n", identity.BatchCreateIdentitiesLimit, batchSize)
+		return cmdx.FailSilently(cmd)
+	}
+	if concurrency < 1 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "The concurrency must be at least 1 but is %d.\n", concurrency)
+		return cmdx.FailSilently(cmd)
+	}
+
+	batches := make(chan jsonlBatch)
+	results := make(chan jsonlResult)
+
+	g, ctx := errgroup.WithContext(cmd.Context())
+	g.Go(func() error {
+		defer close(batches)
+		return readJSONL(ctx, cmd, args, batchSize, batches, results)
+	})
+	for k := 0; k < concurrency; k++ {
+		g.Go(func() error {
+			for b := range batches {
+				if err := createBatch(ctx, c, b, results); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	var readErr error
+	go func() {
+		readErr = g.Wait()
+		close(results)
+	}()
+
+	var imported, failed int
+	for r := range results {
+		if r.err != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", r.source, r.err)
+			continue
+		}
+
+		imported++
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), r.id)
+	}
+
+	if readErr != nil {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), readErr)
+		return cmdx.FailSilently(cmd)
+	}
+
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Imported %d identities, %d failed.\n", imported, failed)
+	if failed != 0 {
+		return cmdx.FailSilently(cmd)
+	}
+	return nil
+}
+
+func readJSONL(ctx context.Context, cmd *cobra.Command, args []string, batchSize int, batches chan<- jsonlBatch, results chan<- jsonlResult) error {
+	b := jsonlBatch{}
+	flush := func() error {
+		if len(b.identities) == 0 {
+			return nil
+		}
+
+		select {
+		case batches <- b:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		b = jsonlBatch{}
+		return nil
+	}
+
+	read := func(src string, r io.Reader) error {
+		reader := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			raw, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return errors.Errorf("%s:%d: Could not read: %s", src, line, err)
+			}
+
+			source := fmt.Sprintf("%s:%d", src, line)
+			if trimmed := strings.TrimSpace(raw); len(trimmed) > 0 {
+				var params kratos.AdminCreateIdentityBody
+				if perr := json.Unmarshal([]byte(trimmed), &params); perr != nil {
+					select {
+					case results <- jsonlResult{source: source, err: errors.Errorf("Could not parse identity: %s", perr)}:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				} else {
+					b.sources = append(b.sources, source)
+					b.identities = append(b.identities, params)
+					if len(b.identities) >= batchSize {
+						if ferr := flush(); ferr != nil {
+							return ferr
+						}
+					}
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+		}
+	}
+
+	if len(args) == 0 {
+		if err := read("STD_IN", cmd.InOrStdin()); err != nil {
+			return err
+		}
+		return flush()
+	}
+
+	for _, fn := range args {
+		f, err := os.Open(fn)
+		if err != nil {
+			return errors.Errorf("%s: Could not open identity file: %s", fn, err)
+		}
+
+		err = read(fn, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+func createBatch(ctx context.Context, c *kratos.APIClient, b jsonlBatch, results chan<- jsonlResult) error {
+	send := func(r jsonlResult) error {
+		select {
+		case results <- r:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	res, _, err := c.V0alpha2Api.
+		AdminBatchCreateIdentities(ctx).
+		AdminBatchCreateIdentitiesBody(kratos.AdminBatchCreateIdentitiesBody{Identities: b.identities}).
+		Execute()
+	if err = x.SDKError(err); err != nil {
+		// The whole batch was rejected, so none of its identities were created.
+		for _, src := range b.sources {
+			if err := send(jsonlResult{source: src, err: err}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for k, src := range b.sources {
+		r := jsonlResult{source: src, err: errors.New("The server did not return a result for this identity.")}
+		if k < len(res.Identities) {
+			if e := res.Identities[k].Error; e != nil {
+				msg := e.Message
+				if e.Reason != nil && *e.Reason != "" {
+					msg = fmt.Sprintf("%s: %s", msg, *e.Reason)
+				}
+				r.err = errors.New(msg)
+			} else if id := res.Identities[k].Identity; id != nil {
+				r = jsonlResult{source: src, id: *id}
+			}
+		}
+
+		if err := send(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/ory/kratos/cmd/identities"
@@ -130,3 +132,95 @@ func TestImportCmd(t *testing.T) {
 		assert.Len(t, stdOut, 0)
 	})
 }
+
+func TestImportCmdJSONL(t *testing.T) {
+	c := identities.NewImportCmd()
+	reg := setup(t, c)
+	require.NoError(t, c.Flags().Set(identities.FlagImportJSONL, "true"))
+	require.NoError(t, c.Flags().Set(identities.FlagImportBatchSize, "2"))
+	require.NoError(t, c.Flags().Set(identities.FlagImportConcurrency, "2"))
+
+	line := func(t *testing.T, traits map[string]interface{}) string {
+		ij, err := json.Marshal(kratos.AdminCreateIdentityBody{
+			SchemaId: config.DefaultIdentityTraitsSchemaID,
+			Traits:   traits,
+		})
+		require.NoError(t, err)
+		return string(ij) + "\n"
+	}
+
+	assertImported := func(t *testing.T, stdOut string, expected int) {
+		ids := strings.Fields(stdOut)
+		require.Len(t, ids, expected, stdOut)
+		for _, raw := range ids {
+			id, err := uuid.FromString(raw)
+			require.NoError(t, err)
+			_, err = reg.Persister().GetIdentity(context.Background(), id)
+			assert.NoError(t, err)
+		}
+	}
+
+	t.Run("case=imports identities from STD_IN", func(t *testing.T) {
+		var in bytes.Buffer
+		for k := 0; k < 5; k++ {
+			in.WriteString(line(t, map[string]interface{}{"testKey": fmt.Sprintf("stdin-%d", k)}))
+		}
+		// blank lines are skipped
+		in.WriteString("\n")
+
+		stdOut, stdErr, err := exec(c, &in)
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+		assert.Contains(t, stdErr, "Imported 5 identities, 0 failed.")
+		assertImported(t, stdOut, 5)
+	})
+
+	t.Run("case=imports identities from multiple files", func(t *testing.T) {
+		files := make([]string, 2)
+		for k := range files {
+			f, err := ioutil.TempFile("", "*.jsonl")
+			require.NoError(t, err)
+			for l := 0; l < 3; l++ {
+				_, err = f.WriteString(line(t, map[string]interface{}{"testKey": fmt.Sprintf("file-%d-%d", k, l)}))
+				require.NoError(t, err)
+			}
+			require.NoError(t, f.Close())
+			files[k] = f.Name()
+		}
+
+		stdOut, stdErr, err := exec(c, nil, files...)
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+		assertImported(t, stdOut, 6)
+	})
+
+	t.Run("case=reports identities which could not be imported", func(t *testing.T) {
+		in := bytes.NewBufferString(
+			line(t, map[string]interface{}{"testKey": "valid-0"}) +
+				"{not json\n" +
+				line(t, map[string]interface{}{"testKey": 1234}) +
+				line(t, map[string]interface{}{"testKey": "valid-1"}))
+
+		stdOut, stdErr, err := exec(c, in)
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "STD_IN:2: Could not parse identity")
+		assert.Contains(t, stdErr, "STD_IN:3: ")
+		assert.NotContains(t, stdErr, "STD_IN:1: ")
+		assert.NotContains(t, stdErr, "STD_IN:4: ")
+		assert.Contains(t, stdErr, "Imported 2 identities, 2 failed.")
+		assertImported(t, stdOut, 2)
+	})
+
+	t.Run("case=fails on unknown file", func(t *testing.T) {
+		stdErr := execErr(t, c, "does-not-exist.jsonl")
+		assert.Contains(t, stdErr, "does-not-exist.jsonl: Could not open identity file")
+	})
+
+	t.Run("case=fails on invalid batch size", func(t *testing.T) {
+		require.NoError(t, c.Flags().Set(identities.FlagImportBatchSize, "1001"))
+		t.Cleanup(func() {
+			require.NoError(t, c.Flags().Set(identities.FlagImportBatchSize, "2"))
+		})
+
+		stdErr := execErr(t, c)
+		assert.Contains(t, stdErr, "The batch size must be between 1 and 1000 but is 1001.")
+	})
+}
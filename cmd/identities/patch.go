@@ -1,21 +1,102 @@
 package identities
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/cmd/cliclient"
+	"github.com/ory/kratos/internal/clihelpers"
+)
+
+const (
+	FlagPatchFile = "file"
 )
 
 func NewPatchCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "patch <file.json [file-2.json [file-3.json] ...]>",
-		Short: "Patch identities by ID (not yet implemented)",
-		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			// TODO
-			fmt.Println("not yet implemented")
-			os.Exit(1)
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "patch <id-0 [id-1 ...]>",
+		Short: "Patch identities by ID using JSON Patch",
+		Long: fmt.Sprintf(`This command applies a JSON Patch (RFC 6902) document read from a file or STD_IN to one or more identities.
+
+Only the schema ID, state, traits, metadata, addresses, and credential identifiers of an identity can be patched. The patched identity is validated against its identity schema. Use the "test" operation to make sure that a value was not changed in the meantime.
+
+%s
+`, clihelpers.WarningJQIsComplicated),
+		Example: `To deactivate the identity with ID "f7e3...", run:
+
+	$ echo '[{"op": "replace", "path": "/state", "value": "inactive"}]' | kratos identities patch f7e3...
+
+To set the department of all identities with the recovery email address at the domain "ory.sh", run:
+
+	$ cat > ./patch.json <<EOF
+	[{"op": "add", "path": "/traits/department", "value": "engineering"}]
+	EOF
+	$ kratos identities patch --file patch.json $(kratos identities list --format json | jq -r 'map(select(.recovery_addresses[].value | endswith("@ory.sh"))) | .[].id')`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := cliclient.NewClient(cmd)
+
+			src := "STD_IN"
+			var (
+				raw []byte
+				err error
+			)
+			if file == "" {
+				raw, err = ioutil.ReadAll(cmd.InOrStdin())
+			} else {
+				src = file
+				raw, err = ioutil.ReadFile(file)
+			}
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Could not read JSON Patch: %s\n", src, err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			var patch []kratos.JsonPatch
+			if err := json.Unmarshal(raw, &patch); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Could not parse JSON Patch: %s\n", src, err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			patched := make([]kratos.Identity, 0, len(args))
+			failed := make(map[string]error)
+			for _, id := range args {
+				identity, _, err := c.V0alpha2Api.
+					AdminPatchIdentity(cmd.Context(), id).
+					JsonPatch(patch).
+					Execute()
+
+				if x.SDKError(err) != nil {
+					failed[id] = err
+					continue
+				}
+
+				patched = append(patched, *identity)
+			}
+
+			if len(patched) == 1 {
+				cmdx.PrintRow(cmd, (*outputIdentity)(&patched[0]))
+			} else if len(patched) > 1 {
+				cmdx.PrintTable(cmd, &outputIdentityCollection{patched})
+			}
+			cmdx.PrintErrors(cmd, failed)
+
+			if len(failed) != 0 {
+				return cmdx.FailSilently(cmd)
+			}
+			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&file, FlagPatchFile, "", "Read the JSON Patch from this file instead of STD_IN")
+	return cmd
 }
@@ -0,0 +1,78 @@
+package identities_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cmd/identities"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+func TestPatchCmd(t *testing.T) {
+	c := identities.NewPatchCmd()
+	reg := setup(t, c)
+
+	t.Run("case=patches an identity from STD_IN", func(t *testing.T) {
+		_, ids := makeIdentities(t, reg, 1)
+
+		stdOut, stdErr, err := exec(c, strings.NewReader(`[{"op":"add","path":"/traits/testKey","value":"foo"},{"op":"replace","path":"/state","value":"inactive"}]`), ids...)
+		require.NoError(t, err, stdErr)
+
+		assert.Equal(t, ids[0], gjson.Get(stdOut, "id").String(), stdOut)
+		assert.Equal(t, "foo", gjson.Get(stdOut, "traits.testKey").String(), stdOut)
+		assert.Equal(t, string(identity.StateInactive), gjson.Get(stdOut, "state").String(), stdOut)
+
+		actual, err := reg.Persister().GetIdentity(context.Background(), x.ParseUUID(ids[0]))
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateInactive, actual.State)
+		assert.JSONEq(t, `{"testKey":"foo"}`, string(actual.Traits))
+	})
+
+	t.Run("case=patches three identities from a file", func(t *testing.T) {
+		_, ids := makeIdentities(t, reg, 3)
+
+		fn := filepath.Join(t.TempDir(), "patch.json")
+		require.NoError(t, ioutil.WriteFile(fn, []byte(`[{"op":"add","path":"/traits/testKey","value":"bar"}]`), 0600))
+		require.NoError(t, c.Flags().Set(identities.FlagPatchFile, fn))
+		defer func() {
+			require.NoError(t, c.Flags().Set(identities.FlagPatchFile, ""))
+		}()
+
+		stdOut := execNoErr(t, c, ids...)
+
+		for _, id := range ids {
+			assert.Equal(t, "bar", gjson.Get(stdOut, `#(id=="`+id+`").traits.testKey`).String(), stdOut)
+		}
+	})
+
+	t.Run("case=fails with unknown ID", func(t *testing.T) {
+		stdOut, stdErr, err := exec(c, strings.NewReader(`[{"op":"replace","path":"/state","value":"inactive"}]`), x.NewUUID().String())
+		require.Error(t, err)
+		assert.Len(t, stdOut, 0)
+		assert.Contains(t, stdErr, "404 Not Found", stdErr)
+	})
+
+	t.Run("case=fails if the patched identity is invalid", func(t *testing.T) {
+		_, ids := makeIdentities(t, reg, 1)
+
+		_, stdErr, err := exec(c, strings.NewReader(`[{"op":"add","path":"/traits/unknown","value":"foo"}]`), ids...)
+		require.Error(t, err)
+		assert.Contains(t, stdErr, "400 Bad Request", stdErr)
+	})
+
+	t.Run("case=fails if the JSON Patch can not be parsed", func(t *testing.T) {
+		_, ids := makeIdentities(t, reg, 1)
+
+		_, stdErr, err := exec(c, strings.NewReader(`{"op":"replace"}`), ids...)
+		require.Error(t, err)
+		assert.Contains(t, stdErr, "STD_IN: Could not parse JSON Patch", stdErr)
+	})
+}
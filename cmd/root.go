@@ -7,7 +7,9 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 
+	"github.com/ory/kratos/cmd/cleanup"
 	"github.com/ory/kratos/cmd/courier"
+	"github.com/ory/kratos/cmd/webhooks"
 	"github.com/ory/kratos/cmd/hashers"
 
 	"github.com/ory/kratos/cmd/remote"
@@ -31,9 +33,11 @@ func NewRootCmd() (cmd *cobra.Command) {
 	jsonnet.RegisterCommandRecursive(cmd)
 	serve.RegisterCommandRecursive(cmd)
 	migrate.RegisterCommandRecursive(cmd)
+	cleanup.RegisterCommandRecursive(cmd)
 	remote.RegisterCommandRecursive(cmd)
 	hashers.RegisterCommandRecursive(cmd)
 	courier.RegisterCommandRecursive(cmd)
+	webhooks.RegisterCommandRecursive(cmd)
 
 	cmd.AddCommand(cmdx.Version(&config.Version, &config.Commit, &config.Date))
 
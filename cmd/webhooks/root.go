@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ory/x/configx"
+)
+
+// NewWebHooksCmd creates a new web-hooks command
+func NewWebHooksCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "web-hooks",
+		Short: "Commands related to the Ory Kratos web hook worker",
+	}
+	configx.RegisterFlags(c.PersistentFlags())
+	return c
+}
+
+func RegisterCommandRecursive(parent *cobra.Command) {
+	c := NewWebHooksCmd()
+	parent.AddCommand(c)
+	c.AddCommand(NewWatchCmd())
+}
@@ -0,0 +1,39 @@
+package webhooks
+
+import (
+	cx "context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ory/graceful"
+	"github.com/ory/kratos/driver"
+	"github.com/ory/x/configx"
+)
+
+func NewWatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Starts the Ory Kratos web hook worker which delivers asynchronous web hooks",
+		Run: func(cmd *cobra.Command, args []string) {
+			r := driver.New(cmd.Context(), cmd.ErrOrStderr(), configx.WithFlags(cmd.Flags()))
+			Watch(cmd.Context(), r)
+		},
+	}
+}
+
+// Watch delivers asynchronous web hooks from the outbox until the context is done.
+func Watch(ctx cx.Context, r driver.Registry) {
+	ctx, cancel := cx.WithCancel(ctx)
+
+	r.Logger().Println("Web hook worker started.")
+	if err := graceful.Graceful(func() error {
+		return r.WebHookWorker().Work(ctx)
+	}, func(_ cx.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		r.Logger().WithError(err).Fatalf("Failed to run web hook worker.")
+	}
+
+	r.Logger().Println("Web hook worker was shutdown gracefully.")
+}
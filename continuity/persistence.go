@@ -2,6 +2,7 @@ package continuity
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -14,4 +15,6 @@ type Persister interface {
 	SaveContinuitySession(ctx context.Context, c *Container) error
 	GetContinuitySession(ctx context.Context, id uuid.UUID) (*Container, error)
 	DeleteContinuitySession(ctx context.Context, id uuid.UUID) error
+	// DeleteExpiredContinuitySessions deletes at most limit containers which expired before the given time.
+	DeleteExpiredContinuitySessions(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 }
@@ -0,0 +1,215 @@
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/stringslice"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+const (
+	channelTypeSMTP = "smtp"
+	channelTypeHTTP = "http"
+
+	defaultEmailChannel = "email"
+	defaultSMSChannel   = "sms"
+)
+
+type (
+	// channel delivers messages through a single upstream provider, either an SMTP server or an HTTP API.
+	channel struct {
+		ID   string
+		Type string
+
+		SMTP          *smtpClient
+		RequestConfig json.RawMessage
+		From          string
+	}
+
+	route struct {
+		config.CourierRoute
+		recipient *regexp.Regexp
+	}
+
+	channelHealth struct {
+		failures    int
+		lastFailure time.Time
+	}
+
+	channelHealthTracker struct {
+		sync.Mutex
+		channels map[string]*channelHealth
+	}
+)
+
+func newChannels(ctx context.Context, deps Dependencies, c *courier) map[string]*channel {
+	conf := deps.CourierConfig(ctx)
+	channels := make(map[string]*channel)
+
+	if conf.CourierEmailStrategy() == channelTypeHTTP {
+		channels[defaultEmailChannel] = &channel{ID: defaultEmailChannel, Type: channelTypeHTTP, RequestConfig: c.httpClient.RequestConfig}
+	} else {
+		channels[defaultEmailChannel] = &channel{ID: defaultEmailChannel, Type: channelTypeSMTP, SMTP: c.smtpClient}
+	}
+
+	if conf.CourierSMSEnabled() {
+		channels[defaultSMSChannel] = &channel{ID: defaultSMSChannel, Type: channelTypeHTTP, RequestConfig: c.smsClient.RequestConfig, From: conf.CourierSMSFrom()}
+	}
+
+	for _, cc := range conf.CourierChannels() {
+		ch := &channel{ID: cc.ID, Type: cc.Type, RequestConfig: cc.RequestConfig, From: cc.From}
+		if ch.From == "" {
+			ch.From = conf.CourierSMSFrom()
+		}
+
+		if cc.Type == channelTypeSMTP {
+			if cc.SMTP == nil {
+				deps.Logger().WithField("channel_id", cc.ID).Error("Ignoring courier channel because its SMTP configuration is missing.")
+				continue
+			}
+
+			uri, err := url.Parse(cc.SMTP.ConnectionURI)
+			if err != nil {
+				deps.Logger().WithError(err).WithField("channel_id", cc.ID).Error("Ignoring courier channel because its SMTP connection URI is invalid.")
+				continue
+			}
+
+			ch.SMTP = &smtpClient{Dialer: newSMTPDialer(uri), sender: cc.SMTP}
+		}
+
+		channels[cc.ID] = ch
+	}
+
+	return channels
+}
+
+func newRoutes(ctx context.Context, deps Dependencies) []route {
+	var routes []route
+	for _, rc := range deps.CourierConfig(ctx).CourierRoutes() {
+		r := route{CourierRoute: rc}
+		if rc.RecipientPattern != "" {
+			re, err := regexp.Compile(rc.RecipientPattern)
+			if err != nil {
+				deps.Logger().WithError(err).WithField("recipient_pattern", rc.RecipientPattern).Error("Ignoring courier route because its recipient pattern is invalid.")
+				continue
+			}
+			r.recipient = re
+		}
+		routes = append(routes, r)
+	}
+	return routes
+}
+
+func (r *route) matches(msg Message) bool {
+	if r.MessageType != "" && r.MessageType != msg.Type.String() {
+		return false
+	}
+
+	if len(r.TemplateTypes) > 0 && !stringslice.Has(r.TemplateTypes, string(msg.TemplateType)) {
+		return false
+	}
+
+	if r.recipient != nil && !r.recipient.MatchString(msg.Recipient) {
+		return false
+	}
+
+	if len(r.IdentitySchemas) > 0 && !stringslice.Has(r.IdentitySchemas, gjson.GetBytes(msg.TemplateData, "Identity.schema_id").String()) {
+		return false
+	}
+
+	return true
+}
+
+// channelsFor returns the channels a message should be delivered through, in order of preference. Channels which
+// keep failing are left out unless every channel of the route is failing.
+func (c *courier) channelsFor(ctx context.Context, msg Message) ([]*channel, error) {
+	var ids []string
+	for k := range c.routes {
+		if c.routes[k].matches(msg) {
+			ids = c.routes[k].Channels
+			break
+		}
+	}
+
+	if len(ids) == 0 {
+		switch msg.Type {
+		case MessageTypeEmail:
+			ids = []string{defaultEmailChannel}
+		case MessageTypePhone:
+			if _, ok := c.channels[defaultSMSChannel]; !ok {
+				return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an sms but courier.sms.enabled is set to false!"))
+			}
+			ids = []string{defaultSMSChannel}
+		default:
+			return nil, errors.Errorf("received unexpected message type: %d", msg.Type)
+		}
+	}
+
+	all := make([]*channel, 0, len(ids))
+	for _, id := range ids {
+		ch, ok := c.channels[id]
+		if !ok {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver a message through channel %s but no such channel is configured.", id))
+		}
+		all = append(all, ch)
+	}
+
+	threshold := c.deps.CourierConfig(ctx).CourierFailoverThreshold()
+	cooldown := c.deps.CourierConfig(ctx).CourierFailoverCooldown()
+
+	healthy := make([]*channel, 0, len(all))
+	for _, ch := range all {
+		if !c.health.isFailing(ch.ID, threshold, cooldown) {
+			healthy = append(healthy, ch)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return all, nil
+	}
+	return healthy, nil
+}
+
+func (t *channelHealthTracker) isFailing(id string, threshold int, cooldown time.Duration) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	h, ok := t.channels[id]
+	if !ok {
+		return false
+	}
+	return h.failures >= threshold && time.Since(h.lastFailure) < cooldown
+}
+
+func (t *channelHealthTracker) report(id string, err error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.channels == nil {
+		t.channels = make(map[string]*channelHealth)
+	}
+
+	h, ok := t.channels[id]
+	if !ok {
+		h = new(channelHealth)
+		t.channels[id] = h
+	}
+
+	if err == nil {
+		h.failures = 0
+		return
+	}
+
+	h.failures++
+	h.lastFailure = time.Now()
+}
@@ -0,0 +1,136 @@
+package courier_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestChannelRouting(t *testing.T) {
+	newGateway := func(t *testing.T, statusCode *int32) (*httptest.Server, *int32) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(int(atomic.LoadInt32(statusCode)))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &calls
+	}
+
+	requestConfig := func(url string) map[string]interface{} {
+		return map[string]interface{}{"url": url, "method": "POST", "body": "file://./stub/request.config.twilio.jsonnet"}
+	}
+
+	primaryStatus, secondaryStatus, defaultStatus := int32(http.StatusOK), int32(http.StatusOK), int32(http.StatusOK)
+	primary, primaryCalls := newGateway(t, &primaryStatus)
+	secondary, secondaryCalls := newGateway(t, &secondaryStatus)
+	fallback, defaultCalls := newGateway(t, &defaultStatus)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+	conf.MustSet(config.ViperKeyCourierSMSRequestConfig, fmt.Sprintf(`{"url": "%s", "method": "POST", "body": "file://./stub/request.config.twilio.jsonnet"}`, fallback.URL))
+	conf.MustSet(config.ViperKeyCourierChannels, []map[string]interface{}{
+		{"id": "sms-eu", "type": "http", "request_config": requestConfig(primary.URL)},
+		{"id": "sms-eu-backup", "type": "http", "request_config": requestConfig(secondary.URL)},
+	})
+	conf.MustSet(config.ViperKeyCourierRoutes, []map[string]interface{}{
+		{"message_type": "phone", "identity_schemas": []string{"customer"}, "channels": []string{"sms-eu-backup"}},
+		{"message_type": "phone", "recipient_pattern": `^\+49`, "channels": []string{"sms-eu", "sms-eu-backup"}},
+	})
+	conf.MustSet(config.ViperKeyCourierFailoverThreshold, 2)
+	reg.Logger().Level = logrus.TraceLevel
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+
+	send := func(t *testing.T, m *sms.TestStubModel) *courier.Message {
+		id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, m))
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, id)
+
+		_ = c.DispatchQueue(ctx)
+
+		msg, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return msg
+	}
+
+	reset := func() {
+		atomic.StoreInt32(primaryCalls, 0)
+		atomic.StoreInt32(secondaryCalls, 0)
+		atomic.StoreInt32(defaultCalls, 0)
+	}
+
+	t.Run("case=routes by recipient pattern", func(t *testing.T) {
+		reset()
+		m := send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body"})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		assert.EqualValues(t, 1, atomic.LoadInt32(primaryCalls))
+		assert.EqualValues(t, 0, atomic.LoadInt32(secondaryCalls))
+		assert.EqualValues(t, 0, atomic.LoadInt32(defaultCalls))
+	})
+
+	t.Run("case=routes by identity schema", func(t *testing.T) {
+		reset()
+		m := send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body", Identity: map[string]interface{}{"schema_id": "customer"}})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		assert.EqualValues(t, 0, atomic.LoadInt32(primaryCalls))
+		assert.EqualValues(t, 1, atomic.LoadInt32(secondaryCalls))
+	})
+
+	t.Run("case=falls back to default channel", func(t *testing.T) {
+		reset()
+		m := send(t, &sms.TestStubModel{To: "+12065550101", Body: "body"})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		assert.EqualValues(t, 0, atomic.LoadInt32(primaryCalls))
+		assert.EqualValues(t, 1, atomic.LoadInt32(defaultCalls))
+	})
+
+	t.Run("case=fails over to the next channel", func(t *testing.T) {
+		reset()
+		atomic.StoreInt32(&primaryStatus, http.StatusForbidden)
+
+		m := send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body"})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		require.Len(t, m.Dispatches, 2)
+		assert.Equal(t, courier.MessageDispatchStatusFailed, m.Dispatches[0].Status)
+		assert.Equal(t, http.StatusForbidden, m.Dispatches[0].ResponseCode)
+		assert.Equal(t, courier.MessageDispatchStatusSuccess, m.Dispatches[1].Status)
+
+		m = send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body"})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		require.Len(t, m.Dispatches, 2)
+
+		// The primary channel failed twice in a row and is now skipped.
+		m = send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body"})
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		require.Len(t, m.Dispatches, 1)
+
+		assert.EqualValues(t, 2, atomic.LoadInt32(primaryCalls))
+		assert.EqualValues(t, 3, atomic.LoadInt32(secondaryCalls))
+	})
+
+	t.Run("case=does not fail over rejected messages", func(t *testing.T) {
+		reset()
+		atomic.StoreInt32(&secondaryStatus, http.StatusBadRequest)
+
+		m := send(t, &sms.TestStubModel{To: "+4917612345678", Body: "body", Identity: map[string]interface{}{"schema_id": "customer"}})
+		assert.Equal(t, courier.MessageStatusRejected, m.Status)
+		assert.Len(t, m.Dispatches, 1)
+		assert.EqualValues(t, 0, atomic.LoadInt32(primaryCalls))
+	})
+}
@@ -47,17 +47,25 @@ type (
 	courier struct {
 		smsClient   *smsClient
 		smtpClient  *smtpClient
+		httpClient  *httpClient
+		channels    map[string]*channel
+		routes      []route
+		health      channelHealthTracker
 		deps        Dependencies
 		failOnError bool
 	}
 )
 
 func NewCourier(ctx context.Context, deps Dependencies) Courier {
-	return &courier{
+	c := &courier{
 		smsClient:  newSMS(ctx, deps),
 		smtpClient: newSMTP(ctx, deps),
+		httpClient: newHTTP(ctx, deps),
+		routes:     newRoutes(ctx, deps),
 		deps:       deps,
 	}
+	c.channels = newChannels(ctx, deps, c)
+	return c
 }
 
 func (c *courier) FailOnDispatchError() {
@@ -5,28 +5,67 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
 )
 
 func (c *courier) DispatchMessage(ctx context.Context, msg Message) error {
+	channels, err := c.channelsFor(ctx, msg)
+	if err != nil {
+		// Routing errors are recorded like any other failed attempt so that the message is eventually abandoned
+		// instead of being retried forever.
+		reason := err.Error()
+		if e := new(herodot.DefaultError); errors.As(err, &e) && e.Reason() != "" {
+			reason = e.Reason()
+		}
+		c.recordDispatch(ctx, &MessageDispatch{
+			MessageID: msg.ID,
+			Status:    MessageDispatchStatusFailed,
+			Channel:   MessageDispatchChannelNone,
+			Error:     sqlxx.NullString(reason),
+		})
+	}
+
+	for k, ch := range channels {
+		err = c.dispatchVia(ctx, ch, msg)
+		if err == nil {
+			break
+		}
+		if _, ok := err.(*MessageRejectedError); ok {
+			break
+		}
+
+		if k < len(channels)-1 {
+			c.deps.Logger().
+				WithError(err).
+				WithField("message_id", msg.ID).
+				WithField("channel_id", ch.ID).
+				WithField("failover_channel_id", channels[k+1].ID).
+				Warn("Courier was unable to deliver message, failing over to the next channel.")
+		}
+	}
+
+	// Failing over to another channel is part of the same delivery round, so the send count is only incremented
+	// once no matter how many channels were tried. The individual attempts are kept in the message's dispatches.
+	// The message might already have been delivered at this point, which is why an error here must not prevent
+	// its status from being updated. Otherwise it would be queued again and delivered twice.
+	if err := c.deps.CourierPersister().IncrementMessageSendCount(ctx, msg.ID); err != nil {
+		c.deps.Logger().
+			WithError(err).
+			WithField("message_id", msg.ID).
+			Error(`Unable to increment the message send count.`)
+	}
+
 	messageStatus := MessageStatusSent
 	logMessage := "Courier sent out message."
-
-	switch msg.Type {
-	case MessageTypeEmail:
-		if err := c.dispatchEmail(ctx, msg); err != nil {
+	if err != nil {
+		if m, ok := err.(*MessageRejectedError); ok {
+			messageStatus = MessageStatusRejected
+			logMessage = m.Error()
+		} else {
 			return err
 		}
-	case MessageTypePhone:
-		if err := c.dispatchSMS(ctx, msg); err != nil {
-			if m, ok := err.(*MessageRejectedError); ok {
-				messageStatus = MessageStatusRejected
-				logMessage = m.Error()
-			} else {
-				return err
-			}
-		}
-	default:
-		return errors.Errorf("received unexpected message type: %d", msg.Type)
 	}
 
 	if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, messageStatus); err != nil {
@@ -47,6 +86,56 @@ func (c *courier) DispatchMessage(ctx context.Context, msg Message) error {
 	return nil
 }
 
+// dispatchVia makes a single attempt of delivering the message through the given channel and records it.
+func (c *courier) dispatchVia(ctx context.Context, ch *channel, msg Message) error {
+	dispatch := MessageDispatch{
+		MessageID: msg.ID,
+		Status:    MessageDispatchStatusSuccess,
+	}
+
+	start := time.Now()
+	var err error
+	switch {
+	case msg.Type == MessageTypeEmail && ch.Type == channelTypeSMTP:
+		dispatch.Channel = MessageDispatchChannelSMTP
+		dispatch.ResponseCode, err = c.dispatchEmail(ctx, ch.SMTP, msg)
+	case msg.Type == MessageTypeEmail && ch.Type == channelTypeHTTP:
+		dispatch.Channel = MessageDispatchChannelHTTP
+		dispatch.ResponseCode, err = c.dispatchMailerEmail(ctx, ch.RequestConfig, msg)
+	case msg.Type == MessageTypePhone && ch.Type == channelTypeHTTP:
+		dispatch.Channel = MessageDispatchChannelSMS
+		dispatch.ResponseCode, err = c.dispatchSMS(ctx, ch.RequestConfig, ch.From, msg)
+	default:
+		return errors.Errorf("courier channel %s of type %s can not deliver messages of type %s", ch.ID, ch.Type, msg.Type)
+	}
+	dispatch.Latency = time.Since(start).Milliseconds()
+
+	if err != nil {
+		dispatch.Status = MessageDispatchStatusFailed
+		dispatch.Error = sqlxx.NullString(err.Error())
+	}
+
+	// A rejected message is caused by the message itself, the channel is working fine.
+	if _, ok := err.(*MessageRejectedError); ok {
+		c.health.report(ch.ID, nil)
+	} else {
+		c.health.report(ch.ID, err)
+	}
+
+	c.recordDispatch(ctx, &dispatch)
+
+	return err
+}
+
+func (c *courier) recordDispatch(ctx context.Context, dispatch *MessageDispatch) {
+	if err := c.deps.CourierPersister().RecordDispatch(ctx, dispatch); err != nil {
+		c.deps.Logger().
+			WithError(err).
+			WithField("message_id", dispatch.MessageID).
+			Error(`Unable to record the message dispatch.`)
+	}
+}
+
 func (c *courier) DispatchQueue(ctx context.Context) error {
 	messages, err := c.deps.CourierPersister().NextMessages(ctx, 10)
 	if err != nil {
@@ -57,9 +146,10 @@ func (c *courier) DispatchQueue(ctx context.Context) error {
 	}
 
 	ttl := c.deps.CourierConfig(ctx).CourierMessageTTL()
+	maxRetries := c.deps.CourierConfig(ctx).CourierMessageRetries()
 
 	for k, msg := range messages {
-		if time.Now().After(msg.CreatedAt.Add(ttl)) {
+		if msg.SendCount > maxRetries || time.Now().After(msg.CreatedAt.Add(ttl)) {
 			if err := c.deps.CourierPersister().SetMessageStatus(ctx, msg.ID, MessageStatusAbandoned); err != nil {
 				if c.failOnError {
 					return err
@@ -72,14 +162,26 @@ func (c *courier) DispatchQueue(ctx context.Context) error {
 		} else {
 			if err := c.DispatchMessage(ctx, msg); err != nil {
 				for _, replace := range messages[k:] {
-					if err := c.deps.CourierPersister().SetMessageStatus(ctx, replace.ID, MessageStatusQueued); err != nil {
+					status := MessageStatusQueued
+					if replace.ID == msg.ID && msg.SendCount+1 > maxRetries {
+						// This was the last attempt, move the message to the dead letter state. The error of the
+						// failed attempt is kept in the message's dispatches.
+						status = MessageStatusAbandoned
+						c.deps.Logger().
+							WithError(err).
+							WithField("message_id", msg.ID).
+							WithField("message_send_count", msg.SendCount+1).
+							Warn("Courier exhausted all retries for message and abandoned it.")
+					}
+
+					if err := c.deps.CourierPersister().SetMessageStatus(ctx, replace.ID, status); err != nil {
 						if c.failOnError {
 							return err
 						}
 						c.deps.Logger().
 							WithError(err).
 							WithField("message_id", replace.ID).
-							Error(`Unable to reset the failed message's status to "queued".`)
+							Errorf(`Unable to reset the failed message's status to "%s".`, status)
 					}
 				}
 
@@ -2,15 +2,21 @@ package courier_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ory/kratos/courier"
 	templates "github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/courier/template/sms"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
 )
@@ -51,3 +57,173 @@ func TestMessageTTL(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, id, message.ID)
 }
+
+func TestMessageDispatches(t *testing.T) {
+	var statusCode int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte("gateway says no"))
+	}))
+	t.Cleanup(srv.Close)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMSRequestConfig, fmt.Sprintf(`{
+		"url": "%s",
+		"method": "POST",
+		"body": "file://./stub/request.config.twilio.jsonnet"
+	}`, srv.URL))
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, true)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(config.ViperKeyCourierMessageRetries, 1)
+	reg.Logger().Level = logrus.TraceLevel
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+
+	queue := func(t *testing.T) uuid.UUID {
+		id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{
+			To:   "+12065550101",
+			Body: "test-sms-body",
+		}))
+		require.NoError(t, err)
+		return id
+	}
+
+	fetch := func(t *testing.T, id uuid.UUID) *courier.Message {
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("case=records successful dispatch", func(t *testing.T) {
+		statusCode = http.StatusOK
+		id := queue(t)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		m := fetch(t, id)
+		assert.Equal(t, courier.MessageStatusSent, m.Status)
+		assert.Equal(t, 1, m.SendCount)
+		require.Len(t, m.Dispatches, 1)
+		assert.Equal(t, courier.MessageDispatchStatusSuccess, m.Dispatches[0].Status)
+		assert.Equal(t, courier.MessageDispatchChannelSMS, m.Dispatches[0].Channel)
+		assert.Equal(t, http.StatusOK, m.Dispatches[0].ResponseCode)
+		assert.Empty(t, m.Dispatches[0].Error)
+	})
+
+	t.Run("case=records rejected dispatch", func(t *testing.T) {
+		statusCode = http.StatusBadRequest
+		id := queue(t)
+
+		require.NoError(t, c.DispatchQueue(ctx))
+
+		m := fetch(t, id)
+		assert.Equal(t, courier.MessageStatusRejected, m.Status)
+		require.Len(t, m.Dispatches, 1)
+		assert.Equal(t, courier.MessageDispatchStatusFailed, m.Dispatches[0].Status)
+		assert.Equal(t, http.StatusBadRequest, m.Dispatches[0].ResponseCode)
+		assert.Contains(t, m.Dispatches[0].Error, "gateway says no")
+	})
+
+	t.Run("case=abandons message after exhausting retries", func(t *testing.T) {
+		statusCode = http.StatusForbidden
+		id := queue(t)
+
+		require.Error(t, c.DispatchQueue(ctx))
+		assert.Equal(t, courier.MessageStatusQueued, fetch(t, id).Status)
+
+		require.Error(t, c.DispatchQueue(ctx))
+
+		m := fetch(t, id)
+		assert.Equal(t, courier.MessageStatusAbandoned, m.Status)
+		assert.Equal(t, 2, m.SendCount)
+		require.Len(t, m.Dispatches, 2)
+		for _, d := range m.Dispatches {
+			assert.Equal(t, courier.MessageDispatchStatusFailed, d.Status)
+			assert.Equal(t, http.StatusForbidden, d.ResponseCode)
+			assert.Contains(t, d.Error, "gateway says no")
+		}
+
+		_, err := reg.CourierPersister().NextMessages(ctx, 10)
+		require.ErrorIs(t, err, courier.ErrQueueEmpty)
+	})
+}
+
+func TestMessageSendCountWithFailover(t *testing.T) {
+	var calls int32
+	newGateway := func(t *testing.T) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	primary, secondary := newGateway(t), newGateway(t)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(config.ViperKeyCourierChannels, []map[string]interface{}{
+		{"id": "sms-primary", "type": "http", "request_config": map[string]interface{}{"url": primary.URL, "method": "POST", "body": "file://./stub/request.config.twilio.jsonnet"}},
+		{"id": "sms-secondary", "type": "http", "request_config": map[string]interface{}{"url": secondary.URL, "method": "POST", "body": "file://./stub/request.config.twilio.jsonnet"}},
+	})
+	conf.MustSet(config.ViperKeyCourierRoutes, []map[string]interface{}{
+		{"message_type": "phone", "channels": []string{"sms-primary", "sms-secondary"}},
+	})
+	conf.MustSet(config.ViperKeyCourierMessageRetries, 3)
+	reg.Logger().Level = logrus.TraceLevel
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+
+	id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{To: "+12065550101", Body: "test-sms-body"}))
+	require.NoError(t, err)
+
+	// Each round tries both channels but counts as a single delivery attempt, so the message is only abandoned
+	// after the initial attempt and three retries.
+	for round := 1; round <= 4; round++ {
+		require.Error(t, c.DispatchQueue(ctx))
+
+		m, err := reg.CourierPersister().FetchMessage(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, round, m.SendCount)
+		assert.Len(t, m.Dispatches, 2*round)
+		if round < 4 {
+			assert.Equal(t, courier.MessageStatusQueued, m.Status, "round %d", round)
+		} else {
+			assert.Equal(t, courier.MessageStatusAbandoned, m.Status)
+		}
+	}
+	assert.EqualValues(t, 8, atomic.LoadInt32(&calls))
+
+	_, err = reg.CourierPersister().NextMessages(ctx, 10)
+	require.ErrorIs(t, err, courier.ErrQueueEmpty)
+}
+
+func TestMessageWithoutChannel(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "http://foo.url")
+	conf.MustSet(config.ViperKeyCourierSMSEnabled, false)
+	conf.MustSet(config.ViperKeyCourierMessageRetries, 1)
+	reg.Logger().Level = logrus.TraceLevel
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+
+	id, err := c.QueueSMS(ctx, sms.NewTestStub(reg, &sms.TestStubModel{To: "+12065550101", Body: "test-sms-body"}))
+	require.NoError(t, err)
+
+	require.Error(t, c.DispatchQueue(ctx))
+	require.Error(t, c.DispatchQueue(ctx))
+
+	m, err := reg.CourierPersister().FetchMessage(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusAbandoned, m.Status)
+	assert.Equal(t, 2, m.SendCount)
+	require.Len(t, m.Dispatches, 2)
+	for _, d := range m.Dispatches {
+		assert.Equal(t, courier.MessageDispatchStatusFailed, d.Status)
+		assert.Equal(t, courier.MessageDispatchChannelNone, d.Channel)
+		assert.Contains(t, d.Error, "courier.sms.enabled is set to false")
+	}
+}
@@ -45,6 +45,8 @@ func GetEmailTemplateType(t EmailTemplate) (TemplateType, error) {
 		return TypeVerificationValid, nil
 	case *email.TestStub:
 		return TypeTestStub, nil
+	case *email.CodeMessage:
+		return TypeCode, nil
 	default:
 		return "", errors.Errorf("unexpected template type")
 	}
@@ -82,6 +84,12 @@ func NewEmailTemplateFromMessage(d template.Dependencies, msg Message) (EmailTem
 			return nil, err
 		}
 		return email.NewTestStub(d, &t), nil
+	case TypeCode:
+		var t email.CodeMessageModel
+		if err := json.Unmarshal(msg.TemplateData, &t); err != nil {
+			return nil, err
+		}
+		return email.NewCodeMessage(d, &t), nil
 	default:
 		return nil, errors.Errorf("received unexpected message template type: %s", msg.TemplateType)
 	}
@@ -20,6 +20,7 @@ func TestGetTemplateType(t *testing.T) {
 		courier.TypeVerificationInvalid: &email.VerificationInvalid{},
 		courier.TypeVerificationValid:   &email.VerificationValid{},
 		courier.TypeTestStub:            &email.TestStub{},
+		courier.TypeCode:                &email.CodeMessage{},
 	} {
 		t.Run(fmt.Sprintf("case=%s", expectedType), func(t *testing.T) {
 			actualType, err := courier.GetEmailTemplateType(tmpl)
@@ -39,6 +40,7 @@ func TestNewEmailTemplateFromMessage(t *testing.T) {
 		courier.TypeVerificationInvalid: email.NewVerificationInvalid(reg, &email.VerificationInvalidModel{To: "baz"}),
 		courier.TypeVerificationValid:   email.NewVerificationValid(reg, &email.VerificationValidModel{To: "faz", VerificationURL: "http://bar.foo"}),
 		courier.TypeTestStub:            email.NewTestStub(reg, &email.TestStubModel{To: "far", Subject: "test subject", Body: "test body"}),
+		courier.TypeCode:                email.NewCodeMessage(reg, &email.CodeMessageModel{To: "boo", Code: "123456"}),
 	} {
 		t.Run(fmt.Sprintf("case=%s", tmplType), func(t *testing.T) {
 			tmplData, err := json.Marshal(expectedTmpl)
@@ -0,0 +1,317 @@
+package courier
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	AdminRouteCourier       = "/courier"
+	AdminRouteMessages      = AdminRouteCourier + "/messages"
+	AdminRouteMessage       = AdminRouteMessages + "/:id"
+	AdminRouteMessageRetry  = AdminRouteMessage + "/retry"
+	AdminRouteMessageCancel = AdminRouteMessage + "/cancel"
+)
+
+type (
+	handlerDependencies interface {
+		PersistenceProvider
+		x.WriterProvider
+		x.LoggingProvider
+		config.Provider
+	}
+	HandlerProvider interface {
+		CourierHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(AdminRouteMessages, h.adminListMessages)
+	admin.GET(AdminRouteMessage, h.adminGetMessage)
+	admin.POST(AdminRouteMessageRetry, h.adminRetryMessage)
+	admin.POST(AdminRouteMessageCancel, h.adminCancelMessage)
+}
+
+// A list of messages.
+// swagger:model courierMessageList
+// nolint:deadcode,unused
+type courierMessageList []Message
+
+// swagger:parameters adminListCourierMessages
+// nolint:deadcode,unused
+type adminListCourierMessages struct {
+	x.PaginationParams
+
+	// Status filters out messages based on status. If no value is provided, it doesn't take effect on filter.
+	//
+	// required: false
+	// in: query
+	Status string `json:"status"`
+
+	// Type filters out messages based on their type, which is either `email` or `phone`.
+	//
+	// required: false
+	// in: query
+	Type string `json:"type"`
+
+	// Recipient filters out messages based on recipient. If no value is provided, it doesn't take effect on filter.
+	//
+	// required: false
+	// in: query
+	Recipient string `json:"recipient"`
+
+	// CreatedAfter only returns messages created at or after the given RFC3339 timestamp.
+	//
+	// required: false
+	// in: query
+	CreatedAfter time.Time `json:"created_after"`
+
+	// CreatedBefore only returns messages created before the given RFC3339 timestamp.
+	//
+	// required: false
+	// in: query
+	CreatedBefore time.Time `json:"created_before"`
+}
+
+// swagger:route GET /admin/courier/messages v0alpha2 adminListCourierMessages
+//
+// List Messages
+//
+// Lists all messages by given status, type, recipient and creation time, newest first.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: courierMessageList
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) adminListMessages(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filter, err := parseMessagesFilter(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	page, itemsPerPage := x.ParsePagination(r)
+	l, total, err := h.r.CourierPersister().ListMessages(r.Context(), filter, page, itemsPerPage)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), AdminRouteMessages), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, l)
+}
+
+func parseMessagesFilter(r *http.Request) (ListMessagesFilter, error) {
+	var f ListMessagesFilter
+	q := r.URL.Query()
+
+	if s := q.Get("status"); s != "" {
+		status, err := ToMessageStatus(s)
+		if err != nil {
+			return f, err
+		}
+		f.Status = status
+	}
+
+	if t := q.Get("type"); t != "" {
+		mt, err := ToMessageType(t)
+		if err != nil {
+			return f, err
+		}
+		f.Type = mt
+	}
+
+	f.Recipient = q.Get("recipient")
+
+	for _, p := range []struct {
+		key string
+		t   *time.Time
+	}{
+		{key: "created_after", t: &f.CreatedAfter},
+		{key: "created_before", t: &f.CreatedBefore},
+	} {
+		if v := q.Get(p.key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return f, errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to parse parameter %s as an RFC3339 timestamp.", p.key))
+			}
+			*p.t = t
+		}
+	}
+
+	return f, nil
+}
+
+// swagger:parameters adminGetCourierMessage adminRetryCourierMessage adminCancelCourierMessage
+// nolint:deadcode,unused
+type adminGetCourierMessage struct {
+	// ID is the message's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/courier/messages/{id} v0alpha2 adminGetCourierMessage
+//
+// Get a Message
+//
+// Returns a single message including its delivery status.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: message
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminGetMessage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	m, err := h.r.CourierPersister().FetchMessage(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, m)
+}
+
+// swagger:route POST /admin/courier/messages/{id}/retry v0alpha2 adminRetryCourierMessage
+//
+// Retry a Message
+//
+// Queues a copy of a message which was abandoned, rejected or cancelled so that the courier attempts to deliver it
+// again. The original message is left untouched. Messages which are still queued or in processing can not be retried.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       201: message
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) adminRetryMessage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	m, err := h.r.CourierPersister().FetchMessage(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if m.Status == MessageStatusQueued || m.Status == MessageStatusProcessing {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.WithReasonf("Message can not be retried because it is still %s.", m.Status)))
+		return
+	}
+
+	retry := &Message{
+		Type:         m.Type,
+		Recipient:    m.Recipient,
+		Body:         m.Body,
+		Subject:      m.Subject,
+		TemplateType: m.TemplateType,
+		TemplateData: m.TemplateData,
+	}
+	if err := h.r.CourierPersister().AddMessage(r.Context(), retry); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Logger().
+		WithField("message_id", retry.ID).
+		WithField("retried_message_id", m.ID).
+		Info("Message was queued again for delivery.")
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), AdminRouteMessages, retry.ID.String()).String(),
+		retry,
+	)
+}
+
+// swagger:route POST /admin/courier/messages/{id}/cancel v0alpha2 adminCancelCourierMessage
+//
+// Cancel a Message
+//
+// Cancels a message which has not yet been picked up by the courier. Only queued messages can be cancelled.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: message
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) adminCancelMessage(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	if err := h.r.CourierPersister().CancelMessage(r.Context(), id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	m, err := h.r.CourierPersister().FetchMessage(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, m)
+}
@@ -0,0 +1,97 @@
+package courier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/request"
+)
+
+type httpDataModel struct {
+	Recipient    string          `json:"recipient"`
+	Subject      string          `json:"subject"`
+	Body         string          `json:"body"`
+	HTMLBody     string          `json:"html_body"`
+	TemplateType TemplateType    `json:"template_type"`
+	TemplateData json.RawMessage `json:"template_data"`
+}
+
+type httpClient struct {
+	RequestConfig json.RawMessage
+}
+
+func newHTTP(ctx context.Context, deps Dependencies) *httpClient {
+	return &httpClient{
+		RequestConfig: deps.CourierConfig(ctx).CourierEmailRequestConfig(),
+	}
+}
+
+func (c *courier) dispatchMailerEmail(ctx context.Context, requestConfig json.RawMessage, msg Message) (int, error) {
+	data := &httpDataModel{
+		Recipient:    msg.Recipient,
+		Subject:      msg.Subject,
+		Body:         msg.Body,
+		TemplateType: msg.TemplateType,
+	}
+	if len(msg.TemplateData) > 0 {
+		data.TemplateData = msg.TemplateData
+	}
+
+	// A broken HTML template fails the dispatch so that the message is retried or eventually abandoned instead
+	// of being delivered without its HTML part.
+	tmpl, err := c.smtpClient.NewTemplateFromMessage(c.deps, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	data.HTMLBody, err = tmpl.EmailBody(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, err := request.NewBuilder(requestConfig, c.deps.HTTPClient(ctx), c.deps.Logger())
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := builder.BuildRequest(data)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := c.deps.HTTPClient(ctx).Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+	case res.StatusCode == http.StatusBadRequest:
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return res.StatusCode, err
+		}
+		return res.StatusCode, NewMessageRejectedError(res.StatusCode, string(b))
+	default:
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return res.StatusCode, err
+		}
+		return res.StatusCode, errors.Errorf("Status: %s, body: %s", http.StatusText(res.StatusCode), string(b))
+	}
+
+	c.deps.Logger().
+		WithField("message_id", msg.ID).
+		WithField("message_type", msg.Type).
+		WithField("message_template_type", msg.TemplateType).
+		WithField("message_subject", msg.Subject).
+		Debug("Courier sent out message.")
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,145 @@
+package courier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	templates "github.com/ory/kratos/courier/template/email"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestQueueEmailHTTP(t *testing.T) {
+	type sendEmailRequestBody struct {
+		Recipient    string          `json:"recipient"`
+		Subject      string          `json:"subject"`
+		Body         string          `json:"body"`
+		HTMLBody     string          `json:"html_body"`
+		TemplateType string          `json:"template_type"`
+		TemplateData json.RawMessage `json:"template_data"`
+	}
+
+	var actual []sendEmailRequestBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer api-key", r.Header.Get("Authorization"))
+
+		rb, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var body sendEmailRequestBody
+		require.NoError(t, json.Unmarshal(rb, &body))
+		actual = append(actual, body)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierDeliveryStrategy, "http")
+	conf.MustSet(config.ViperKeyCourierHTTPRequestConfig, fmt.Sprintf(`{
+		"url": "%s",
+		"method": "POST",
+		"body": "file://./stub/request.config.mailer.jsonnet",
+		"auth": {
+			"type": "api_key",
+			"config": {
+				"in": "header",
+				"name": "Authorization",
+				"value": "Bearer api-key"
+			}
+		}
+	}`, srv.URL))
+	reg.Logger().Level = logrus.TraceLevel
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+
+	id, err := c.QueueEmail(ctx, templates.NewTestStub(reg, &templates.TestStubModel{
+		To:      "test-recipient-1@example.org",
+		Subject: "test-subject-1",
+		Body:    "test-body-1",
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, c.DispatchQueue(ctx))
+
+	require.Len(t, actual, 1)
+	assert.Equal(t, "test-recipient-1@example.org", actual[0].Recipient)
+	assert.Equal(t, "stub email subject test-subject-1", actual[0].Subject)
+	assert.Equal(t, "stub email body test-body-1", actual[0].Body)
+	assert.Equal(t, "stub email body test-body-1", actual[0].HTMLBody)
+	assert.Equal(t, "stub", actual[0].TemplateType)
+	assert.JSONEq(t, `{"To":"test-recipient-1@example.org","Subject":"test-subject-1","Body":"test-body-1"}`, string(actual[0].TemplateData))
+
+	m, err := reg.CourierPersister().FetchMessage(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusSent, m.Status)
+	require.Len(t, m.Dispatches, 1)
+	assert.Equal(t, courier.MessageDispatchChannelHTTP, m.Dispatches[0].Channel)
+	assert.Equal(t, http.StatusAccepted, m.Dispatches[0].ResponseCode)
+}
+
+type brokenHTMLTemplate struct {
+	*templates.TestStub
+}
+
+func (t *brokenHTMLTemplate) EmailBody(context.Context) (string, error) {
+	return "", errors.New("broken template")
+}
+
+func TestQueueEmailHTTPBrokenHTMLTemplate(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyCourierDeliveryStrategy, "http")
+	conf.MustSet(config.ViperKeyCourierHTTPRequestConfig, fmt.Sprintf(`{
+		"url": "%s",
+		"method": "POST",
+		"body": "file://./stub/request.config.mailer.jsonnet"
+	}`, srv.URL))
+
+	ctx := context.Background()
+	c := reg.Courier(ctx)
+	c.SetNewEmailTemplateFromMessage(func(d template.Dependencies, msg courier.Message) (courier.EmailTemplate, error) {
+		tmpl, err := courier.NewEmailTemplateFromMessage(d, msg)
+		if err != nil {
+			return nil, err
+		}
+		return &brokenHTMLTemplate{TestStub: tmpl.(*templates.TestStub)}, nil
+	})
+
+	id, err := c.QueueEmail(ctx, templates.NewTestStub(reg, &templates.TestStubModel{
+		To:      "test-recipient-1@example.org",
+		Subject: "test-subject-1",
+		Body:    "test-body-1",
+	}))
+	require.NoError(t, err)
+
+	require.Error(t, c.DispatchQueue(ctx))
+	assert.Zero(t, requests)
+
+	m, err := reg.CourierPersister().FetchMessage(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, courier.MessageStatusQueued, m.Status)
+	assert.Equal(t, 1, m.SendCount)
+	require.Len(t, m.Dispatches, 1)
+	assert.Equal(t, courier.MessageDispatchStatusFailed, m.Dispatches[0].Status)
+}
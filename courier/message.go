@@ -2,13 +2,21 @@ package courier
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/stringsx"
 
 	"github.com/ory/kratos/corp"
 )
 
+// A Message's Status
+//
+// swagger:model courierMessageStatus
 type MessageStatus int
 
 const (
@@ -17,8 +25,103 @@ const (
 	MessageStatusProcessing
 	MessageStatusAbandoned
 	MessageStatusRejected // Service won't send this message for some unrecoverable reasons (incorrect phone number e.g.)
+	MessageStatusCancelled
 )
 
+const (
+	messageStatusQueuedText     = "queued"
+	messageStatusSentText       = "sent"
+	messageStatusProcessingText = "processing"
+	messageStatusAbandonedText  = "abandoned"
+	messageStatusRejectedText   = "rejected"
+	messageStatusCancelledText  = "cancelled"
+)
+
+func ToMessageStatus(str string) (MessageStatus, error) {
+	s := stringsx.SwitchExact(str)
+	switch {
+	case s.AddCase(MessageStatusQueued.String()):
+		return MessageStatusQueued, nil
+	case s.AddCase(MessageStatusSent.String()):
+		return MessageStatusSent, nil
+	case s.AddCase(MessageStatusProcessing.String()):
+		return MessageStatusProcessing, nil
+	case s.AddCase(MessageStatusAbandoned.String()):
+		return MessageStatusAbandoned, nil
+	case s.AddCase(MessageStatusRejected.String()):
+		return MessageStatusRejected, nil
+	case s.AddCase(MessageStatusCancelled.String()):
+		return MessageStatusCancelled, nil
+	default:
+		return 0, errors.WithStack(herodot.ErrBadRequest.WithWrap(s.ToUnknownCaseErr()).WithReason("Message status is not valid"))
+	}
+}
+
+func (ms MessageStatus) String() string {
+	switch ms {
+	case MessageStatusQueued:
+		return messageStatusQueuedText
+	case MessageStatusSent:
+		return messageStatusSentText
+	case MessageStatusProcessing:
+		return messageStatusProcessingText
+	case MessageStatusAbandoned:
+		return messageStatusAbandonedText
+	case MessageStatusRejected:
+		return messageStatusRejectedText
+	case MessageStatusCancelled:
+		return messageStatusCancelledText
+	default:
+		return ""
+	}
+}
+
+func (ms MessageStatus) IsValid() error {
+	switch ms {
+	case MessageStatusQueued, MessageStatusSent, MessageStatusProcessing, MessageStatusAbandoned, MessageStatusRejected, MessageStatusCancelled:
+		return nil
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Message status is not valid"))
+	}
+}
+
+func (ms MessageStatus) MarshalJSON() ([]byte, error) {
+	if err := ms.IsValid(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(ms.String())
+}
+
+func (ms *MessageStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	x, err := ToMessageStatus(s)
+	if err != nil {
+		return err
+	}
+
+	*ms = x
+	return nil
+}
+
+// IsFinal returns true if the courier will not attempt to deliver a message with this status anymore.
+func (ms MessageStatus) IsFinal() bool {
+	switch ms {
+	case MessageStatusSent, MessageStatusAbandoned, MessageStatusRejected, MessageStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// A Message's Type
+//
+// It can either be `email` or `phone`
+//
+// swagger:model courierMessageType
 type MessageType int
 
 const (
@@ -26,22 +129,99 @@ const (
 	MessageTypePhone
 )
 
-// swagger:ignore
+const (
+	messageTypeEmailText = "email"
+	messageTypePhoneText = "phone"
+)
+
+func ToMessageType(str string) (MessageType, error) {
+	s := stringsx.SwitchExact(str)
+	switch {
+	case s.AddCase(MessageTypeEmail.String()):
+		return MessageTypeEmail, nil
+	case s.AddCase(MessageTypePhone.String()):
+		return MessageTypePhone, nil
+	default:
+		return 0, errors.WithStack(herodot.ErrBadRequest.WithWrap(s.ToUnknownCaseErr()).WithReason("Message type is not valid"))
+	}
+}
+
+func (mt MessageType) String() string {
+	switch mt {
+	case MessageTypeEmail:
+		return messageTypeEmailText
+	case MessageTypePhone:
+		return messageTypePhoneText
+	default:
+		return ""
+	}
+}
+
+func (mt MessageType) IsValid() error {
+	switch mt {
+	case MessageTypeEmail, MessageTypePhone:
+		return nil
+	default:
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("Message type is not valid"))
+	}
+}
+
+func (mt MessageType) MarshalJSON() ([]byte, error) {
+	if err := mt.IsValid(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mt.String())
+}
+
+func (mt *MessageType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	x, err := ToMessageType(s)
+	if err != nil {
+		return err
+	}
+
+	*mt = x
+	return nil
+}
+
+// swagger:model message
 type Message struct {
-	ID           uuid.UUID     `json:"-" faker:"-" db:"id"`
-	NID          uuid.UUID     `json:"-"  faker:"-" db:"nid"`
-	Status       MessageStatus `json:"-" db:"status"`
-	Type         MessageType   `json:"-" db:"type"`
-	Recipient    string        `json:"-" db:"recipient"`
-	Body         string        `json:"-" db:"body"`
-	Subject      string        `json:"-" db:"subject"`
-	TemplateType TemplateType  `json:"-" db:"template_type"`
-	TemplateData []byte        `json:"-" db:"template_data"`
+	// required: true
+	ID  uuid.UUID `json:"id" faker:"-" db:"id"`
+	NID uuid.UUID `json:"-"  faker:"-" db:"nid"`
+	// required: true
+	Status MessageStatus `json:"status" db:"status"`
+	// required: true
+	Type MessageType `json:"type" db:"type"`
+	// required: true
+	Recipient string `json:"recipient" db:"recipient"`
+	// required: true
+	Body string `json:"body" db:"body"`
+	// required: true
+	Subject string `json:"subject" db:"subject"`
+	// required: true
+	TemplateType TemplateType `json:"template_type" db:"template_type"`
+	TemplateData []byte       `json:"-" db:"template_data"`
+	// SendCount is the number of times the courier attempted to deliver this message.
+	//
+	// required: true
+	SendCount int `json:"send_count" db:"send_count"`
+	// Dispatches contains the delivery attempts of this message, oldest first. It is only set when a single
+	// message is fetched.
+	Dispatches []MessageDispatch `json:"dispatches,omitempty" faker:"-" db:"-"`
 
 	// CreatedAt is a helper struct field for gobuffalo.pop.
-	CreatedAt time.Time `json:"-" faker:"-" db:"created_at"`
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
 	// UpdatedAt is a helper struct field for gobuffalo.pop.
-	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
 }
 
 func (m Message) TableName(ctx context.Context) string {
@@ -0,0 +1,102 @@
+package courier
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+)
+
+// A Message Dispatch's Status
+//
+// It can either be `failed` or `success`.
+//
+// swagger:model courierMessageDispatchStatus
+type MessageDispatchStatus string
+
+const (
+	MessageDispatchStatusFailed  MessageDispatchStatus = "failed"
+	MessageDispatchStatusSuccess MessageDispatchStatus = "success"
+)
+
+// The channel a Message Dispatch was sent through
+//
+// swagger:model courierMessageDispatchChannel
+type MessageDispatchChannel string
+
+const (
+	MessageDispatchChannelSMTP MessageDispatchChannel = "smtp"
+	MessageDispatchChannelSMS  MessageDispatchChannel = "sms"
+	MessageDispatchChannelHTTP MessageDispatchChannel = "http"
+
+	// MessageDispatchChannelNone is recorded if no channel was able to deliver the message, for example because
+	// the route points to a channel which is not configured.
+	MessageDispatchChannelNone MessageDispatchChannel = "none"
+)
+
+// MessageDispatch represents a single attempt of delivering a courier message.
+//
+// swagger:model messageDispatch
+type MessageDispatch struct {
+	// The ID of this message dispatch
+	//
+	// required: true
+	ID uuid.UUID `json:"id" db:"id"`
+
+	// The ID of the message being dispatched
+	//
+	// required: true
+	MessageID uuid.UUID `json:"message_id" db:"message_id"`
+
+	// The status of this dispatch, either `failed` or `success`
+	//
+	// required: true
+	Status MessageDispatchStatus `json:"status" db:"status"`
+
+	// The channel which was used to deliver the message
+	//
+	// required: true
+	Channel MessageDispatchChannel `json:"channel" db:"channel"`
+
+	// The response code returned by the upstream server, e.g. the SMTP reply code or the HTTP status code. It is
+	// zero if no response was received, for example because the upstream server could not be reached.
+	//
+	// required: true
+	ResponseCode int `json:"response_code" db:"response_code"`
+
+	// The error which occurred while delivering the message, if any
+	Error sqlxx.NullString `json:"error,omitempty" db:"error"`
+
+	// How long the delivery attempt took in milliseconds
+	//
+	// required: true
+	Latency int64 `json:"latency_ms" db:"latency_ms"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+func (m MessageDispatch) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "courier_message_dispatches")
+}
+
+func (m *MessageDispatch) GetID() uuid.UUID {
+	return m.ID
+}
+
+func (m *MessageDispatch) GetNID() uuid.UUID {
+	return m.NID
+}
@@ -11,6 +11,7 @@ import (
 	uuid "github.com/gofrs/uuid"
 	gomock "github.com/golang/mock/gomock"
 	courier "github.com/ory/kratos/courier"
+	template "github.com/ory/kratos/courier/template"
 	mail "github.com/ory/mail/v3"
 )
 
@@ -95,6 +96,30 @@ func (mr *MockCourierMockRecorder) QueueSMS(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSMS", reflect.TypeOf((*MockCourier)(nil).QueueSMS), arg0, arg1)
 }
 
+// SetGetEmailTemplateType mocks base method.
+func (m *MockCourier) SetGetEmailTemplateType(arg0 func(courier.EmailTemplate) (courier.TemplateType, error)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetGetEmailTemplateType", arg0)
+}
+
+// SetGetEmailTemplateType indicates an expected call of SetGetEmailTemplateType.
+func (mr *MockCourierMockRecorder) SetGetEmailTemplateType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGetEmailTemplateType", reflect.TypeOf((*MockCourier)(nil).SetGetEmailTemplateType), arg0)
+}
+
+// SetNewEmailTemplateFromMessage mocks base method.
+func (m *MockCourier) SetNewEmailTemplateFromMessage(arg0 func(template.Dependencies, courier.Message) (courier.EmailTemplate, error)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNewEmailTemplateFromMessage", arg0)
+}
+
+// SetNewEmailTemplateFromMessage indicates an expected call of SetNewEmailTemplateFromMessage.
+func (mr *MockCourierMockRecorder) SetNewEmailTemplateFromMessage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNewEmailTemplateFromMessage", reflect.TypeOf((*MockCourier)(nil).SetNewEmailTemplateFromMessage), arg0)
+}
+
 // SmtpDialer mocks base method.
 func (m *MockCourier) SmtpDialer() *mail.Dialer {
 	m.ctrl.T.Helper()
@@ -2,12 +2,18 @@ package courier
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
 )
 
-var ErrQueueEmpty = errors.New("queue is empty")
+var (
+	ErrQueueEmpty            = errors.New("queue is empty")
+	ErrMessageNotCancellable = herodot.ErrConflict.WithReason("Only messages which are still queued can be cancelled.")
+)
 
 type (
 	Persister interface {
@@ -18,8 +24,34 @@ type (
 		SetMessageStatus(context.Context, uuid.UUID, MessageStatus) error
 
 		LatestQueuedMessage(ctx context.Context) (*Message, error)
+
+		// ListMessages returns a page of messages matching the filter, ordered by creation date (newest first),
+		// and the total number of messages matching the filter.
+		ListMessages(ctx context.Context, filter ListMessagesFilter, page, itemsPerPage int) ([]Message, int64, error)
+
+		// FetchMessage returns a message including all of its dispatches.
+		FetchMessage(context.Context, uuid.UUID) (*Message, error)
+
+		// CancelMessage cancels a message that has not yet been picked up by the courier.
+		CancelMessage(context.Context, uuid.UUID) error
+
+		// RecordDispatch stores a single delivery attempt through one channel.
+		RecordDispatch(context.Context, *MessageDispatch) error
+
+		// IncrementMessageSendCount counts one delivery round of the message, regardless of how many channels
+		// were tried during that round.
+		IncrementMessageSendCount(context.Context, uuid.UUID) error
 	}
 	PersistenceProvider interface {
 		CourierPersister() Persister
 	}
+
+	// ListMessagesFilter narrows down the messages returned by Persister.ListMessages. Zero values are ignored.
+	ListMessagesFilter struct {
+		Status        MessageStatus
+		Type          MessageType
+		Recipient     string
+		CreatedAfter  time.Time
+		CreatedBefore time.Time
+	}
 )
@@ -8,8 +8,6 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/ory/herodot"
-
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/kratos/request"
@@ -67,38 +65,34 @@ func (c *courier) QueueSMS(ctx context.Context, t SMSTemplate) (uuid.UUID, error
 	return message.ID, nil
 }
 
-func (c *courier) dispatchSMS(ctx context.Context, msg Message) error {
-	if !c.deps.CourierConfig(ctx).CourierSMSEnabled() {
-		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an sms but courier.sms.enabled is set to false!"))
-	}
-
+func (c *courier) dispatchSMS(ctx context.Context, requestConfig json.RawMessage, from string, msg Message) (int, error) {
 	tmpl, err := c.smsClient.NewTemplateFromMessage(c.deps, msg)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	body, err := tmpl.SMSBody(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	builder, err := request.NewBuilder(c.smsClient.RequestConfig, c.deps.HTTPClient(ctx), c.deps.Logger())
+	builder, err := request.NewBuilder(requestConfig, c.deps.HTTPClient(ctx), c.deps.Logger())
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	req, err := builder.BuildRequest(&sendSMSRequestBody{
 		To:   msg.Recipient,
-		From: c.deps.CourierConfig(ctx).CourierSMSFrom(),
+		From: from,
 		Body: body,
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	res, err := c.deps.HTTPClient(ctx).Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer res.Body.Close()
@@ -109,16 +103,16 @@ func (c *courier) dispatchSMS(ctx context.Context, msg Message) error {
 	case http.StatusBadRequest:
 		b, err := io.ReadAll(res.Body)
 		if err != nil {
-			return err
+			return res.StatusCode, err
 		}
-		return NewMessageRejectedError(res.StatusCode, string(b))
+		return res.StatusCode, NewMessageRejectedError(res.StatusCode, string(b))
 	default:
 		b, err := io.ReadAll(res.Body)
 		if err != nil {
-			return err
+			return res.StatusCode, err
 		}
-		return errors.Errorf("Status: %s, body: %s", http.StatusText(res.StatusCode), string(b))
+		return res.StatusCode, errors.Errorf("Status: %s, body: %s", http.StatusText(res.StatusCode), string(b))
 	}
 
-	return nil
+	return res.StatusCode, nil
 }
@@ -5,6 +5,8 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net/textproto"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -19,16 +21,29 @@ import (
 	gomail "github.com/ory/mail/v3"
 )
 
+// smtpStatusOK is the reply code an SMTP server answers with once it accepted a message.
+const smtpStatusOK = 250
+
 type smtpClient struct {
 	*gomail.Dialer
 
+	// sender overrides the sender configured in courier.smtp. It is only set for named channels.
+	sender *config.CourierChannelSMTP
+
 	GetTemplateType        func(t EmailTemplate) (TemplateType, error)
 	NewTemplateFromMessage func(d template.Dependencies, msg Message) (EmailTemplate, error)
 }
 
 func newSMTP(ctx context.Context, deps Dependencies) *smtpClient {
-	uri := deps.CourierConfig(ctx).CourierSMTPURL()
+	return &smtpClient{
+		Dialer: newSMTPDialer(deps.CourierConfig(ctx).CourierSMTPURL()),
 
+		GetTemplateType:        GetEmailTemplateType,
+		NewTemplateFromMessage: NewEmailTemplateFromMessage,
+	}
+}
+
+func newSMTPDialer(uri *url.URL) *gomail.Dialer {
 	password, _ := uri.User.Password()
 	port, _ := strconv.ParseInt(uri.Port(), 10, 0)
 
@@ -64,12 +79,7 @@ func newSMTP(ctx context.Context, deps Dependencies) *smtpClient {
 		dialer.SSL = true
 	}
 
-	return &smtpClient{
-		Dialer: dialer,
-
-		GetTemplateType:        GetEmailTemplateType,
-		NewTemplateFromMessage: NewEmailTemplateFromMessage,
-	}
+	return dialer
 }
 
 func (c *courier) SetGetEmailTemplateType(f func(t EmailTemplate) (TemplateType, error)) {
@@ -127,13 +137,25 @@ func (c *courier) QueueEmail(ctx context.Context, t EmailTemplate) (uuid.UUID, e
 	return message.ID, nil
 }
 
-func (c *courier) dispatchEmail(ctx context.Context, msg Message) error {
-	if c.smtpClient.Host == "" {
-		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but %s is not set!", config.ViperKeyCourierSMTPURL))
+func (c *courier) dispatchEmail(ctx context.Context, client *smtpClient, msg Message) (int, error) {
+	if client.Host == "" {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Courier tried to deliver an email but %s is not set!", config.ViperKeyCourierSMTPURL))
 	}
 
 	from := c.deps.CourierConfig(ctx).CourierSMTPFrom()
 	fromName := c.deps.CourierConfig(ctx).CourierSMTPFromName()
+	headers := c.deps.CourierConfig(ctx).CourierSMTPHeaders()
+	if s := client.sender; s != nil {
+		if s.FromAddress != "" {
+			from = s.FromAddress
+		}
+		if s.FromName != "" {
+			fromName = s.FromName
+		}
+		if s.Headers != nil {
+			headers = s.Headers
+		}
+	}
 
 	gm := gomail.NewMessage()
 	if fromName == "" {
@@ -145,7 +167,6 @@ func (c *courier) dispatchEmail(ctx context.Context, msg Message) error {
 	gm.SetHeader("To", msg.Recipient)
 	gm.SetHeader("Subject", msg.Subject)
 
-	headers := c.deps.CourierConfig(ctx).CourierSMTPHeaders()
 	for k, v := range headers {
 		gm.SetHeader(k, v)
 	}
@@ -170,15 +191,15 @@ func (c *courier) dispatchEmail(ctx context.Context, msg Message) error {
 		}
 	}
 
-	if err := c.smtpClient.DialAndSend(ctx, gm); err != nil {
+	if err := client.DialAndSend(ctx, gm); err != nil {
 		c.deps.Logger().
 			WithError(err).
-			WithField("smtp_server", fmt.Sprintf("%s:%d", c.smtpClient.Host, c.smtpClient.Port)).
-			WithField("smtp_ssl_enabled", c.smtpClient.SSL).
+			WithField("smtp_server", fmt.Sprintf("%s:%d", client.Host, client.Port)).
+			WithField("smtp_ssl_enabled", client.SSL).
 			// WithField("email_to", msg.Recipient).
 			WithField("message_from", from).
 			Error("Unable to send email using SMTP connection.")
-		return errors.WithStack(err)
+		return smtpResponseCode(err), errors.WithStack(err)
 	}
 
 	c.deps.Logger().
@@ -188,5 +209,21 @@ func (c *courier) dispatchEmail(ctx context.Context, msg Message) error {
 		WithField("message_subject", msg.Subject).
 		Debug("Courier sent out message.")
 
-	return nil
+	return smtpStatusOK, nil
+}
+
+// smtpResponseCode extracts the reply code of the SMTP server from an error. It returns zero if the error
+// was not caused by an SMTP reply, for example because the server could not be reached.
+func smtpResponseCode(err error) int {
+	var sendErr *gomail.SendError
+	if errors.As(err, &sendErr) {
+		err = sendErr.Cause
+	}
+
+	var replyErr *textproto.Error
+	if errors.As(err, &replyErr) {
+		return replyErr.Code
+	}
+
+	return 0
 }
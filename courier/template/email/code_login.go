@@ -0,0 +1,48 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/ory/kratos/courier/template"
+)
+
+type (
+	CodeMessage struct {
+		d template.Dependencies
+		m *CodeMessageModel
+	}
+	CodeMessageModel struct {
+		To      string
+		Code    string
+		Locales []string
+	}
+)
+
+func NewCodeMessage(d template.Dependencies, m *CodeMessageModel) *CodeMessage {
+	return &CodeMessage{d: d, m: m}
+}
+
+func (t *CodeMessage) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *CodeMessage) EmailSubject(ctx context.Context) (string, error) {
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "login/email.subject.gotmpl", t.m.Locales), "login/email.subject*", t.m, "")
+}
+
+func (t *CodeMessage) EmailBody(ctx context.Context) (string, error) {
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadHTML(ctx, t.d, filesystem, template.LocalizedName(filesystem, "login/email.body.gotmpl", t.m.Locales), "login/email.body*", t.m, "")
+}
+
+func (t *CodeMessage) EmailBodyPlaintext(ctx context.Context) (string, error) {
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "login/email.body.plaintext.gotmpl", t.m.Locales), "login/email.body.plaintext*", t.m, "")
+}
+
+func (t *CodeMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.m)
+}
@@ -14,7 +14,8 @@ type (
 		m *RecoveryInvalidModel
 	}
 	RecoveryInvalidModel struct {
-		To string
+		To      string
+		Locales []string
 	}
 )
 
@@ -27,15 +28,18 @@ func (t *RecoveryInvalid) EmailRecipient() (string, error) {
 }
 
 func (t *RecoveryInvalid) EmailSubject(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/invalid/email.subject.gotmpl", "recovery/invalid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Subject)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/invalid/email.subject.gotmpl", t.m.Locales), "recovery/invalid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Subject)
 }
 
 func (t *RecoveryInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/invalid/email.body.gotmpl", "recovery/invalid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Body.HTML)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadHTML(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/invalid/email.body.gotmpl", t.m.Locales), "recovery/invalid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Body.HTML)
 }
 
 func (t *RecoveryInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/invalid/email.body.plaintext.gotmpl", "recovery/invalid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Body.PlainText)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/invalid/email.body.plaintext.gotmpl", t.m.Locales), "recovery/invalid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryInvalid().Body.PlainText)
 }
 
 func (t *RecoveryInvalid) MarshalJSON() ([]byte, error) {
@@ -17,6 +17,7 @@ type (
 		To          string
 		RecoveryURL string
 		Identity    map[string]interface{}
+		Locales     []string
 	}
 )
 
@@ -29,15 +30,18 @@ func (t *RecoveryValid) EmailRecipient() (string, error) {
 }
 
 func (t *RecoveryValid) EmailSubject(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/valid/email.subject.gotmpl", "recovery/valid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Subject)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/valid/email.subject.gotmpl", t.m.Locales), "recovery/valid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Subject)
 }
 
 func (t *RecoveryValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/valid/email.body.gotmpl", "recovery/valid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Body.HTML)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadHTML(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/valid/email.body.gotmpl", t.m.Locales), "recovery/valid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Body.HTML)
 }
 
 func (t *RecoveryValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "recovery/valid/email.body.plaintext.gotmpl", "recovery/valid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Body.PlainText)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "recovery/valid/email.body.plaintext.gotmpl", t.m.Locales), "recovery/valid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesRecoveryValid().Body.PlainText)
 }
 
 func (t *RecoveryValid) MarshalJSON() ([]byte, error) {
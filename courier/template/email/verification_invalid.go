@@ -14,7 +14,8 @@ type (
 		m *VerificationInvalidModel
 	}
 	VerificationInvalidModel struct {
-		To string
+		To      string
+		Locales []string
 	}
 )
 
@@ -27,15 +28,18 @@ func (t *VerificationInvalid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationInvalid) EmailSubject(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/invalid/email.subject.gotmpl", "verification/invalid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Subject)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/invalid/email.subject.gotmpl", t.m.Locales), "verification/invalid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Subject)
 }
 
 func (t *VerificationInvalid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/invalid/email.body.gotmpl", "verification/invalid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Body.HTML)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadHTML(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/invalid/email.body.gotmpl", t.m.Locales), "verification/invalid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Body.HTML)
 }
 
 func (t *VerificationInvalid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/invalid/email.body.plaintext.gotmpl", "verification/invalid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Body.PlainText)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/invalid/email.body.plaintext.gotmpl", t.m.Locales), "verification/invalid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationInvalid().Body.PlainText)
 }
 
 func (t *VerificationInvalid) MarshalJSON() ([]byte, error) {
@@ -17,6 +17,7 @@ type (
 		To              string
 		VerificationURL string
 		Identity        map[string]interface{}
+		Locales         []string
 	}
 )
 
@@ -29,15 +30,18 @@ func (t *VerificationValid) EmailRecipient() (string, error) {
 }
 
 func (t *VerificationValid) EmailSubject(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/valid/email.subject.gotmpl", "verification/valid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Subject)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/valid/email.subject.gotmpl", t.m.Locales), "verification/valid/email.subject*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Subject)
 }
 
 func (t *VerificationValid) EmailBody(ctx context.Context) (string, error) {
-	return template.LoadHTML(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/valid/email.body.gotmpl", "verification/valid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Body.HTML)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadHTML(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/valid/email.body.gotmpl", t.m.Locales), "verification/valid/email.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Body.HTML)
 }
 
 func (t *VerificationValid) EmailBodyPlaintext(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "verification/valid/email.body.plaintext.gotmpl", "verification/valid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Body.PlainText)
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "verification/valid/email.body.plaintext.gotmpl", t.m.Locales), "verification/valid/email.body.plaintext*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValid().Body.PlainText)
 }
 
 func (t *VerificationValid) MarshalJSON() ([]byte, error) {
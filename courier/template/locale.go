@@ -0,0 +1,71 @@
+package template
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/x"
+)
+
+// Locales returns the locales a message should be rendered in, ordered by preference. The locale stored in the
+// identity trait at traitPath comes first, followed by the languages of the Accept-Language header value.
+func Locales(traits []byte, traitPath, acceptLanguage string) []string {
+	var locales []string
+	if traitPath != "" {
+		if locale := gjson.GetBytes(traits, traitPath).String(); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	return append(locales, x.AcceptLanguages(acceptLanguage)...)
+}
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,8}([-_][a-zA-Z0-9]{1,8})*$`)
+
+// localeVariants returns the file name variants tried for a locale, from the most to the least specific one. For
+// example, `de-DE` yields `de-DE`, `de_DE` and `de`.
+func localeVariants(locale string) []string {
+	if !localePattern.MatchString(locale) {
+		return nil
+	}
+
+	variants := []string{locale}
+	if underscored := strings.ReplaceAll(locale, "-", "_"); underscored != locale {
+		variants = append(variants, underscored)
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		variants = append(variants, locale[:i])
+	}
+	return variants
+}
+
+func templateExists(filesystem fs.FS, name string) bool {
+	if filesystem != nil {
+		if _, err := fs.Stat(filesystem, name); err == nil {
+			return true
+		}
+	}
+	_, err := fs.Stat(templates, filepath.Join("courier/builtin/templates", name))
+	return err == nil
+}
+
+// LocalizedName returns the name of the template to load for the given locales, ordered by preference. For a
+// template named `recovery/valid/email.body.gotmpl` and the locale `de-DE`, the names
+// `recovery/valid/email.body.de-DE.gotmpl`, `recovery/valid/email.body.de_DE.gotmpl` and
+// `recovery/valid/email.body.de.gotmpl` are tried in the templates directory and the bundled templates. If none of
+// them exists, the default name is returned.
+func LocalizedName(filesystem fs.FS, name string, locales []string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for _, locale := range locales {
+		for _, variant := range localeVariants(locale) {
+			if localized := base + "." + variant + ext; templateExists(filesystem, localized) {
+				return localized
+			}
+		}
+	}
+	return name
+}
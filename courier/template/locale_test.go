@@ -0,0 +1,65 @@
+package template_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/internal"
+)
+
+func TestLocalizedName(t *testing.T) {
+	filesystem := fstest.MapFS{
+		"recovery/valid/email.body.gotmpl":       {Data: []byte("Hello {{ .Name }}")},
+		"recovery/valid/email.body.de.gotmpl":    {Data: []byte("Hallo {{ .Name }}")},
+		"recovery/valid/email.body.pt_BR.gotmpl": {Data: []byte("Olá {{ .Name }}")},
+	}
+	name := "recovery/valid/email.body.gotmpl"
+
+	for _, tc := range []struct {
+		d        string
+		locales  []string
+		expected string
+	}{
+		{d: "no locales", expected: name},
+		{d: "exact match", locales: []string{"de"}, expected: "recovery/valid/email.body.de.gotmpl"},
+		{d: "falls back to the language", locales: []string{"de-AT"}, expected: "recovery/valid/email.body.de.gotmpl"},
+		{d: "matches underscored regions", locales: []string{"pt-BR"}, expected: "recovery/valid/email.body.pt_BR.gotmpl"},
+		{d: "respects the order of preference", locales: []string{"fr", "pt_BR", "de"}, expected: "recovery/valid/email.body.pt_BR.gotmpl"},
+		{d: "unknown locale", locales: []string{"fr"}, expected: name},
+		{d: "rejects path traversal", locales: []string{"../../de"}, expected: name},
+	} {
+		t.Run("case="+tc.d, func(t *testing.T) {
+			assert.Equal(t, tc.expected, template.LocalizedName(filesystem, name, tc.locales))
+		})
+	}
+
+	t.Run("case=finds bundled templates", func(t *testing.T) {
+		assert.Equal(t, "test_stub/email.body.html.en_US.gotmpl",
+			template.LocalizedName(fstest.MapFS{}, "test_stub/email.body.html.gotmpl", []string{"en-US"}))
+	})
+
+	t.Run("case=renders the localized template", func(t *testing.T) {
+		template.Cache, _ = lru.New(16)
+		_, reg := internal.NewFastRegistryWithMocks(t)
+
+		actual, err := template.LoadText(context.Background(), reg, filesystem,
+			template.LocalizedName(filesystem, name, []string{"de-DE"}), "", map[string]interface{}{"Name": "Ory"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Hallo Ory", actual)
+	})
+}
+
+func TestLocales(t *testing.T) {
+	traits := []byte(`{"email":"foo@ory.sh","preferences":{"language":"de"}}`)
+
+	assert.Equal(t, []string{"de", "fr", "en"}, template.Locales(traits, "preferences.language", "fr,en;q=0.5"))
+	assert.Equal(t, []string{"fr", "en"}, template.Locales(traits, "", "fr,en;q=0.5"))
+	assert.Equal(t, []string{"fr"}, template.Locales(traits, "locale", "fr"))
+	assert.Equal(t, []string{"de"}, template.Locales(traits, "preferences.language", ""))
+}
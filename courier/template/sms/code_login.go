@@ -13,8 +13,9 @@ type (
 		m *CodeMessageModel
 	}
 	CodeMessageModel struct {
-		To   string
-		Code string
+		To      string
+		Code    string
+		Locales []string
 	}
 )
 
@@ -27,7 +28,8 @@ func (t *CodeMessage) PhoneNumber() (string, error) {
 }
 
 func (t *CodeMessage) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "login/sms.body.gotmpl", "login/sms.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValidSMS())
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "login/sms.body.gotmpl", t.m.Locales), "login/sms.body*", t.m, t.d.CourierConfig(ctx).CourierTemplatesVerificationValidSMS())
 }
 
 func (t *CodeMessage) MarshalJSON() ([]byte, error) {
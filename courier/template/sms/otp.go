@@ -18,6 +18,7 @@ type (
 		To       string
 		Code     string
 		Identity map[string]interface{}
+		Locales  []string
 	}
 )
 
@@ -30,7 +31,8 @@ func (t *OTPMessage) PhoneNumber() (string, error) {
 }
 
 func (t *OTPMessage) SMSBody(ctx context.Context) (string, error) {
-	return template.LoadText(ctx, t.d, os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot()), "otp/sms.body.gotmpl", "otp/sms.body*", t.m, "")
+	filesystem := os.DirFS(t.d.CourierConfig(ctx).CourierTemplatesRoot())
+	return template.LoadText(ctx, t.d, filesystem, template.LocalizedName(filesystem, "otp/sms.body.gotmpl", t.m.Locales), "otp/sms.body*", t.m, "")
 }
 
 func (t *OTPMessage) MarshalJSON() ([]byte, error) {
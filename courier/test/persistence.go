@@ -95,6 +95,132 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 			require.ErrorIs(t, err, courier.ErrQueueEmpty)
 		})
 
+		t.Run("case=list messages", func(t *testing.T) {
+			all, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 0, 100)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(messages), total)
+			require.Len(t, all, len(messages))
+			assert.Equal(t, messages[len(messages)-1].ID, all[0].ID, "newest messages come first")
+
+			paginated, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 0, 2)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(messages), total)
+			assert.Len(t, paginated, 2)
+
+			filtered, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{Status: courier.MessageStatusAbandoned}, 0, 100)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, total)
+			require.Len(t, filtered, 1)
+			assert.Equal(t, messages[0].ID, filtered[0].ID)
+
+			filtered, total, err = p.ListMessages(ctx, courier.ListMessagesFilter{Recipient: messages[1].Recipient}, 0, 100)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, total)
+			require.Len(t, filtered, 1)
+			assert.Equal(t, messages[1].ID, filtered[0].ID)
+
+			_, total, err = p.ListMessages(ctx, courier.ListMessagesFilter{CreatedAfter: time.Now().Add(time.Hour)}, 0, 100)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, total)
+
+			_, total, err = p.ListMessages(ctx, courier.ListMessagesFilter{CreatedBefore: time.Now().Add(time.Hour)}, 0, 100)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(messages), total)
+		})
+
+		t.Run("case=fetch message", func(t *testing.T) {
+			actual, err := p.FetchMessage(ctx, messages[1].ID)
+			require.NoError(t, err)
+			assert.Equal(t, messages[1].ID, actual.ID)
+			assert.Equal(t, messages[1].Subject, actual.Subject)
+			assert.Equal(t, messages[1].Recipient, actual.Recipient)
+
+			_, err = p.FetchMessage(ctx, x.NewUUID())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=cancel message", func(t *testing.T) {
+			var m courier.Message
+			require.NoError(t, faker.FakeData(&m))
+			require.NoError(t, p.AddMessage(ctx, &m))
+
+			require.NoError(t, p.CancelMessage(ctx, m.ID))
+			actual, err := p.FetchMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, courier.MessageStatusCancelled, actual.Status)
+
+			_, err = p.NextMessages(ctx, 10)
+			require.ErrorIs(t, err, courier.ErrQueueEmpty, "cancelled messages must not be dispatched")
+
+			require.ErrorIs(t, p.CancelMessage(ctx, m.ID), courier.ErrMessageNotCancellable)
+			require.ErrorIs(t, p.CancelMessage(ctx, messages[0].ID), courier.ErrMessageNotCancellable)
+			require.ErrorIs(t, p.CancelMessage(ctx, x.NewUUID()), sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=record dispatch", func(t *testing.T) {
+			var m courier.Message
+			require.NoError(t, faker.FakeData(&m))
+			m.SendCount = 0
+			require.NoError(t, p.AddMessage(ctx, &m))
+			t.Cleanup(func() {
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+			})
+
+			failed := courier.MessageDispatch{
+				MessageID:    m.ID,
+				Status:       courier.MessageDispatchStatusFailed,
+				Channel:      courier.MessageDispatchChannelSMS,
+				ResponseCode: 503,
+				Error:        "Status: Service Unavailable",
+				Latency:      120,
+			}
+			require.NoError(t, p.RecordDispatch(ctx, &failed))
+			assert.NotEqual(t, uuid.Nil, failed.ID)
+			assert.EqualValues(t, nid, failed.NID)
+
+			succeeded := courier.MessageDispatch{
+				MessageID:    m.ID,
+				Status:       courier.MessageDispatchStatusSuccess,
+				Channel:      courier.MessageDispatchChannelSMS,
+				ResponseCode: 200,
+				Latency:      80,
+			}
+			require.NoError(t, p.RecordDispatch(ctx, &succeeded))
+
+			actual, err := p.FetchMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 0, actual.SendCount, "recording a dispatch must not count as a delivery round")
+			require.Len(t, actual.Dispatches, 2)
+			assert.Equal(t, failed.ID, actual.Dispatches[0].ID)
+			assert.Equal(t, courier.MessageDispatchStatusFailed, actual.Dispatches[0].Status)
+			assert.Equal(t, 503, actual.Dispatches[0].ResponseCode)
+			assert.EqualValues(t, "Status: Service Unavailable", actual.Dispatches[0].Error)
+			assert.EqualValues(t, 120, actual.Dispatches[0].Latency)
+			assert.Equal(t, succeeded.ID, actual.Dispatches[1].ID)
+			assert.Equal(t, courier.MessageDispatchStatusSuccess, actual.Dispatches[1].Status)
+
+			require.ErrorIs(t, p.RecordDispatch(ctx, &courier.MessageDispatch{MessageID: x.NewUUID(), Status: courier.MessageDispatchStatusFailed}), sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=increment send count", func(t *testing.T) {
+			var m courier.Message
+			require.NoError(t, faker.FakeData(&m))
+			m.SendCount = 0
+			require.NoError(t, p.AddMessage(ctx, &m))
+			t.Cleanup(func() {
+				require.NoError(t, p.SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+			})
+
+			require.NoError(t, p.IncrementMessageSendCount(ctx, m.ID))
+			require.NoError(t, p.IncrementMessageSendCount(ctx, m.ID))
+
+			actual, err := p.FetchMessage(ctx, m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, actual.SendCount)
+
+			require.ErrorIs(t, p.IncrementMessageSendCount(ctx, x.NewUUID()), sqlcon.ErrNoRows)
+		})
+
 		t.Run("case=network", func(t *testing.T) {
 			id := x.NewUUID()
 
@@ -129,6 +255,31 @@ func TestPersister(ctx context.Context, newNetworkUnlessExisting NetworkWrapper,
 				require.ErrorIs(t, err, courier.ErrQueueEmpty)
 			})
 
+			t.Run("can not list, fetch or cancel on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+
+				l, total, err := p.ListMessages(ctx, courier.ListMessagesFilter{}, 0, 100)
+				require.NoError(t, err)
+				assert.EqualValues(t, 0, total)
+				assert.Len(t, l, 0)
+
+				_, err = p.FetchMessage(ctx, id)
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+
+				require.ErrorIs(t, p.CancelMessage(ctx, id), sqlcon.ErrNoRows)
+			})
+
+			t.Run("can not record dispatch on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				err := p.RecordDispatch(ctx, &courier.MessageDispatch{MessageID: id, Status: courier.MessageDispatchStatusFailed})
+				require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			})
+
+			t.Run("can not increment send count on another network", func(t *testing.T) {
+				_, p := newNetwork(t, ctx)
+				require.ErrorIs(t, p.IncrementMessageSendCount(ctx, id), sqlcon.ErrNoRows)
+			})
+
 			t.Run("can not update on another network", func(t *testing.T) {
 				_, p := newNetwork(t, ctx)
 				err := p.SetMessageStatus(ctx, id, courier.MessageStatusProcessing)
@@ -7,10 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"runtime"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -66,13 +68,21 @@ const (
 	ViperKeyCourierTemplatesRecoveryValidEmail               = "courier.templates.recovery.valid.email"
 	ViperKeyCourierTemplatesVerificationInvalidEmail         = "courier.templates.verification.invalid.email"
 	ViperKeyCourierTemplatesVerificationValidEmail           = "courier.templates.verification.valid.email"
+	ViperKeyCourierTemplatesLocaleTrait                      = "courier.templates.locale_trait"
 	ViperKeyCourierSMTPFrom                                  = "courier.smtp.from_address"
 	ViperKeyCourierSMTPFromName                              = "courier.smtp.from_name"
 	ViperKeyCourierSMTPHeaders                               = "courier.smtp.headers"
+	ViperKeyCourierDeliveryStrategy                          = "courier.delivery_strategy"
+	ViperKeyCourierHTTPRequestConfig                         = "courier.http.request_config"
+	ViperKeyCourierChannels                                  = "courier.channels"
+	ViperKeyCourierRoutes                                    = "courier.routes"
+	ViperKeyCourierFailoverThreshold                         = "courier.failover.threshold"
+	ViperKeyCourierFailoverCooldown                          = "courier.failover.cooldown"
 	ViperKeyCourierSMSRequestConfig                          = "courier.sms.request_config"
 	ViperKeyCourierSMSEnabled                                = "courier.sms.enabled"
 	ViperKeyCourierSMSFrom                                   = "courier.sms.from"
 	ViperKeyCourierMessageTTL                                = "courier.message_ttl"
+	ViperKeyCourierMessageRetries                            = "courier.message_retries"
 	ViperKeySecretsDefault                                   = "secrets.default"
 	ViperKeySecretsCookie                                    = "secrets.cookie"
 	ViperKeySecretsCipher                                    = "secrets.cipher"
@@ -87,6 +97,7 @@ const (
 	ViperKeyPublicTLSKeyBase64                               = "serve.public.tls.key.base64"
 	ViperKeyPublicTLSCertPath                                = "serve.public.tls.cert.path"
 	ViperKeyPublicTLSKeyPath                                 = "serve.public.tls.key.path"
+	ViperKeyPublicTrustedProxies                             = "serve.public.trusted_proxies"
 	ViperKeyDisableAdminHealthRequestLog                     = "serve.admin.request_log.disable_for_health"
 	ViperKeyAdminBaseURL                                     = "serve.admin.base_url"
 	ViperKeyAdminPort                                        = "serve.admin.port"
@@ -106,6 +117,18 @@ const (
 	ViperKeySessionPersistentCookie                          = "session.cookie.persistent"
 	ViperKeySessionWhoAmIAAL                                 = "session.whoami.required_aal"
 	ViperKeySessionRefreshMinTimeLeft                        = "session.earliest_possible_extend"
+	ViperKeySessionDevicesGeoLocationHeaders                 = "session.devices.geo_location.headers"
+	ViperKeySessionTokenizerTemplates                        = "session.whoami.tokenizer.templates"
+	ViperKeyDatabaseCleanupBatchSize                         = "database.cleanup.batch_size"
+	ViperKeyDatabaseCleanupOlderThan                         = "database.cleanup.older_than"
+	ViperKeyDatabaseCleanupMaxDuration                       = "database.cleanup.max_duration"
+	ViperKeyDatabaseCleanupSleep                             = "database.cleanup.sleep"
+	ViperKeyDatabaseCleanupJanitorEnabled                    = "database.cleanup.janitor.enabled"
+	ViperKeyDatabaseCleanupJanitorInterval                   = "database.cleanup.janitor.interval"
+	ViperKeyWebHookDeliveryMaxRetries                        = "selfservice.web_hooks.delivery.max_retries"
+	ViperKeyWebHookDeliveryInitialInterval                   = "selfservice.web_hooks.delivery.initial_interval"
+	ViperKeyWebHookDeliveryMaxInterval                       = "selfservice.web_hooks.delivery.max_interval"
+	ViperKeyEventSinks                                       = "events.sinks"
 	ViperKeyCookieSameSite                                   = "cookies.same_site"
 	ViperKeyCookieDomain                                     = "cookies.domain"
 	ViperKeyCookiePath                                       = "cookies.path"
@@ -121,6 +144,13 @@ const (
 	ViperKeySelfServiceLoginRequestLifespan                  = "selfservice.flows.login.lifespan"
 	ViperKeySelfServiceLoginAfter                            = "selfservice.flows.login.after"
 	ViperKeySelfServiceLoginBeforeHooks                      = "selfservice.flows.login.before.hooks"
+	ViperKeySelfServiceLoginLockoutEnabled                   = "selfservice.flows.login.lockout.enabled"
+	ViperKeySelfServiceLoginLockoutMaxAttempts               = "selfservice.flows.login.lockout.max_attempts"
+	ViperKeySelfServiceLoginLockoutMaxAttemptsPerAddress     = "selfservice.flows.login.lockout.max_attempts_per_address"
+	ViperKeySelfServiceLoginLockoutWindow                    = "selfservice.flows.login.lockout.window"
+	ViperKeySelfServiceLoginLockoutDuration                  = "selfservice.flows.login.lockout.duration"
+	ViperKeySelfServiceLoginLockoutDelay                     = "selfservice.flows.login.lockout.delay"
+	ViperKeySelfServiceLoginLockoutMaxDelay                  = "selfservice.flows.login.lockout.max_delay"
 	ViperKeySelfServiceErrorUI                               = "selfservice.flows.error.ui_url"
 	ViperKeySelfServiceLogoutBrowserDefaultReturnTo          = "selfservice.flows.logout.after." + DefaultBrowserReturnURL
 	ViperKeySelfServiceSettingsURL                           = "selfservice.flows.settings.ui_url"
@@ -171,6 +201,11 @@ const (
 	CodeTestNumbers                                          = "selfservice.methods.code.config.test_numbers"
 	CodeMaxAttempts                                          = "selfservice.methods.code.config.max_attempts"
 	CodeLifespan                                             = "selfservice.methods.code.config.lifespan"
+	CodeLength                                               = "selfservice.methods.code.config.length"
+	CodeAlphabet                                             = "selfservice.methods.code.config.alphabet"
+	CodeResendInterval                                       = "selfservice.methods.code.config.resend_interval"
+	CodeMaxSendsPerIdentifier                                = "selfservice.methods.code.config.max_sends_per_identifier"
+	CodeMaxSendsPerFlow                                      = "selfservice.methods.code.config.max_sends_per_flow"
 	ViperKeyCourierTemplatesVerificationValidSMS             = "courier.templates.verification.valid.sms"
 )
 
@@ -184,6 +219,8 @@ const (
 	Argon2DefaultDeviation              = 500 * time.Millisecond
 	Argon2DefaultDedicatedMemory        = 1 * bytesize.GB
 	BcryptDefaultCost            uint32 = 12
+	CodeAlphabetNumeric                 = "numeric"
+	CodeAlphabetAlphanumeric            = "alphanumeric"
 )
 
 // DefaultSessionCookieName returns the default cookie name for the kratos session.
@@ -203,10 +240,41 @@ type (
 	Bcrypt struct {
 		Cost uint32 `json:"cost"`
 	}
+	DatabaseCleanup struct {
+		BatchSize       int           `json:"batch_size"`
+		OlderThan       time.Duration `json:"older_than"`
+		MaxDuration     time.Duration `json:"max_duration"`
+		Sleep           time.Duration `json:"sleep"`
+		JanitorEnabled  bool          `json:"janitor_enabled"`
+		JanitorInterval time.Duration `json:"janitor_interval"`
+	}
+	WebHookDelivery struct {
+		MaxRetries      int           `json:"max_retries"`
+		InitialInterval time.Duration `json:"initial_interval"`
+		MaxInterval     time.Duration `json:"max_interval"`
+	}
+	SessionTokenizeFormat struct {
+		TTL             time.Duration `json:"ttl"`
+		JWKSURL         string        `json:"jwks_url"`
+		ClaimsMapperURL string        `json:"claims_mapper_url"`
+	}
+	LoginLockout struct {
+		Enabled               bool          `json:"enabled"`
+		MaxAttempts           int           `json:"max_attempts"`
+		MaxAttemptsPerAddress int           `json:"max_attempts_per_address"`
+		Window                time.Duration `json:"window"`
+		Duration              time.Duration `json:"duration"`
+		Delay                 time.Duration `json:"delay"`
+		MaxDelay              time.Duration `json:"max_delay"`
+	}
 	SelfServiceHook struct {
 		Name   string          `json:"hook"`
 		Config json.RawMessage `json:"config"`
 	}
+	EventSink struct {
+		Type   string          `json:"type"`
+		Config json.RawMessage `json:"config"`
+	}
 	SelfServiceStrategy struct {
 		Enabled bool            `json:"enabled"`
 		Config  json.RawMessage `json:"config"`
@@ -232,6 +300,26 @@ type (
 		Body    *CourierEmailBodyTemplate `json:"body"`
 		Subject string                    `json:"subject"`
 	}
+	CourierChannelSMTP struct {
+		ConnectionURI string            `json:"connection_uri"`
+		FromAddress   string            `json:"from_address"`
+		FromName      string            `json:"from_name"`
+		Headers       map[string]string `json:"headers"`
+	}
+	CourierChannel struct {
+		ID            string              `json:"id"`
+		Type          string              `json:"type"`
+		From          string              `json:"from"`
+		SMTP          *CourierChannelSMTP `json:"smtp"`
+		RequestConfig json.RawMessage     `json:"request_config"`
+	}
+	CourierRoute struct {
+		MessageType      string   `json:"message_type"`
+		TemplateTypes    []string `json:"template_types"`
+		RecipientPattern string   `json:"recipient_pattern"`
+		IdentitySchemas  []string `json:"identity_schemas"`
+		Channels         []string `json:"channels"`
+	}
 	Config struct {
 		l              *logrusx.Logger
 		p              *configx.Provider
@@ -246,6 +334,12 @@ type (
 		CourierSMTPFrom() string
 		CourierSMTPFromName() string
 		CourierSMTPHeaders() map[string]string
+		CourierEmailStrategy() string
+		CourierEmailRequestConfig() json.RawMessage
+		CourierChannels() []CourierChannel
+		CourierRoutes() []CourierRoute
+		CourierFailoverThreshold() int
+		CourierFailoverCooldown() time.Duration
 		CourierSMSEnabled() bool
 		CourierSMSFrom() string
 		CourierSMSRequestConfig() json.RawMessage
@@ -254,7 +348,9 @@ type (
 		CourierTemplatesVerificationValid() *CourierEmailTemplate
 		CourierTemplatesRecoveryInvalid() *CourierEmailTemplate
 		CourierTemplatesRecoveryValid() *CourierEmailTemplate
+		CourierTemplatesLocaleTrait() string
 		CourierMessageTTL() time.Duration
+		CourierMessageRetries() int
 		CourierTemplatesVerificationValidSMS() string
 	}
 )
@@ -554,6 +650,35 @@ func (p *Config) PublicSocketPermission() *configx.UnixPermission {
 	}
 }
 
+// PublicTrustedProxies returns the networks of the reverse proxies whose forwarding headers are trusted
+// when determining the IP address of a client. Entries may be IP addresses or CIDR ranges.
+func (p *Config) PublicTrustedProxies() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, entry := range p.p.Strings(ViperKeyPublicTrustedProxies) {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				p.l.Warnf("Configuration key %s contains \"%s\" which is neither an IP address nor a CIDR range, ignoring it.", ViperKeyPublicTrustedProxies, entry)
+				continue
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			p.l.WithError(err).Warnf("Configuration key %s contains \"%s\" which is neither an IP address nor a CIDR range, ignoring it.", ViperKeyPublicTrustedProxies, entry)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
 func (p *Config) AdminSocketPermission() *configx.UnixPermission {
 	return &configx.UnixPermission{
 		Owner: p.p.String(ViperKeyAdminSocketOwner),
@@ -864,6 +989,70 @@ func (p *Config) SelfServiceFlowLoginRequestLifespan() time.Duration {
 	return p.p.DurationF(ViperKeySelfServiceLoginRequestLifespan, time.Hour)
 }
 
+// SelfServiceFlowLoginLockout returns the brute-force protection settings for login flows.
+func (p *Config) SelfServiceFlowLoginLockout() *LoginLockout {
+	return &LoginLockout{
+		Enabled:               p.p.BoolF(ViperKeySelfServiceLoginLockoutEnabled, false),
+		MaxAttempts:           p.p.IntF(ViperKeySelfServiceLoginLockoutMaxAttempts, 10),
+		MaxAttemptsPerAddress: p.p.IntF(ViperKeySelfServiceLoginLockoutMaxAttemptsPerAddress, 100),
+		Window:                p.p.DurationF(ViperKeySelfServiceLoginLockoutWindow, time.Hour),
+		Duration:              p.p.DurationF(ViperKeySelfServiceLoginLockoutDuration, 15*time.Minute),
+		Delay:                 p.p.DurationF(ViperKeySelfServiceLoginLockoutDelay, 250*time.Millisecond),
+		MaxDelay:              p.p.DurationF(ViperKeySelfServiceLoginLockoutMaxDelay, 5*time.Second),
+	}
+}
+
+// DatabaseCleanup returns the settings for deleting expired records from the database.
+func (p *Config) DatabaseCleanup() *DatabaseCleanup {
+	return &DatabaseCleanup{
+		BatchSize:       p.p.IntF(ViperKeyDatabaseCleanupBatchSize, 100),
+		OlderThan:       p.p.DurationF(ViperKeyDatabaseCleanupOlderThan, 24*time.Hour),
+		MaxDuration:     p.p.DurationF(ViperKeyDatabaseCleanupMaxDuration, 0),
+		Sleep:           p.p.DurationF(ViperKeyDatabaseCleanupSleep, 0),
+		JanitorEnabled:  p.p.BoolF(ViperKeyDatabaseCleanupJanitorEnabled, false),
+		JanitorInterval: p.p.DurationF(ViperKeyDatabaseCleanupJanitorInterval, time.Hour),
+	}
+}
+
+// WebHookDelivery returns the retry settings for asynchronous web hooks which are delivered from the outbox.
+func (p *Config) WebHookDelivery() *WebHookDelivery {
+	return &WebHookDelivery{
+		MaxRetries:      p.p.IntF(ViperKeyWebHookDeliveryMaxRetries, 10),
+		InitialInterval: p.p.DurationF(ViperKeyWebHookDeliveryInitialInterval, 10*time.Second),
+		MaxInterval:     p.p.DurationF(ViperKeyWebHookDeliveryMaxInterval, time.Hour),
+	}
+}
+
+// EventSinks returns the sinks identity lifecycle events are sent to.
+func (p *Config) EventSinks() []EventSink {
+	var sinks []EventSink
+	if !p.p.Exists(ViperKeyEventSinks) {
+		return []EventSink{}
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Fatalf("Unable to decode values from configuration key: %s", ViperKeyEventSinks)
+	}
+
+	config := gjson.GetBytes(out, ViperKeyEventSinks).Raw
+	if len(config) == 0 {
+		return []EventSink{}
+	}
+
+	if err := jsonx.NewStrictDecoder(bytes.NewBufferString(config)).Decode(&sinks); err != nil {
+		p.l.WithError(err).Fatalf("Unable to encode value \"%s\" from configuration key: %s", config, ViperKeyEventSinks)
+	}
+
+	for k := range sinks {
+		if len(sinks[k].Config) == 0 {
+			sinks[k].Config = json.RawMessage("{}")
+		}
+	}
+
+	return sinks
+}
+
 func (p *Config) SelfServiceFlowSettingsFlowLifespan() time.Duration {
 	return p.p.DurationF(ViperKeySelfServiceSettingsRequestLifespan, time.Hour)
 }
@@ -935,14 +1124,101 @@ func (p *Config) CourierTemplatesRecoveryValid() *CourierEmailTemplate {
 	return p.CourierTemplatesHelper(ViperKeyCourierTemplatesRecoveryValidEmail)
 }
 
+// CourierTemplatesLocaleTrait returns the path of the identity trait which holds the preferred locale of an identity.
+// An empty string disables locale lookups on identity traits.
+func (p *Config) CourierTemplatesLocaleTrait() string {
+	return p.p.String(ViperKeyCourierTemplatesLocaleTrait)
+}
+
 func (p *Config) CourierMessageTTL() time.Duration {
 	return p.p.DurationF(ViperKeyCourierMessageTTL, time.Hour)
 }
 
+func (p *Config) CourierMessageRetries() int {
+	return p.p.IntF(ViperKeyCourierMessageRetries, 5)
+}
+
 func (p *Config) CourierSMTPHeaders() map[string]string {
 	return p.p.StringMap(ViperKeyCourierSMTPHeaders)
 }
 
+func (p *Config) CourierEmailStrategy() string {
+	return p.p.StringF(ViperKeyCourierDeliveryStrategy, "smtp")
+}
+
+func (p *Config) CourierEmailRequestConfig() json.RawMessage {
+	if p.CourierEmailStrategy() != "http" {
+		return nil
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Warn("Unable to marshal courier http configuration.")
+		return nil
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierHTTPRequestConfig).Raw
+	if len(config) <= 0 {
+		return json.RawMessage("{}")
+	}
+
+	return json.RawMessage(config)
+}
+
+func (p *Config) CourierChannels() []CourierChannel {
+	var channels []CourierChannel
+	if !p.p.Exists(ViperKeyCourierChannels) {
+		return channels
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Fatalf("Unable to decode values from configuration key: %s", ViperKeyCourierChannels)
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierChannels).Raw
+	if len(config) == 0 {
+		return channels
+	}
+
+	if err := json.NewDecoder(bytes.NewBufferString(config)).Decode(&channels); err != nil {
+		p.l.WithError(err).Fatalf("Unable to encode value \"%s\" from configuration key: %s", config, ViperKeyCourierChannels)
+	}
+
+	return channels
+}
+
+func (p *Config) CourierRoutes() []CourierRoute {
+	var routes []CourierRoute
+	if !p.p.Exists(ViperKeyCourierRoutes) {
+		return routes
+	}
+
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Fatalf("Unable to decode values from configuration key: %s", ViperKeyCourierRoutes)
+	}
+
+	config := gjson.GetBytes(out, ViperKeyCourierRoutes).Raw
+	if len(config) == 0 {
+		return routes
+	}
+
+	if err := json.NewDecoder(bytes.NewBufferString(config)).Decode(&routes); err != nil {
+		p.l.WithError(err).Fatalf("Unable to encode value \"%s\" from configuration key: %s", config, ViperKeyCourierRoutes)
+	}
+
+	return routes
+}
+
+func (p *Config) CourierFailoverThreshold() int {
+	return p.p.IntF(ViperKeyCourierFailoverThreshold, 3)
+}
+
+func (p *Config) CourierFailoverCooldown() time.Duration {
+	return p.p.DurationF(ViperKeyCourierFailoverCooldown, time.Minute)
+}
+
 func (p *Config) CourierSMSRequestConfig() json.RawMessage {
 	if !p.p.Bool(ViperKeyCourierSMSEnabled) {
 		return nil
@@ -1033,6 +1309,10 @@ func (p *Config) IsBackgroundCourierEnabled() bool {
 	return p.Source().Bool("watch-courier")
 }
 
+func (p *Config) IsBackgroundWebHookWorkerEnabled() bool {
+	return p.Source().Bool("watch-web-hooks")
+}
+
 func (p *Config) CourierExposeMetricsPort() int {
 	return p.Source().Int("expose-metrics-port")
 }
@@ -1116,6 +1396,39 @@ func (p *Config) SessionRefreshMinTimeLeft() time.Duration {
 	return p.p.DurationF(ViperKeySessionRefreshMinTimeLeft, p.SessionLifespan())
 }
 
+func (p *Config) SessionDevicesGeoLocationHeaders() []string {
+	return p.p.Strings(ViperKeySessionDevicesGeoLocationHeaders)
+}
+
+// TokenizeTemplate returns the session tokenizer template with the given name.
+func (p *Config) TokenizeTemplate(key string) (*SessionTokenizeFormat, error) {
+	path := ViperKeySessionTokenizerTemplates + "." + key
+	if !p.p.Exists(path) {
+		return nil, errors.Errorf("unable to find tokenizer template \"%s\"", key)
+	}
+
+	return &SessionTokenizeFormat{
+		TTL:             p.p.DurationF(path+".ttl", time.Minute),
+		JWKSURL:         p.p.String(path + ".jwks_url"),
+		ClaimsMapperURL: p.p.String(path + ".claims_mapper_url"),
+	}, nil
+}
+
+// TokenizeTemplates returns the names of all configured session tokenizer templates.
+func (p *Config) TokenizeTemplates() []string {
+	templates, ok := p.p.Get(ViperKeySessionTokenizerTemplates).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (p *Config) SelfServiceSettingsRequiredAAL() string {
 	return p.p.String(ViperKeySelfServiceSettingsRequiredAAL)
 }
@@ -1272,6 +1585,31 @@ func (p *Config) SelfServiceCodeLifespan() time.Duration {
 	return p.p.DurationF(CodeLifespan, time.Hour)
 }
 
+func (p *Config) SelfServiceCodeLength() int {
+	return p.p.IntF(CodeLength, 6)
+}
+
+// SelfServiceCodeAlphabet returns the alphabet one-time codes are generated from, either
+// CodeAlphabetNumeric or CodeAlphabetAlphanumeric.
+func (p *Config) SelfServiceCodeAlphabet() string {
+	return p.p.StringF(CodeAlphabet, CodeAlphabetNumeric)
+}
+
+func (p *Config) SelfServiceCodeResendInterval() time.Duration {
+	return p.p.DurationF(CodeResendInterval, 30*time.Second)
+}
+
+// SelfServiceCodeMaxSendsPerIdentifier returns how many codes may be sent to one identifier within 24 hours.
+// Zero disables the limit.
+func (p *Config) SelfServiceCodeMaxSendsPerIdentifier() int {
+	return p.p.IntF(CodeMaxSendsPerIdentifier, 10)
+}
+
+// SelfServiceCodeMaxSendsPerFlow returns how many codes may be sent within one flow. Zero disables the limit.
+func (p *Config) SelfServiceCodeMaxSendsPerFlow() int {
+	return p.p.IntF(CodeMaxSendsPerFlow, 5)
+}
+
 func (p *Config) CourierTemplatesVerificationValidSMS() string {
 	return p.p.String(ViperKeyCourierTemplatesVerificationValidSMS)
 }
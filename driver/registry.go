@@ -35,10 +35,12 @@ import (
 	"github.com/ory/x/dbal"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/errorx"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type Registry interface {
@@ -50,6 +52,7 @@ type Registry interface {
 
 	WithCSRFHandler(c nosurf.Handler)
 	WithCSRFTokenGenerator(cg x.CSRFToken)
+	WithGeoLocationResolver(r session.GeoLocationResolver)
 
 	MetricsHandler() *prometheus.Handler
 	HealthHandler(ctx context.Context) *healthx.Handler
@@ -76,6 +79,13 @@ type Registry interface {
 	continuity.PersistenceProvider
 
 	courier.Provider
+	courier.HandlerProvider
+
+	webhook.PersistenceProvider
+	webhook.HandlerProvider
+	webhook.WorkerProvider
+
+	event.StreamProvider
 
 	persistence.Provider
 
@@ -99,6 +109,8 @@ type Registry interface {
 	session.HandlerProvider
 	session.ManagementProvider
 	session.PersistenceProvider
+	session.GeoLocationResolverProvider
+	session.TokenizerProvider
 
 	settings.HandlerProvider
 	settings.ErrorHandlerProvider
@@ -106,6 +118,8 @@ type Registry interface {
 	settings.StrategyProvider
 
 	login.FlowPersistenceProvider
+	login.AttemptPersistenceProvider
+	login.LockoutManagerProvider
 	login.ErrorHandlerProvider
 	login.HooksProvider
 	login.HookExecutorProvider
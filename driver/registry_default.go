@@ -67,10 +67,12 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/errorx"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type RegistryDefault struct {
@@ -101,10 +103,19 @@ type RegistryDefault struct {
 
 	continuityManager continuity.Manager
 
+	courierHandler *courier.Handler
+
+	webHookHandler *webhook.Handler
+	webHookWorker  *webhook.Worker
+
+	eventStream *event.Stream
+
 	schemaHandler *schema.Handler
 
-	sessionHandler *session.Handler
-	sessionManager session.Manager
+	sessionHandler             *session.Handler
+	sessionManager             session.Manager
+	sessionGeoLocationResolver session.GeoLocationResolver
+	sessionTokenizer           *session.Tokenizer
 
 	passwordHasher    hash.Hasher
 	passwordValidator password2.Validator
@@ -122,6 +133,7 @@ type RegistryDefault struct {
 	selfserviceLoginExecutor            *login.HookExecutor
 	selfserviceLoginHandler             *login.Handler
 	selfserviceLoginRequestErrorHandler *login.ErrorHandler
+	selfserviceLoginLockoutManager      *login.LockoutManager
 
 	selfserviceSettingsHandler      *settings.Handler
 	selfserviceSettingsErrorHandler *settings.ErrorHandler
@@ -181,6 +193,7 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *x.Ro
 func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.RouterAdmin) {
 	m.RegistrationHandler().RegisterAdminRoutes(router)
 	m.LoginHandler().RegisterAdminRoutes(router)
+	m.AllLoginStrategies().RegisterAdminRoutes(router)
 	m.LogoutHandler().RegisterAdminRoutes(router)
 	m.SchemaHandler().RegisterAdminRoutes(router)
 	m.SettingsHandler().RegisterAdminRoutes(router)
@@ -190,6 +203,8 @@ func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.Rou
 	m.RecoveryHandler().RegisterAdminRoutes(router)
 	m.AllRecoveryStrategies().RegisterAdminRoutes(router)
 	m.SessionHandler().RegisterAdminRoutes(router)
+	m.CourierHandler().RegisterAdminRoutes(router)
+	m.WebHookHandler().RegisterAdminRoutes(router)
 
 	m.VerificationHandler().RegisterAdminRoutes(router)
 	m.AllVerificationStrategies().RegisterAdminRoutes(router)
@@ -380,6 +395,34 @@ func (m *RegistryDefault) IdentityHandler() *identity.Handler {
 	return m.identityHandler
 }
 
+func (m *RegistryDefault) CourierHandler() *courier.Handler {
+	if m.courierHandler == nil {
+		m.courierHandler = courier.NewHandler(m)
+	}
+	return m.courierHandler
+}
+
+func (m *RegistryDefault) WebHookHandler() *webhook.Handler {
+	if m.webHookHandler == nil {
+		m.webHookHandler = webhook.NewHandler(m)
+	}
+	return m.webHookHandler
+}
+
+func (m *RegistryDefault) WebHookWorker() *webhook.Worker {
+	if m.webHookWorker == nil {
+		m.webHookWorker = webhook.NewWorker(m)
+	}
+	return m.webHookWorker
+}
+
+func (m *RegistryDefault) EventStream() *event.Stream {
+	if m.eventStream == nil {
+		m.eventStream = event.NewStream(m)
+	}
+	return m.eventStream
+}
+
 func (m *RegistryDefault) SchemaHandler() *schema.Handler {
 	if m.schemaHandler == nil {
 		m.schemaHandler = schema.NewHandler(m)
@@ -493,6 +536,24 @@ func (m *RegistryDefault) SessionManager() session.Manager {
 	return m.sessionManager
 }
 
+func (m *RegistryDefault) WithGeoLocationResolver(r session.GeoLocationResolver) {
+	m.sessionGeoLocationResolver = r
+}
+
+func (m *RegistryDefault) SessionGeoLocationResolver() session.GeoLocationResolver {
+	if m.sessionGeoLocationResolver == nil {
+		m.sessionGeoLocationResolver = session.NewHeaderGeoLocationResolver(m)
+	}
+	return m.sessionGeoLocationResolver
+}
+
+func (m *RegistryDefault) SessionTokenizer() *session.Tokenizer {
+	if m.sessionTokenizer == nil {
+		m.sessionTokenizer = session.NewTokenizer(m)
+	}
+	return m.sessionTokenizer
+}
+
 func (m *RegistryDefault) SelfServiceErrorManager() *errorx.Manager {
 	if m.errorManager == nil {
 		m.errorManager = errorx.NewManager(m)
@@ -638,6 +699,10 @@ func (m *RegistryDefault) LoginFlowPersister() login.FlowPersister {
 	return m.persister
 }
 
+func (m *RegistryDefault) LoginAttemptPersister() login.AttemptPersister {
+	return m.persister
+}
+
 func (m *RegistryDefault) SettingsFlowPersister() settings.FlowPersister {
 	return m.persister
 }
@@ -654,6 +719,10 @@ func (m *RegistryDefault) CourierPersister() courier.Persister {
 	return m.persister
 }
 
+func (m *RegistryDefault) WebHookPersister() webhook.Persister {
+	return m.persister
+}
+
 func (m *RegistryDefault) RecoveryTokenPersister() link.RecoveryTokenPersister {
 	return m.Persister()
 }
@@ -670,6 +739,10 @@ func (m *RegistryDefault) Persister() persistence.Persister {
 	return m.persister
 }
 
+func (m *RegistryDefault) TransactionPersister() x.TransactionPersister {
+	return m.persister
+}
+
 func (m *RegistryDefault) Ping() error {
 	return m.persister.Ping()
 }
@@ -58,3 +58,11 @@ func (m *RegistryDefault) LoginFlowErrorHandler() *login.ErrorHandler {
 
 	return m.selfserviceLoginRequestErrorHandler
 }
+
+func (m *RegistryDefault) LoginLockoutManager() *login.LockoutManager {
+	if m.selfserviceLoginLockoutManager == nil {
+		m.selfserviceLoginLockoutManager = login.NewLockoutManager(m)
+	}
+
+	return m.selfserviceLoginLockoutManager
+}
@@ -9,7 +9,14 @@ import (
 )
 
 func (m *RegistryDefault) PostRegistrationPrePersistHooks(ctx context.Context, credentialsType identity.CredentialsType) (b []registration.PostHookPrePersistExecutor) {
-	for _, v := range m.getHooks(string(credentialsType), m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(string(credentialsType))) {
+	strategy := string(credentialsType)
+	hooks := m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(strategy)
+	if len(hooks) == 0 {
+		// global hooks are only used if no strategy specific hooks are defined, same as for the post persist hooks
+		strategy, hooks = config.HookGlobal, m.Config(ctx).SelfServiceFlowRegistrationAfterHooks(config.HookGlobal)
+	}
+
+	for _, v := range m.getHooks(strategy, hooks) {
 		if hook, ok := v.(registration.PostHookPrePersistExecutor); ok {
 			b = append(b, hook)
 		}
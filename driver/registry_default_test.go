@@ -242,6 +242,55 @@ func TestDriverDefault_Hooks(t *testing.T) {
 				assert.Equal(t, expectedExecutors, h)
 			})
 		}
+
+		// AFTER hooks running before the identity is persisted
+		for _, tc := range []struct {
+			uc     string
+			prep   func(conf *config.Config)
+			expect func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor
+		}{
+			{
+				uc:     "No hooks configured",
+				prep:   func(conf *config.Config) {},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor { return nil },
+			},
+			{
+				uc: "A web_hook is configured on a global level",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "foo", "method": "POST", "response": map[string]interface{}{"parse": true}}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor {
+					return []registration.PostHookPrePersistExecutor{
+						hook.NewWebHook(reg, json.RawMessage(`{"method":"POST","response":{"parse":true},"url":"foo"}`)),
+					}
+				},
+			},
+			{
+				uc: "Hooks are configured on a global level, as well as on a strategy level",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".password.hooks", []map[string]interface{}{
+						{"hook": "session"},
+					})
+					conf.MustSet(config.ViperKeySelfServiceRegistrationAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "bar", "method": "POST"}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []registration.PostHookPrePersistExecutor { return nil },
+			},
+		} {
+			t.Run(fmt.Sprintf("after/pre-persist/uc=%s", tc.uc), func(t *testing.T) {
+				conf, reg := internal.NewFastRegistryWithMocks(t)
+				tc.prep(conf)
+
+				h := reg.PostRegistrationPrePersistHooks(ctx, identity.CredentialsTypePassword)
+
+				expectedExecutors := tc.expect(reg)
+				require.Len(t, h, len(expectedExecutors))
+				assert.Equal(t, expectedExecutors, h)
+			})
+		}
 	})
 
 	t.Run("type=login", func(t *testing.T) {
@@ -473,6 +522,59 @@ func TestDriverDefault_Hooks(t *testing.T) {
 				assert.Equal(t, expectedExecutors, h)
 			})
 		}
+
+		// AFTER hooks running before the identity is persisted
+		for _, tc := range []struct {
+			uc     string
+			prep   func(conf *config.Config)
+			expect func(reg *driver.RegistryDefault) []settings.PostHookPrePersistExecutor
+		}{
+			{
+				uc:     "No hooks configured",
+				prep:   func(conf *config.Config) {},
+				expect: func(reg *driver.RegistryDefault) []settings.PostHookPrePersistExecutor { return nil },
+			},
+			{
+				uc: "A web_hook is configured on a global level",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "foo", "method": "POST", "response": map[string]interface{}{"parse": true}}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []settings.PostHookPrePersistExecutor {
+					return []settings.PostHookPrePersistExecutor{
+						hook.NewWebHook(reg, json.RawMessage(`{"method":"POST","response":{"parse":true},"url":"foo"}`)),
+					}
+				},
+			},
+			{
+				uc: "Hooks are configured on a global level, as well as on a strategy level",
+				prep: func(conf *config.Config) {
+					conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".profile.hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "foo", "method": "GET"}},
+					})
+					conf.MustSet(config.ViperKeySelfServiceSettingsAfter+".hooks", []map[string]interface{}{
+						{"hook": "web_hook", "config": map[string]interface{}{"url": "foo", "method": "POST"}},
+					})
+				},
+				expect: func(reg *driver.RegistryDefault) []settings.PostHookPrePersistExecutor {
+					return []settings.PostHookPrePersistExecutor{
+						hook.NewWebHook(reg, json.RawMessage(`{"method":"GET","url":"foo"}`)),
+					}
+				},
+			},
+		} {
+			t.Run(fmt.Sprintf("after/pre-persist/uc=%s", tc.uc), func(t *testing.T) {
+				conf, reg := internal.NewFastRegistryWithMocks(t)
+				tc.prep(conf)
+
+				h := reg.PostSettingsPrePersistHooks(ctx, "profile")
+
+				expectedExecutors := tc.expect(reg)
+				require.Len(t, h, len(expectedExecutors))
+				assert.Equal(t, expectedExecutors, h)
+			})
+		}
 	})
 }
 
@@ -713,7 +815,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all recovery strategies", func(t *testing.T) {
-		expects := []string{"link"}
+		expects := []string{"link", "code"}
 		s := reg.AllRecoveryStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/x"
+)
+
+// Type identifies what happened to an identity.
+type Type string
+
+const (
+	TypeIdentityCreated   Type = "identity.created"
+	TypeIdentityUpdated   Type = "identity.updated"
+	TypeIdentityDeleted   Type = "identity.deleted"
+	TypeSessionIssued     Type = "session.issued"
+	TypeSessionRevoked    Type = "session.revoked"
+	TypeLoginFailed       Type = "login.failed"
+	TypeAddressVerified   Type = "address.verified"
+	TypeRecoveryCompleted Type = "recovery.completed"
+)
+
+// Event describes a change in the lifecycle of an identity.
+type Event struct {
+	// ID is unique for every event and can be used by consumers to detect duplicates.
+	ID uuid.UUID `json:"id"`
+
+	// Type is what happened.
+	Type Type `json:"type"`
+
+	// Time is when it happened.
+	Time time.Time `json:"time"`
+
+	// IdentityID is the identity the event is about. It is not set if a login failed for an unknown identifier.
+	IdentityID *uuid.UUID `json:"identity_id,omitempty"`
+
+	// SessionID is the session the event is about. It is not set if several sessions were revoked at once.
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+
+	// FlowID is the self-service flow which caused the event, if any.
+	FlowID *uuid.UUID `json:"flow_id,omitempty"`
+
+	// Data holds additional information depending on the event's type.
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// New returns an event of the given type about the identity. Pass uuid.Nil if the identity is not known.
+func New(t Type, identityID uuid.UUID) *Event {
+	return &Event{
+		ID:         x.NewUUID(),
+		Type:       t,
+		Time:       time.Now().UTC(),
+		IdentityID: x.PointToUUID(identityID),
+	}
+}
+
+func (e *Event) WithSessionID(id uuid.UUID) *Event {
+	e.SessionID = x.PointToUUID(id)
+	return e
+}
+
+func (e *Event) WithFlowID(id uuid.UUID) *Event {
+	e.FlowID = x.PointToUUID(id)
+	return e
+}
+
+func (e *Event) WithData(key string, value interface{}) *Event {
+	if e.Data == nil {
+		e.Data = map[string]interface{}{}
+	}
+	e.Data[key] = value
+	return e
+}
+
+// NewAddressVerified returns the event for an address of the identity which was verified in the given flow.
+func NewAddressVerified(identityID, flowID uuid.UUID, address, via string) *Event {
+	return New(TypeAddressVerified, identityID).
+		WithFlowID(flowID).
+		WithData("address", address).
+		WithData("via", via)
+}
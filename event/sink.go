@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/webhook"
+)
+
+const (
+	SinkTypeHTTP   = "http"
+	SinkTypeFile   = "file"
+	SinkTypeStdout = "stdout"
+)
+
+// passthroughTemplate is used as the body of HTTP sinks without a template and sends the event as is
+// (`function(ctx) ctx`).
+const passthroughTemplate = "base64://ZnVuY3Rpb24oY3R4KSBjdHg="
+
+// Sink receives events.
+type Sink interface {
+	Emit(ctx context.Context, e *Event) error
+}
+
+type httpSinkDependencies interface {
+	webhook.PersistenceProvider
+	cipher.Provider
+}
+
+// httpSink writes events to the web hook outbox, from where they are delivered and retried in the background.
+type httpSink struct {
+	d    httpSinkDependencies
+	conf json.RawMessage
+}
+
+func newHTTPSink(d httpSinkDependencies, conf json.RawMessage) (*httpSink, error) {
+	var err error
+	if !gjson.GetBytes(conf, "method").Exists() {
+		if conf, err = sjson.SetBytes(conf, "method", "POST"); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	if !gjson.GetBytes(conf, "body").Exists() {
+		if conf, err = sjson.SetBytes(conf, "body", passthroughTemplate); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return &httpSink{d: d, conf: conf}, nil
+}
+
+func (s *httpSink) Emit(ctx context.Context, e *Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	d, err := webhook.NewDelivery(ctx, s.d.Cipher(), s.conf, payload)
+	if err != nil {
+		return err
+	}
+
+	return s.d.WebHookPersister().AddDelivery(ctx, d)
+}
+
+// ndjsonSink writes every event as a single line of JSON.
+type ndjsonSink struct {
+	mu   *sync.Mutex
+	open func() (io.WriteCloser, error)
+}
+
+func newFileSink(mu *sync.Mutex, path string) *ndjsonSink {
+	return &ndjsonSink{mu: mu, open: func() (io.WriteCloser, error) {
+		// The file is opened for every event so that it can be rotated without restarting.
+		return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	}}
+}
+
+func newWriterSink(mu *sync.Mutex, w io.Writer) *ndjsonSink {
+	return &ndjsonSink{mu: mu, open: func() (io.WriteCloser, error) {
+		return nopCloser{w}, nil
+	}}
+}
+
+func (s *ndjsonSink) Emit(_ context.Context, e *Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.open()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		_ = w.Close()
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(w.Close())
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
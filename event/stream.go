@@ -0,0 +1,92 @@
+package event
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/webhook"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	streamDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		webhook.PersistenceProvider
+		cipher.Provider
+	}
+	StreamProvider interface {
+		EventStream() *Stream
+	}
+
+	// Stream sends identity lifecycle events to the sinks configured in `events.sinks`.
+	Stream struct {
+		d streamDependencies
+
+		// mu serializes writes of newline-delimited JSON sinks so that lines never interleave.
+		mu sync.Mutex
+	}
+)
+
+func NewStream(d streamDependencies) *Stream {
+	return &Stream{d: d}
+}
+
+// Emit sends the event to all configured sinks. Errors are logged but not returned because an event must
+// never fail the action it describes.
+//
+// If the context carries a transaction, HTTP sinks write the event to the outbox within the transaction, so
+// that it is discarded together with the action if the transaction is rolled back. All other sinks can not
+// take back an event, which is why they only receive it once the transaction was committed.
+func (s *Stream) Emit(ctx context.Context, e *Event) {
+	for _, c := range s.d.Config(ctx).EventSinks() {
+		sink, err := s.sink(c)
+		if err != nil {
+			s.logError(err, e, c)
+			continue
+		}
+
+		if c.Type == SinkTypeHTTP {
+			s.emit(ctx, sink, e, c)
+			continue
+		}
+
+		c := c
+		x.OnCommit(ctx, func() {
+			s.emit(ctx, sink, e, c)
+		})
+	}
+}
+
+func (s *Stream) emit(ctx context.Context, sink Sink, e *Event, c config.EventSink) {
+	if err := sink.Emit(ctx, e); err != nil {
+		s.logError(err, e, c)
+	}
+}
+
+func (s *Stream) logError(err error, e *Event, c config.EventSink) {
+	s.d.Logger().
+		WithError(err).
+		WithField("event_id", e.ID).
+		WithField("event_type", e.Type).
+		WithField("event_sink", c.Type).
+		Error("Unable to emit event.")
+}
+
+func (s *Stream) sink(c config.EventSink) (Sink, error) {
+	switch c.Type {
+	case SinkTypeHTTP:
+		return newHTTPSink(s.d, c.Config)
+	case SinkTypeFile:
+		return newFileSink(&s.mu, gjson.GetBytes(c.Config, "path").String()), nil
+	case SinkTypeStdout:
+		return newWriterSink(&s.mu, os.Stdout), nil
+	}
+	return nil, errors.Errorf("unknown event sink type: %s", c.Type)
+}
@@ -0,0 +1,138 @@
+package event_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/webhook"
+	"github.com/ory/kratos/x"
+)
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object"}`))
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	conf.MustSet(config.ViperKeyEventSinks, []map[string]interface{}{
+		{"type": "file", "config": map[string]interface{}{"path": path}},
+		{"type": "http", "config": map[string]interface{}{"url": "https://example.org/events"}},
+	})
+
+	readEvents := func(t *testing.T) (events []event.Event) {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e event.Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			events = append(events, e)
+		}
+		require.NoError(t, scanner.Err())
+		return events
+	}
+
+	t.Run("case=writes events to all sinks", func(t *testing.T) {
+		sessionID := x.NewUUID()
+		expected := event.New(event.TypeSessionIssued, x.NewUUID()).WithSessionID(sessionID).WithData("foo", "bar")
+		reg.EventStream().Emit(ctx, expected)
+
+		events := readEvents(t)
+		require.Len(t, events, 1)
+		assert.Equal(t, expected.ID, events[0].ID)
+		assert.Equal(t, event.TypeSessionIssued, events[0].Type)
+		assert.Equal(t, *expected.IdentityID, *events[0].IdentityID)
+		assert.Equal(t, sessionID, *events[0].SessionID)
+		assert.Nil(t, events[0].FlowID)
+		assert.Equal(t, "bar", events[0].Data["foo"])
+
+		deliveries, total, err := reg.WebHookPersister().ListDeliveries(ctx, webhook.DeliveryStatusQueued, 0, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, total)
+		assert.Equal(t, "https://example.org/events", deliveries[0].URL)
+		assert.Equal(t, "POST", deliveries[0].Method)
+		payload, err := reg.Cipher().Decrypt(ctx, deliveries[0].Payload)
+		require.NoError(t, err)
+		assert.Equal(t, expected.ID.String(), gjson.GetBytes(payload, "id").String())
+		deliveryConf, err := reg.Cipher().Decrypt(ctx, deliveries[0].Config)
+		require.NoError(t, err)
+		assert.NotEmpty(t, gjson.GetBytes(deliveryConf, "body").String(), "a body template is required to deliver the payload")
+	})
+
+	t.Run("case=emits identity lifecycle events", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		require.NoError(t, reg.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits))
+
+		events := readEvents(t)
+		require.Len(t, events, 2)
+		assert.Equal(t, event.TypeIdentityCreated, events[0].Type)
+		assert.Equal(t, event.TypeIdentityUpdated, events[1].Type)
+		for _, e := range events {
+			assert.Equal(t, i.ID, *e.IdentityID)
+			assert.Equal(t, i.ID.String(), gjson.Get(mustMarshal(t, e.Data), "identity.id").String())
+		}
+	})
+
+	t.Run("case=only emits events of committed transactions", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+		countDeliveries := func(t *testing.T) int64 {
+			_, total, err := reg.WebHookPersister().ListDeliveries(ctx, "", 0, 1)
+			require.NoError(t, err)
+			return total
+		}
+		before := countDeliveries(t)
+
+		rolledBack := event.New(event.TypeIdentityCreated, x.NewUUID())
+		require.Error(t, reg.TransactionPersister().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+			reg.EventStream().Emit(ctx, rolledBack)
+			return errors.New("roll back")
+		}))
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "the file sink must not receive events of rolled back transactions")
+		assert.Equal(t, before, countDeliveries(t), "the outbox must be rolled back together with the action")
+
+		committed := event.New(event.TypeIdentityCreated, x.NewUUID())
+		require.NoError(t, reg.TransactionPersister().Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+			reg.EventStream().Emit(ctx, committed)
+			_, err := os.Stat(path)
+			assert.True(t, os.IsNotExist(err), "the file sink must only receive the event once the transaction is committed")
+			return nil
+		}))
+		events := readEvents(t)
+		require.Len(t, events, 1)
+		assert.Equal(t, committed.ID, events[0].ID)
+		assert.Equal(t, before+1, countDeliveries(t))
+	})
+
+	t.Run("case=failing sinks do not fail the action", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyEventSinks, []map[string]interface{}{{"type": "file", "config": map[string]interface{}{"path": t.TempDir()}}})
+		reg.EventStream().Emit(ctx, event.New(event.TypeLoginFailed, x.NewUUID()))
+	})
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(out)
+}
@@ -1,17 +1,27 @@
 package hash
 
 import (
+	"bytes"
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"  // #nosec G501 - compatibility for imported passwords
+	"crypto/sha1" // #nosec G505 - compatibility for imported passwords
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"hash"
 	"regexp"
 	"strings"
 
+	"github.com/inhies/go-bytesize"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 
 	"github.com/ory/kratos/driver/config"
 )
@@ -26,6 +36,16 @@ func Compare(ctx context.Context, password []byte, hash []byte) error {
 		return CompareArgon2id(ctx, password, hash)
 	case IsPbkdf2Hash(hash):
 		return ComparePbkdf2(ctx, password, hash)
+	case IsArgon2iHash(hash):
+		return CompareArgon2i(ctx, password, hash)
+	case IsScryptHash(hash):
+		return CompareScrypt(ctx, password, hash)
+	case IsFirebaseScryptHash(hash):
+		return CompareFirebaseScrypt(ctx, password, hash)
+	case IsSSHAHash(hash):
+		return CompareSSHA(ctx, password, hash)
+	case IsMD5CryptHash(hash):
+		return CompareMD5Crypt(ctx, password, hash)
 	default:
 		return errors.WithStack(ErrUnknownHashAlgorithm)
 	}
@@ -47,7 +67,7 @@ func CompareBcrypt(_ context.Context, password []byte, hash []byte) error {
 func CompareArgon2id(_ context.Context, password []byte, hash []byte) error {
 	// Extract the parameters, salt and derived key from the encoded password
 	// hash.
-	p, salt, hash, err := decodeArgon2idHash(string(hash))
+	p, salt, hash, err := decodeArgon2Hash(string(hash))
 	if err != nil {
 		return err
 	}
@@ -84,10 +104,107 @@ func ComparePbkdf2(_ context.Context, password []byte, hash []byte) error {
 	return errors.WithStack(ErrMismatchedHashAndPassword)
 }
 
+// CompareArgon2i compares an argon2i hash, which is only supported for imported passwords.
+func CompareArgon2i(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeArgon2Hash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	otherHash := argon2.Key(password, salt, p.Iterations, uint32(p.Memory), p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+func CompareScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, err := decodeScryptHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	otherHash, err := scrypt.Key(password, salt, 1<<p.LogCost, p.BlockSize, p.Parallelism, len(hash))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// CompareFirebaseScrypt compares a hash created with Firebase's modified scrypt algorithm. The password
+// and the salt concatenated with the salt separator are used to derive a key, which encrypts the signer
+// key using AES-256 in CTR mode. The result must match the hash.
+func CompareFirebaseScrypt(_ context.Context, password []byte, hash []byte) error {
+	p, salt, hash, saltSeparator, signerKey, err := decodeFirebaseScryptHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key(password, append(salt, saltSeparator...), 1<<p.LogCost, p.BlockSize, p.Parallelism, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	otherHash := make([]byte, len(signerKey))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(otherHash, signerKey)
+
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// CompareSSHA compares a salted SHA hash in the format used by LDAP servers.
+// format: {SSHA|SSHA256|SSHA512}<base64(digest(password + salt) + salt)>
+func CompareSSHA(_ context.Context, password []byte, hash []byte) error {
+	newHash, salt, hash, err := decodeSSHAHash(string(hash))
+	if err != nil {
+		return err
+	}
+
+	h := newHash()
+	_, _ = h.Write(password)
+	_, _ = h.Write(salt)
+
+	if subtle.ConstantTimeCompare(hash, h.Sum(nil)) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
+// CompareMD5Crypt compares a hash created with the MD5 based crypt(3) algorithm.
+// format: $1$<salt>$<hash>
+func CompareMD5Crypt(_ context.Context, password []byte, hash []byte) error {
+	if err := validateMD5CryptHash(string(hash)); err != nil {
+		return err
+	}
+
+	otherHash := md5Crypt(password, []byte(strings.Split(string(hash), "$")[2]))
+	if subtle.ConstantTimeCompare(hash, otherHash) == 1 {
+		return nil
+	}
+	return errors.WithStack(ErrMismatchedHashAndPassword)
+}
+
 var (
 	isBcryptHash   = regexp.MustCompile(`^\$2[abzy]?\$`)
 	isArgon2idHash = regexp.MustCompile(`^\$argon2id\$`)
 	isPbkdf2Hash   = regexp.MustCompile(`^\$pbkdf2-sha[0-9]{1,3}\$`)
+
+	isArgon2iHash        = regexp.MustCompile(`^\$argon2i\$`)
+	isScryptHash         = regexp.MustCompile(`^\$scrypt\$`)
+	isFirebaseScryptHash = regexp.MustCompile(`^\$firescrypt\$`)
+	isSSHAHash           = regexp.MustCompile(`^{SSHA(256|512)?}`)
+	isMD5CryptHash       = regexp.MustCompile(`^\$1\$`)
 )
 
 func IsBcryptHash(hash []byte) bool {
@@ -102,7 +219,78 @@ func IsPbkdf2Hash(hash []byte) bool {
 	return isPbkdf2Hash.Match(hash)
 }
 
-func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte, err error) {
+func IsArgon2iHash(hash []byte) bool {
+	return isArgon2iHash.Match(hash)
+}
+
+func IsScryptHash(hash []byte) bool {
+	return isScryptHash.Match(hash)
+}
+
+func IsFirebaseScryptHash(hash []byte) bool {
+	return isFirebaseScryptHash.Match(hash)
+}
+
+func IsSSHAHash(hash []byte) bool {
+	return isSSHAHash.Match(hash)
+}
+
+func IsMD5CryptHash(hash []byte) bool {
+	return isMD5CryptHash.Match(hash)
+}
+
+// IsValidHashFormat returns whether the hash is in a format which can be compared using Compare. Hashes with
+// parameters and segments, for example a salt, are only valid if they can be decoded.
+func IsValidHashFormat(hash []byte) bool {
+	var err error
+	switch {
+	case IsBcryptHash(hash):
+	case IsArgon2idHash(hash), IsArgon2iHash(hash):
+		_, _, _, err = decodeArgon2Hash(string(hash))
+	case IsPbkdf2Hash(hash):
+		_, _, _, err = decodePbkdf2Hash(string(hash))
+	case IsScryptHash(hash):
+		_, _, _, err = decodeScryptHash(string(hash))
+	case IsFirebaseScryptHash(hash):
+		_, _, _, _, _, err = decodeFirebaseScryptHash(string(hash))
+	case IsSSHAHash(hash):
+		_, _, _, err = decodeSSHAHash(string(hash))
+	case IsMD5CryptHash(hash):
+		err = validateMD5CryptHash(string(hash))
+	default:
+		return false
+	}
+	return err == nil
+}
+
+const (
+	// minSaltLength and minKeyLength are the minimum lengths in bytes of salts and derived keys of decoded hashes.
+	// A derived key must never be empty because an empty key matches every password.
+	minSaltLength = 8
+	minKeyLength  = 16
+
+	// maxScryptMemory limits the memory, in bytes, which one scrypt hash may use during the comparison.
+	maxScryptMemory = 256 << 20
+	// maxScryptParallelism limits the number of sequential scrypt mixes for one hash.
+	maxScryptParallelism = 16
+
+	// maxArgon2Memory limits the memory, in KiB, which one argon2 hash may use during the comparison.
+	maxArgon2Memory = 4 << 20
+	// maxArgon2Iterations and maxArgon2Parallelism limit the passes and lanes of one argon2 hash.
+	maxArgon2Iterations  = 128
+	maxArgon2Parallelism = 255
+)
+
+func validateSaltAndKey(salt, key []byte) error {
+	if len(salt) < minSaltLength || len(key) < minKeyLength {
+		return errors.WithStack(ErrInvalidHash)
+	}
+	return nil
+}
+
+// decodeArgon2Hash decodes argon2id and argon2i encoded password hashes.
+// format: $argon2<variant>$v=<version>$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+func decodeArgon2Hash(encodedHash string) (p *config.Argon2, salt, hash []byte, err error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
 		return nil, nil, nil, ErrInvalidHash
@@ -117,11 +305,23 @@ func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte
 		return nil, nil, nil, ErrIncompatibleVersion
 	}
 
-	p = new(config.Argon2)
-	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism)
+	var memory, iterations, parallelism uint64
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	// argon2.Key panics for zero passes or lanes, and crafted parameters could otherwise exhaust the memory.
+	if memory < 1 || memory > maxArgon2Memory ||
+		iterations < 1 || iterations > maxArgon2Iterations ||
+		parallelism < 1 || parallelism > maxArgon2Parallelism {
+		return nil, nil, nil, errors.WithStack(ErrInvalidHash)
+	}
+
+	p = &config.Argon2{
+		Memory:      bytesize.ByteSize(memory),
+		Iterations:  uint32(iterations),
+		Parallelism: uint8(parallelism),
+	}
 
 	salt, err = base64.RawStdEncoding.Strict().DecodeString(parts[4])
 	if err != nil {
@@ -135,6 +335,10 @@ func decodeArgon2idHash(encodedHash string) (p *config.Argon2, salt, hash []byte
 	}
 	p.KeyLength = uint32(len(hash))
 
+	if err := validateSaltAndKey(salt, hash); err != nil {
+		return nil, nil, nil, err
+	}
+
 	return p, salt, hash, nil
 }
 
@@ -170,5 +374,208 @@ func decodePbkdf2Hash(encodedHash string) (p *Pbkdf2, salt, hash []byte, err err
 	}
 	p.KeyLength = uint32(len(hash))
 
+	if err := validateSaltAndKey(salt, hash); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return p, salt, hash, nil
+}
+
+type scryptParams struct {
+	LogCost     uint
+	BlockSize   int
+	Parallelism int
+}
+
+func decodeScryptParams(encoded string) (p *scryptParams, err error) {
+	p = new(scryptParams)
+	_, err = fmt.Sscanf(encoded, "ln=%d,r=%d,p=%d", &p.LogCost, &p.BlockSize, &p.Parallelism)
+	if err != nil {
+		return nil, err
+	}
+	if p.LogCost < 1 || p.LogCost > 31 || p.BlockSize < 1 || p.Parallelism < 1 || p.Parallelism > maxScryptParallelism {
+		return nil, ErrInvalidHash
+	}
+	// scrypt allocates 128 * r * N bytes, so crafted parameters could otherwise exhaust the memory.
+	if uint64(128*p.BlockSize)<<p.LogCost > maxScryptMemory {
+		return nil, ErrInvalidHash
+	}
+	return p, nil
+}
+
+// decodeScryptHash decodes scrypt encoded password hash.
+// format: $scrypt$ln=<log2(cost)>,r=<block size>,p=<parallelism>$<salt>$<hash>
+func decodeScryptHash(encodedHash string) (p *scryptParams, salt, hash []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	p, err = decodeScryptParams(parts[2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err = decodeBase64(parts[3])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash, err = decodeBase64(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := validateSaltAndKey(salt, hash); err != nil {
+		return nil, nil, nil, err
+	}
+
 	return p, salt, hash, nil
 }
+
+// decodeFirebaseScryptHash decodes a password hash exported from Firebase. The cost and block size
+// correspond to the "mem_cost" and "rounds" of Firebase's hash configuration.
+// format: $firescrypt$ln=<mem cost>,r=<rounds>,p=<parallelism>$<salt>$<hash>$<salt separator>$<signer key>
+func decodeFirebaseScryptHash(encodedHash string) (p *scryptParams, salt, hash, saltSeparator, signerKey []byte, err error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 7 {
+		return nil, nil, nil, nil, nil, ErrInvalidHash
+	}
+
+	p, err = decodeScryptParams(parts[2])
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	decoded := make([][]byte, 4)
+	for k := range decoded {
+		decoded[k], err = decodeBase64(parts[k+3])
+		if err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+	}
+
+	// The hash is the encrypted signer key, which is why both must have the same length.
+	if err := validateSaltAndKey(decoded[0], decoded[1]); err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	if len(decoded[3]) != len(decoded[1]) {
+		return nil, nil, nil, nil, nil, errors.WithStack(ErrInvalidHash)
+	}
+
+	return p, decoded[0], decoded[1], decoded[2], decoded[3], nil
+}
+
+func validateMD5CryptHash(encodedHash string) error {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 4 || len(parts[2]) > 8 {
+		return errors.WithStack(ErrInvalidHash)
+	}
+	return nil
+}
+
+func decodeSSHAHash(encodedHash string) (newHash func() hash.Hash, salt, digest []byte, err error) {
+	end := strings.Index(encodedHash, "}")
+	if end < 0 {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	switch encodedHash[1:end] {
+	case "SSHA":
+		newHash = sha1.New
+	case "SSHA256":
+		newHash = sha256.New
+	case "SSHA512":
+		newHash = sha512.New
+	default:
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encodedHash[end+1:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	size := newHash().Size()
+	if len(decoded) <= size {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return newHash, decoded[size:], decoded[:size], nil
+}
+
+// decodeBase64 decodes standard base64 with or without padding, as both variants are common in exported hashes.
+func decodeBase64(encoded string) ([]byte, error) {
+	return base64.RawStdEncoding.Strict().DecodeString(strings.TrimRight(encoded, "="))
+}
+
+const md5CryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// md5Crypt implements the MD5 based crypt(3) algorithm as introduced by FreeBSD.
+func md5Crypt(password, salt []byte) []byte {
+	const magic = "$1$"
+
+	alternate := md5.New() // #nosec G401 - compatibility for imported passwords
+	alternate.Write(password)
+	alternate.Write(salt)
+	alternate.Write(password)
+	alternateSum := alternate.Sum(nil)
+
+	h := md5.New() // #nosec G401 - compatibility for imported passwords
+	h.Write(password)
+	h.Write([]byte(magic))
+	h.Write(salt)
+	for i := len(password); i > 0; i -= 16 {
+		if i > 16 {
+			h.Write(alternateSum)
+		} else {
+			h.Write(alternateSum[:i])
+		}
+	}
+	for i := len(password); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			h.Write([]byte{0})
+		} else {
+			h.Write(password[:1])
+		}
+	}
+	sum := h.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		h := md5.New() // #nosec G401 - compatibility for imported passwords
+		if i&1 == 1 {
+			h.Write(password)
+		} else {
+			h.Write(sum)
+		}
+		if i%3 != 0 {
+			h.Write(salt)
+		}
+		if i%7 != 0 {
+			h.Write(password)
+		}
+		if i&1 == 1 {
+			h.Write(sum)
+		} else {
+			h.Write(password)
+		}
+		sum = h.Sum(nil)
+	}
+
+	var out bytes.Buffer
+	out.WriteString(magic)
+	out.Write(salt)
+	out.WriteByte('$')
+	encode := func(v uint, n int) {
+		for ; n > 0; n-- {
+			out.WriteByte(md5CryptAlphabet[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, i := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		encode(uint(sum[i[0]])<<16|uint(sum[i[1]])<<8|uint(sum[i[2]]), 4)
+	}
+	encode(uint(sum[11]), 2)
+
+	return out.Bytes()
+}
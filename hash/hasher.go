@@ -9,6 +9,10 @@ type Hasher interface {
 
 	// Understands returns whether the given hash can be understood by this hasher.
 	Understands(hash []byte) bool
+
+	// NeedsRehash returns whether the given hash should be regenerated because it was either not created
+	// by this hasher or was created using parameters which differ from the current configuration.
+	NeedsRehash(ctx context.Context, hash []byte) bool
 }
 
 type HashProvider interface {
@@ -67,3 +67,23 @@ func (h *Argon2) Generate(ctx context.Context, password []byte) ([]byte, error)
 func (h *Argon2) Understands(hash []byte) bool {
 	return IsArgon2idHash(hash)
 }
+
+func (h *Argon2) NeedsRehash(ctx context.Context, hash []byte) bool {
+	if !h.Understands(hash) {
+		return true
+	}
+
+	current, _, _, err := decodeArgon2Hash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	p := h.c.Config(ctx).HasherArgon2()
+
+	// decodeArgon2Hash stores the memory parameter in KiB as it is encoded in the hash.
+	return uint32(current.Memory) != toKB(p.Memory) ||
+		current.Iterations != p.Iterations ||
+		current.Parallelism != p.Parallelism ||
+		current.SaltLength != p.SaltLength ||
+		current.KeyLength != p.KeyLength
+}
@@ -51,6 +51,22 @@ func (h *Pbkdf2) Understands(hash []byte) bool {
 	return IsPbkdf2Hash(hash)
 }
 
+func (h *Pbkdf2) NeedsRehash(_ context.Context, hash []byte) bool {
+	if !h.Understands(hash) {
+		return true
+	}
+
+	current, _, _, err := decodePbkdf2Hash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	return current.Algorithm != h.Algorithm ||
+		current.Iterations != h.Iterations ||
+		current.SaltLength != h.SaltLength ||
+		current.KeyLength != h.KeyLength
+}
+
 func getPseudorandomFunctionForPbkdf2(alg string) func() hash.Hash {
 	switch alg {
 	case "sha1":
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/internal"
 )
@@ -195,6 +196,60 @@ func TestPbkdf2Hasher(t *testing.T) {
 	}
 }
 
+func TestNeedsRehash(t *testing.T) {
+	pw := mkpw(t, 32)
+
+	t.Run("hasher=argon2", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		hasher := hash.NewHasherArgon2(reg)
+
+		hs, err := hasher.Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.False(t, hasher.NeedsRehash(context.Background(), hs))
+
+		conf.MustSet(config.ViperKeyHasherArgon2ConfigIterations, 2)
+		assert.True(t, hasher.NeedsRehash(context.Background(), hs))
+
+		hs, err = hasher.Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.False(t, hasher.NeedsRehash(context.Background(), hs))
+
+		conf.MustSet(config.ViperKeyHasherArgon2ConfigMemory, "32MB")
+		assert.True(t, hasher.NeedsRehash(context.Background(), hs))
+
+		bhs, err := hash.NewHasherBcrypt(reg).Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.True(t, hasher.NeedsRehash(context.Background(), bhs))
+	})
+
+	t.Run("hasher=bcrypt", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		hasher := hash.NewHasherBcrypt(reg)
+
+		hs, err := hasher.Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.False(t, hasher.NeedsRehash(context.Background(), hs))
+
+		conf.MustSet(config.ViperKeyHasherBcryptCost, 5)
+		assert.True(t, hasher.NeedsRehash(context.Background(), hs))
+
+		ahs, err := hash.NewHasherArgon2(reg).Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.True(t, hasher.NeedsRehash(context.Background(), ahs))
+	})
+
+	t.Run("hasher=pbkdf2", func(t *testing.T) {
+		hasher := &hash.Pbkdf2{Algorithm: "sha256", Iterations: 1000, SaltLength: 16, KeyLength: 32}
+
+		hs, err := hasher.Generate(context.Background(), pw)
+		require.NoError(t, err)
+		assert.False(t, hasher.NeedsRehash(context.Background(), hs))
+
+		hasher.Iterations = 2000
+		assert.True(t, hasher.NeedsRehash(context.Background(), hs))
+	})
+}
+
 func TestCompare(t *testing.T) {
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$unknown$12$o6hx.Wog/wvFSkT/Bp/6DOxCtLRTDj7lm9on9suF/WaCGNVHbkfL6")))
 
@@ -222,6 +277,65 @@ func TestCompare(t *testing.T) {
 	assert.Nil(t, hash.ComparePbkdf2(context.Background(), []byte("test"), []byte("$pbkdf2-sha512$i=100000,l=32$bdHBpn7OWOivJMVJypy2UqR0UnaD5prQXRZevj/05YU$+wArTfv1a+bNGO1iZrmEdVjhA+lL11wF4/IxpgYfPwc")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha512$i=100000,l=32$bdHBpn7OWOivJMVJypy2UqR0UnaD5prQXRZevj/05YU$+wArTfv1a+bNGO1iZrmEdVjhA+lL11wF4/IxpgYfPww")))
 
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to")))
+	assert.Nil(t, hash.CompareArgon2i(context.Background(), []byte("test"), []byte("$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7tp")))
+	assert.Error(t, hash.CompareArgon2id(context.Background(), []byte("test"), []byte("$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=14,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s")))
+	assert.Nil(t, hash.CompareScrypt(context.Background(), []byte("test"), []byte("$scrypt$ln=14,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=14,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1t")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$ln=64,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$scrypt$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$H1DDtIra0tMozRsHkETxo7z8ghYLBa8JH4T6psdWPMyvng84YBXE3UJHnSUgw5LlObVxNXi9ZolNzLBpoF/COw==$Bw==$fuX/Cx1lSbxLDwX2d3IpW2KOtDWRt1P5ihlHAiW92UJMGfDxb6lKmWVgwIpXHq2yL3DbhMiTkPDnKfAB8uXNvw==")))
+	assert.Nil(t, hash.CompareFirebaseScrypt(context.Background(), []byte("test"), []byte("$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$H1DDtIra0tMozRsHkETxo7z8ghYLBa8JH4T6psdWPMyvng84YBXE3UJHnSUgw5LlObVxNXi9ZolNzLBpoF/COw==$Bw==$fuX/Cx1lSbxLDwX2d3IpW2KOtDWRt1P5ihlHAiW92UJMGfDxb6lKmWVgwIpXHq2yL3DbhMiTkPDnKfAB8uXNvw==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$H1DDtIra0tMozRsHkETxo7z8ghYLBa8JH4T6psdWPMyvng84YBXE3UJHnSUgw5LlObVxNXi9ZolNzLBpoF/COw==$Bg==$fuX/Cx1lSbxLDwX2d3IpW2KOtDWRt1P5ihlHAiW92UJMGfDxb6lKmWVgwIpXHq2yL3DbhMiTkPDnKfAB8uXNvw==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$H1DDtIra0tMozRsHkETxo7z8ghYLBa8JH4T6psdWPMyvng84YBXE3UJHnSUgw5LlObVxNXi9ZolNzLBpoF/COw==$Bw==")))
+
+	for _, h := range []string{
+		// Empty or short keys and salts must not match any password.
+		"$scrypt$ln=4,r=8,p=1$c2FsdA$",
+		"$scrypt$ln=14,r=8,p=1$$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s",
+		"$firescrypt$ln=4,r=8,p=1$c2FsdA$$c2Fs$",
+		"$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$$Bw==$",
+		"$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$",
+		"$argon2id$v=19$m=32,t=2,p=4$$MNzk5BtR2vUhrp6qQEjRNw",
+		"$pbkdf2-sha256$i=1,l=0$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$",
+		// Parameters exceeding the memory and parallelism limits.
+		"$scrypt$ln=31,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s",
+		"$scrypt$ln=14,r=8,p=1024$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s",
+		"$argon2i$v=19$m=4194305,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to",
+		"$argon2i$v=19$m=32,t=129,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to",
+		"$argon2id$v=19$m=32,t=2,p=256$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		// Parameters below the minimums make the argon2 key derivation panic.
+		"$argon2i$v=19$m=0,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to",
+		"$argon2i$v=19$m=32,t=0,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to",
+		"$argon2i$v=19$m=32,t=3,p=0$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to",
+		"$argon2id$v=19$m=32,t=0,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+		"$argon2id$v=19$m=32,t=2,p=0$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw",
+	} {
+		assert.Error(t, hash.Compare(context.Background(), []byte("anything"), []byte(h)), h)
+		assert.False(t, hash.IsValidHashFormat([]byte(h)), h)
+	}
+	assert.True(t, hash.IsValidHashFormat([]byte("$scrypt$ln=14,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$Y9yaDV7knp3lus6aBkJL5aRsVKU0SxIFg5gYdb7j/1s")))
+	assert.True(t, hash.IsValidHashFormat([]byte("$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$3p9jozdef4tIEC2u6RkHn4A1iDMudzUJR2qqdV3r7to")))
+	assert.True(t, hash.IsValidHashFormat([]byte("$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$H1DDtIra0tMozRsHkETxo7z8ghYLBa8JH4T6psdWPMyvng84YBXE3UJHnSUgw5LlObVxNXi9ZolNzLBpoF/COw==$Bw==$fuX/Cx1lSbxLDwX2d3IpW2KOtDWRt1P5ihlHAiW92UJMGfDxb6lKmWVgwIpXHq2yL3DbhMiTkPDnKfAB8uXNvw==")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA}JL+cl+abn3DHI9Kf1uDVNV1Am4iKHwJ30TMEvg==")))
+	assert.Nil(t, hash.CompareSSHA(context.Background(), []byte("test"), []byte("{SSHA}JL+cl+abn3DHI9Kf1uDVNV1Am4iKHwJ30TMEvg==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA}JL+cl+abn3DHI9Kf1uDVNV1Am4iKHwJ30TMEvw==")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA256}zKLkCosm3Rn0WYq1LsVW4GOSPLxwgM6B0J3b9bV4TmWKHwJ30TMEvg==")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA256}zKLkCosm3Rn0WYq1LsVW4GOSPLxwgM6B0J3b9bV4TmWKHwJ30TMEvw==")))
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA512}OKs7pwOVBdLochwJx4KySZ7OUtF+Q+JJUXlo7OorYYCC1rHPcbOZw4MWMcQ4Pv7tYwb1fq0fGpA5Y4TK9OgiBIofAnfRMwS+")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA512}OKs7pwOVBdLochwJx4KySZ7OUtF+Q+JJUXlo7OorYYCC1rHPcbOZw4MWMcQ4Pv7tYwb1fq0fGpA5Y4TK9OgiBIofAnfRMwS/")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("{SSHA512}JL+cl+abn3DHI9Kf1uDVNV1Am4iKHwJ30TMEvg==")))
+
+	assert.Nil(t, hash.Compare(context.Background(), []byte("test"), []byte("$1$HHkq5gKN$wSN6IeTWd.pEEMRV9L8sA0")))
+	assert.Nil(t, hash.CompareMD5Crypt(context.Background(), []byte("test"), []byte("$1$HHkq5gKN$wSN6IeTWd.pEEMRV9L8sA0")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$1$HHkq5gKN$wSN6IeTWd.pEEMRV9L8sA1")))
+	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$1$HHkq5gKNX$wSN6IeTWd.pEEMRV9L8sA0")))
+
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$aaaa$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI")))
 	assert.Error(t, hash.Compare(context.Background(), []byte("test"), []byte("$pbkdf2-sha256$i=100000,l=32$1jP+5Zxpxgtee/iPxGgOz0RfE9/KJuDElP1ley4VxXcc$QJxzfvdbHYBpydCbHoFg3GJEqMFULwskiuqiJctoYpI")))
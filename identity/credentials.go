@@ -56,6 +56,10 @@ const (
 	// CredentialsTypeRecoveryLink is a special credential type linked to the link strategy (recovery flow).
 	// It is not used within the credentials object itself.
 	CredentialsTypeRecoveryLink CredentialsType = "link_recovery"
+
+	// CredentialsTypeRecoveryCode is a special credential type linked to the code strategy (recovery flow).
+	// It is not used within the credentials object itself.
+	CredentialsTypeRecoveryCode CredentialsType = "code_recovery"
 )
 
 // Credentials represents a specific credential type
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -26,20 +27,59 @@ type CredentialsOIDCProvider struct {
 	InitialIDToken      string `json:"initial_id_token"`
 	InitialAccessToken  string `json:"initial_access_token"`
 	InitialRefreshToken string `json:"initial_refresh_token"`
+
+	// AccessToken and RefreshToken are the most recent tokens issued by the provider. Like the initial
+	// tokens, they are encrypted.
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// AccessTokenExpiresAt is when AccessToken expires. It is not set if the provider did not tell.
+	AccessTokenExpiresAt *time.Time `json:"access_token_expires_at,omitempty"`
+}
+
+// NewCredentialsOIDCProvider returns the credentials for an account at the provider linked with the given
+// encrypted tokens.
+func NewCredentialsOIDCProvider(idToken, accessToken, refreshToken string, expiresAt time.Time, provider, subject string) CredentialsOIDCProvider {
+	p := CredentialsOIDCProvider{
+		Subject:             subject,
+		Provider:            provider,
+		InitialIDToken:      idToken,
+		InitialAccessToken:  accessToken,
+		InitialRefreshToken: refreshToken,
+		AccessToken:         accessToken,
+		RefreshToken:        refreshToken,
+	}
+	if !expiresAt.IsZero() {
+		expiresAt = expiresAt.UTC()
+		p.AccessTokenExpiresAt = &expiresAt
+	}
+	return p
+}
+
+// CurrentAccessToken returns the most recent encrypted access token. Accounts linked before the tokens
+// were refreshed only have the initial token.
+func (p *CredentialsOIDCProvider) CurrentAccessToken() string {
+	if p.AccessToken != "" {
+		return p.AccessToken
+	}
+	return p.InitialAccessToken
+}
+
+// CurrentRefreshToken returns the most recent encrypted refresh token.
+func (p *CredentialsOIDCProvider) CurrentRefreshToken() string {
+	if p.RefreshToken != "" {
+		return p.RefreshToken
+	}
+	return p.InitialRefreshToken
 }
 
 // NewCredentialsOIDC creates a new OIDC credential.
-func NewCredentialsOIDC(idToken, accessToken, refreshToken, provider, subject string) (*Credentials, error) {
+func NewCredentialsOIDC(idToken, accessToken, refreshToken string, expiresAt time.Time, provider, subject string) (*Credentials, error) {
 	var b bytes.Buffer
 	if err := json.NewEncoder(&b).Encode(CredentialsOIDC{
 		Providers: []CredentialsOIDCProvider{
-			{
-				Subject:             subject,
-				Provider:            provider,
-				InitialIDToken:      idToken,
-				InitialAccessToken:  accessToken,
-				InitialRefreshToken: refreshToken,
-			}},
+			NewCredentialsOIDCProvider(idToken, accessToken, refreshToken, expiresAt, provider, subject),
+		},
 	}); err != nil {
 		return nil, errors.WithStack(x.PseudoPanic.
 			WithDebugf("Unable to encode password options to JSON: %s", err))
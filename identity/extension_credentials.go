@@ -32,8 +32,8 @@ func (r *SchemaExtensionCredentials) setIdentifier(ct CredentialsType, value int
 		}
 	}
 
-	r.v = stringslice.Unique(append(r.v, strings.ToLower(fmt.Sprintf("%s", value))))
-	cred.Identifiers = r.v
+	r.v[ct] = stringslice.Unique(append(r.v[ct], strings.ToLower(fmt.Sprintf("%s", value))))
+	cred.Identifiers = r.v[ct]
 	r.i.SetCredentials(ct, *cred)
 
 }
@@ -29,18 +29,15 @@ func (r *SchemaExtensionRecovery) Run(ctx jsonschema.ValidationContext, s schema
 			return ctx.Error("format", "%q is not valid %q", value, "email")
 		}
 
-		address := NewRecoveryEmailAddress(fmt.Sprintf("%s", value), r.i.ID)
+		r.appendAddress(NewRecoveryEmailAddress(fmt.Sprintf("%s", value), r.i.ID))
 
-		if has := r.has(r.i.RecoveryAddresses, address); has != nil {
-			if r.has(r.v, address) == nil {
-				r.v = append(r.v, *has)
-			}
-			return nil
+		return nil
+	case "phone":
+		if !jsonschema.Formats["tel"](value) {
+			return ctx.Error("format", "%q is not valid %q", value, "phone")
 		}
 
-		if has := r.has(r.v, address); has == nil {
-			r.v = append(r.v, *address)
-		}
+		r.appendAddress(NewRecoveryPhoneAddress(fmt.Sprintf("%s", value), r.i.ID))
 
 		return nil
 	case "":
@@ -50,6 +47,19 @@ func (r *SchemaExtensionRecovery) Run(ctx jsonschema.ValidationContext, s schema
 	return ctx.Error("", "recovery.via has unknown value %q", s.Recovery.Via)
 }
 
+func (r *SchemaExtensionRecovery) appendAddress(address *RecoveryAddress) {
+	if has := r.has(r.i.RecoveryAddresses, address); has != nil {
+		if r.has(r.v, address) == nil {
+			r.v = append(r.v, *has)
+		}
+		return
+	}
+
+	if has := r.has(r.v, address); has == nil {
+		r.v = append(r.v, *address)
+	}
+}
+
 func (r *SchemaExtensionRecovery) has(haystack []RecoveryAddress, needle *RecoveryAddress) *RecoveryAddress {
 	for _, has := range haystack {
 		if has.Value == needle.Value && has.Via == needle.Via {
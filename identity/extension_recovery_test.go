@@ -137,6 +137,34 @@ func TestSchemaExtensionRecovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			doc:    `{"phones":["+18004444444","+442087599036"]}`,
+			schema: "file://./stub/extension/recovery/phone.schema.json",
+			expect: []RecoveryAddress{
+				{
+					Value:      "+18004444444",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+				{
+					Value:      "+442087599036",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+			existing: []RecoveryAddress{
+				{
+					Value:      "+18004444444",
+					Via:        RecoveryAddressTypePhone,
+					IdentityID: iid,
+				},
+			},
+		},
+		{
+			doc:       `{"username": "foobar"}`,
+			schema:    "file://./stub/extension/recovery/phone.schema.json",
+			expectErr: errors.New("I[#/username] S[#/properties/username/format] \"foobar\" is not valid \"phone\""),
+		},
 	} {
 		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 			id := &Identity{ID: iid, RecoveryAddresses: tc.existing}
@@ -25,8 +25,17 @@ func (r *SchemaExtensionVerification) Run(ctx jsonschema.ValidationContext, s sc
 	defer r.l.Unlock()
 
 	if s.Credentials.Code.Identifier {
-		address := NewVerifiablePhoneAddress(fmt.Sprintf("%s", value), r.i.ID)
-		r.appendAddress(address)
+		switch codeAddressType(s) {
+		case AddressTypeEmail:
+			if !jsonschema.Formats["email"](value) {
+				return ctx.Error("format", "%q is not valid %q", value, "email")
+			}
+			r.appendAddress(NewVerifiableEmailAddress(fmt.Sprintf("%s", value), r.i.ID))
+		case AddressTypePhone:
+			r.appendAddress(NewVerifiablePhoneAddress(fmt.Sprintf("%s", value), r.i.ID))
+		default:
+			return ctx.Error("", "credentials.code.via has unknown value %q", s.Credentials.Code.Via)
+		}
 	}
 
 	switch s.Verification.Via {
@@ -59,6 +68,18 @@ func (r *SchemaExtensionVerification) Run(ctx jsonschema.ValidationContext, s sc
 	return ctx.Error("", "verification.via has unknown value %q", s.Verification.Via)
 }
 
+// codeAddressType returns the type of address one-time codes for a trait are delivered to. An explicit
+// `credentials.code.via` takes precedence over the format of the trait. Traits without either are phone numbers.
+func codeAddressType(s schema.ExtensionConfig) string {
+	if s.Credentials.Code.Via != "" {
+		return s.Credentials.Code.Via
+	}
+	if s.Format == "email" {
+		return AddressTypeEmail
+	}
+	return AddressTypePhone
+}
+
 func (r *SchemaExtensionVerification) Finish() error {
 	r.i.VerifiableAddresses = r.v
 	return nil
@@ -21,6 +21,7 @@ import (
 const (
 	emailSchemaPath = "file://./stub/extension/verify/email.schema.json"
 	phoneSchemaPath = "file://./stub/extension/verify/phone.schema.json"
+	codeSchemaPath  = "file://./stub/extension/verify/code.schema.json"
 )
 
 var ctx = context.Background()
@@ -327,6 +328,47 @@ func TestSchemaExtensionVerification(t *testing.T) {
 				doc:       `{"phones":["+18004444444","+18004444444","12112112"], "username": "+380634872774"}`,
 				expectErr: errors.New("I[#/phones/2] S[#/properties/phones/items/format] \"12112112\" is not valid \"phone\""),
 			},
+			{
+				name:   "code:must create addresses following the trait format",
+				schema: codeSchemaPath,
+				doc:    `{"email":"foo@ory.sh","phone":"+18004444444"}`,
+				expect: []VerifiableAddress{
+					{
+						Value:      "foo@ory.sh",
+						Verified:   false,
+						Status:     VerifiableAddressStatusPending,
+						Via:        VerifiableAddressTypeEmail,
+						IdentityID: iid,
+					},
+					{
+						Value:      "+18004444444",
+						Verified:   false,
+						Status:     VerifiableAddressStatusPending,
+						Via:        VerifiableAddressTypePhone,
+						IdentityID: iid,
+					},
+				},
+			},
+			{
+				name:   "code:must create address following the via setting",
+				schema: codeSchemaPath,
+				doc:    `{"contact":"bar@ory.sh"}`,
+				expect: []VerifiableAddress{
+					{
+						Value:      "bar@ory.sh",
+						Verified:   false,
+						Status:     VerifiableAddressStatusPending,
+						Via:        VerifiableAddressTypeEmail,
+						IdentityID: iid,
+					},
+				},
+			},
+			{
+				name:      "code:must return error for malformed email",
+				schema:    codeSchemaPath,
+				doc:       `{"contact":"+18004444444"}`,
+				expectErr: errors.New("I[#/contact] S[#/properties/contact/format] \"+18004444444\" is not valid \"email\""),
+			},
 		} {
 			t.Run(fmt.Sprintf("case=%v", tc.name), func(t *testing.T) {
 				id := &Identity{ID: iid, VerifiableAddresses: tc.existing}
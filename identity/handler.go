@@ -3,9 +3,14 @@ package identity
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"time"
 
+	"github.com/gofrs/uuid"
+
 	"github.com/ory/kratos/hash"
 
 	"github.com/ory/kratos/x"
@@ -22,6 +27,7 @@ import (
 	"github.com/ory/x/urlx"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 )
 
 const RouteCollection = "/identities"
@@ -37,6 +43,7 @@ type (
 		x.CSRFProvider
 		cipher.Provider
 		hash.HashProvider
+		event.StreamProvider
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -64,13 +71,17 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.GET(RouteItem, x.RedirectToAdminRoute(h.r))
 	public.DELETE(RouteItem, x.RedirectToAdminRoute(h.r))
 	public.POST(RouteCollection, x.RedirectToAdminRoute(h.r))
+	public.PATCH(RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.PUT(RouteItem, x.RedirectToAdminRoute(h.r))
+	public.PATCH(RouteItem, x.RedirectToAdminRoute(h.r))
 
 	public.GET(x.AdminPrefix+RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.GET(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
 	public.DELETE(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
 	public.POST(x.AdminPrefix+RouteCollection, x.RedirectToAdminRoute(h.r))
+	public.PATCH(x.AdminPrefix+RouteCollection, x.RedirectToAdminRoute(h.r))
 	public.PUT(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
+	public.PATCH(x.AdminPrefix+RouteItem, x.RedirectToAdminRoute(h.r))
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
@@ -79,7 +90,9 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.DELETE(RouteItem, h.delete)
 
 	admin.POST(RouteCollection, h.create)
+	admin.PATCH(RouteCollection, h.batchCreate)
 	admin.PUT(RouteItem, h.update)
+	admin.PATCH(RouteItem, h.patch)
 }
 
 // A list of identities.
@@ -91,13 +104,140 @@ type identityList []Identity
 // nolint:deadcode,unused
 type adminListIdentities struct {
 	x.PaginationParams
+
+	// Page Token
+	//
+	// Enables keyset pagination. Set to an empty value to fetch the first page and to the value found in the
+	// `Link` header's `rel="next"` URL to fetch the following pages. When set, `page` is ignored and the
+	// `X-Total-Count` header is omitted.
+	//
+	// required: false
+	// in: query
+	PageToken string `json:"page_token"`
+
+	// Returns identities having a credential with exactly this identifier.
+	//
+	// required: false
+	// in: query
+	CredentialsIdentifier string `json:"credentials_identifier"`
+
+	// Returns identities having a credential identifier starting with this prefix.
+	//
+	// required: false
+	// in: query
+	CredentialsIdentifierPrefix string `json:"credentials_identifier_prefix"`
+
+	// Returns identities having a verifiable address with this value.
+	//
+	// required: false
+	// in: query
+	VerifiableAddress string `json:"verifiable_address"`
+
+	// Returns identities having a verifiable address in this verification status.
+	//
+	// required: false
+	// in: query
+	VerifiableAddressVerified *bool `json:"verifiable_address_verified"`
+
+	// Returns identities using this identity schema.
+	//
+	// required: false
+	// in: query
+	SchemaID string `json:"schema_id"`
+
+	// Returns identities in this state.
+	//
+	// required: false
+	// in: query
+	State State `json:"state"`
+
+	// Returns identities created at or after this time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	CreatedAfter time.Time `json:"created_after"`
+
+	// Returns identities created before this time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	CreatedBefore time.Time `json:"created_before"`
+
+	// A dot-separated path into the identity traits, for example `name.first`. Must be used
+	// together with `trait_value`.
+	//
+	// required: false
+	// in: query
+	TraitPath string `json:"trait_path"`
+
+	// Returns identities whose trait at `trait_path` equals this value.
+	//
+	// required: false
+	// in: query
+	TraitValue string `json:"trait_value"`
+}
+
+var traitPathPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`)
+
+func parseListIdentityParameters(r *http.Request) (params ListIdentityParameters, err error) {
+	query := r.URL.Query()
+	params.Page, params.PerPage = x.ParsePagination(r)
+
+	if _, ok := query["page_token"]; ok {
+		params.PageToken.Valid = true
+		if token := query.Get("page_token"); len(token) > 0 {
+			if params.PageToken.UUID, err = uuid.FromString(token); err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `page_token`.", token))
+			}
+		}
+	}
+
+	params.CredentialsIdentifier = query.Get("credentials_identifier")
+	params.CredentialsIdentifierPrefix = query.Get("credentials_identifier_prefix")
+	params.VerifiableAddress = query.Get("verifiable_address")
+	params.SchemaID = query.Get("schema_id")
+
+	if verified := query.Get("verifiable_address_verified"); len(verified) > 0 {
+		v, err := strconv.ParseBool(verified)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `verifiable_address_verified`.", verified))
+		}
+		params.VerifiableAddressVerified = &v
+	}
+
+	if state := query.Get("state"); len(state) > 0 {
+		params.State = State(state)
+		if err := params.State.IsValid(); err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `state`.", state))
+		}
+	}
+
+	for key, t := range map[string]*time.Time{"created_after": &params.CreatedAfter, "created_before": &params.CreatedBefore} {
+		if value := query.Get(key); len(value) > 0 {
+			if *t, err = time.Parse(time.RFC3339, value); err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `%s`, expected an RFC 3339 timestamp.", value, key))
+			}
+		}
+	}
+
+	if path := query.Get("trait_path"); len(path) > 0 {
+		if !traitPathPattern.MatchString(path) {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `trait_path`.", path))
+		}
+		params.TraitPath, params.TraitValue = path, query.Get("trait_value")
+	}
+
+	return params, nil
 }
 
 // swagger:route GET /admin/identities v0alpha2 adminListIdentities
 //
 // List Identities
 //
-// Lists all identities. Does not support search at the moment.
+// Lists all identities. The result can be filtered by credential identifier, verifiable address, schema,
+// state, creation time, and trait value.
+//
+// Use `page_token` instead of `page` to paginate through large result sets efficiently.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -113,20 +253,44 @@ type adminListIdentities struct {
 //       200: identityList
 //       500: jsonError
 func (h *Handler) list(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	page, itemsPerPage := x.ParsePagination(r)
-	is, err := h.r.IdentityPool().ListIdentities(r.Context(), page, itemsPerPage)
+	params, err := parseListIdentityParameters(r)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	total, err := h.r.IdentityPool().CountIdentities(r.Context())
+	identities, err := h.r.IdentityPool().ListIdentities(r.Context(), params)
 	if err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection), total, page, itemsPerPage)
+	// The admin API is allowed to see the admin metadata, which is omitted by default.
+	is := make([]WithAdminMetadataInJSON, len(identities))
+	for k := range identities {
+		is[k] = WithAdminMetadataInJSON(identities[k])
+	}
+
+	u := urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection)
+	if params.PageToken.Valid {
+		// Counting all matches defeats the purpose of keyset pagination, so only the next page is linked.
+		if len(is) > 0 && len(is) >= x.MaxItemsPerPage(params.PerPage) {
+			query := r.URL.Query()
+			query.Set("page_token", is[len(is)-1].ID.String())
+			query.Set("per_page", strconv.Itoa(params.PerPage))
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, urlx.CopyWithQuery(u, query).String()))
+		}
+		h.r.Writer().Write(w, r, is)
+		return
+	}
+
+	total, err := h.r.IdentityPool().CountIdentities(r.Context(), params)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, u, total, params.Page, params.PerPage)
 	h.r.Writer().Write(w, r, is)
 }
 
@@ -238,6 +402,14 @@ type AdminCreateIdentityBody struct {
 	//
 	// required: false
 	State State `json:"state"`
+
+	// Store metadata about the identity which the identity itself can see when calling for example the
+	// session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// Store metadata about the identity which is only accessible and writable through the admin APIs
+	// such as `GET /admin/identities/<id>`.
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
 }
 
 // swagger:model adminIdentityImportCredentials
@@ -323,12 +495,33 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
+	i, err := h.identityFromCreateBody(r.Context(), &cr)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(
+			h.r.Config(r.Context()).SelfAdminURL(),
+			"identities",
+			i.ID.String(),
+		).String(),
+		WithAdminMetadataInJSON(*i),
+	)
+}
+
+func (h *Handler) identityFromCreateBody(ctx context.Context, cr *AdminCreateIdentityBody) (*Identity, error) {
 	stateChangedAt := sqlxx.NullTime(time.Now())
 	state := StateActive
 	if cr.State != "" {
 		if err := cr.State.IsValid(); err != nil {
-			h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err)))
-			return
+			return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err))
 		}
 		state = cr.State
 	}
@@ -340,26 +533,15 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		StateChangedAt:      &stateChangedAt,
 		VerifiableAddresses: cr.VerifiableAddresses,
 		RecoveryAddresses:   cr.RecoveryAddresses,
+		MetadataPublic:      []byte(cr.MetadataPublic),
+		MetadataAdmin:       []byte(cr.MetadataAdmin),
 	}
 
-	if err := h.importCredentials(r.Context(), i, cr.Credentials); err != nil {
-		h.r.Writer().WriteError(w, r, err)
-		return
+	if err := h.importCredentials(ctx, i, cr.Credentials); err != nil {
+		return nil, err
 	}
 
-	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
-		h.r.Writer().WriteError(w, r, err)
-		return
-	}
-
-	h.r.Writer().WriteCreated(w, r,
-		urlx.AppendPaths(
-			h.r.Config(r.Context()).SelfAdminURL(),
-			"identities",
-			i.ID.String(),
-		).String(),
-		i,
-	)
+	return i, nil
 }
 
 // swagger:parameters adminUpdateIdentity
@@ -393,6 +575,14 @@ type AdminUpdateIdentityBody struct {
 	//
 	// required: true
 	State State `json:"state"`
+
+	// Store metadata about the identity which the identity itself can see when calling for example the
+	// session endpoint. Do not store sensitive information (e.g. credit score) about the identity in this field.
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// Store metadata about the identity which is only accessible and writable through the admin APIs
+	// such as `GET /admin/identities/<id>`.
+	MetadataAdmin json.RawMessage `json:"metadata_admin,omitempty"`
 }
 
 // swagger:route PUT /admin/identities/{id} v0alpha2 adminUpdateIdentity
@@ -402,7 +592,8 @@ type AdminUpdateIdentityBody struct {
 // This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 // using this method! A way to achieve that will be introduced in the future.
 //
-// The full identity payload (except credentials) is expected. This endpoint does not support patching.
+// The full identity payload (except credentials) is expected. Use `PATCH /admin/identities/{id}` to partially
+// update an identity.
 //
 // Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -454,16 +645,110 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	}
 
 	identity.Traits = []byte(ur.Traits)
+	identity.MetadataPublic = []byte(ur.MetadataPublic)
+	identity.MetadataAdmin = []byte(ur.MetadataAdmin)
 	if err := h.r.IdentityManager().Update(
 		r.Context(),
 		identity,
 		ManagerAllowWriteProtectedTraits,
+		ManagerAllowWriteMetadata,
 	); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
-	h.r.Writer().Write(w, r, identity)
+	h.r.Writer().Write(w, r, WithAdminMetadataInJSON(*identity))
+}
+
+// swagger:parameters adminPatchIdentity
+// nolint:deadcode,unused
+type adminPatchIdentity struct {
+	// ID must be set to the ID of identity you want to update
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body []PatchOperation
+}
+
+// swagger:route PATCH /admin/identities/{id} v0alpha2 adminPatchIdentity
+//
+// Patch an Identity
+//
+// This endpoint partially updates an identity using [JSON Patch](https://jsonpatch.com/) (RFC 6902). Only
+// the paths `/schema_id`, `/state`, `/traits`, `/metadata_public`, `/metadata_admin`, `/verifiable_addresses`,
+// `/recovery_addresses`, and `/credentials/<type>/identifiers` can be patched. The patched identity is
+// validated against its identity schema.
+//
+// The identity is locked while the patch is applied, so concurrent patches do not overwrite each other's
+// changes. Use the `test` operation to make sure that a value has not been changed in the meantime.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: identity
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var patch json.RawMessage
+	if err := errors.WithStack(json.NewDecoder(r.Body).Decode(&patch)); err != nil {
+		h.r.Writer().WriteError(w, r, herodot.ErrBadRequest.WithReasonf("Unable to decode the JSON Patch: %s", err).WithWrap(err))
+		return
+	}
+
+	id := x.ParseUUID(ps.ByName("id"))
+	var identity *Identity
+	if err := h.r.PrivilegedIdentityPool().LockIdentity(r.Context(), id, func(ctx context.Context) (err error) {
+		identity, err = h.r.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		original := identity.VerifiableAddresses
+		if err := identity.ApplyJSONPatch(patch); err != nil {
+			return err
+		}
+		changed := changedVerifiableAddresses(original, identity.VerifiableAddresses)
+
+		if err := h.r.IdentityManager().Update(
+			ctx,
+			identity,
+			ManagerAllowWriteProtectedTraits,
+			ManagerAllowWriteMetadata,
+		); err != nil {
+			return err
+		}
+
+		// Verifiable addresses are derived from the traits, which drops changes to addresses whose value is
+		// changed or removed by the same patch. Rejecting the patch is better than silently losing them.
+		for k := range changed {
+			if has(identity.VerifiableAddresses, &changed[k]) == nil {
+				return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Patch changes the verifiable address %q which no longer matches a trait of the patched identity.", changed[k].Value))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, WithAdminMetadataInJSON(*identity))
 }
 
 // swagger:parameters adminDeleteIdentity
@@ -499,10 +784,13 @@ type adminDeleteIdentity struct {
 //       404: jsonError
 //       500: jsonError
 func (h *Handler) delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+	id := x.ParseUUID(ps.ByName("id"))
+	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), id); err != nil {
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
+	h.r.EventStream().Emit(r.Context(), event.New(event.TypeIdentityDeleted, id))
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,117 @@
+package identity
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+)
+
+// BatchCreateIdentitiesLimit is the maximum number of identities which can be created with one request.
+const BatchCreateIdentitiesLimit = 1000
+
+// swagger:parameters adminBatchCreateIdentities
+// nolint:deadcode,unused
+type adminBatchCreateIdentities struct {
+	// in: body
+	Body AdminBatchCreateIdentitiesBody
+}
+
+// swagger:model adminBatchCreateIdentitiesBody
+type AdminBatchCreateIdentitiesBody struct {
+	// The identities to create. At most 1000 identities can be created with one request.
+	//
+	// required: true
+	Identities []AdminCreateIdentityBody `json:"identities"`
+}
+
+// swagger:model adminBatchCreateIdentitiesResponse
+type AdminBatchCreateIdentitiesResponse struct {
+	// The result for each identity, in the same order as the identities of the request.
+	//
+	// required: true
+	Identities []AdminBatchCreateIdentityResult `json:"identities"`
+}
+
+// swagger:model adminBatchCreateIdentityResult
+type AdminBatchCreateIdentityResult struct {
+	// The ID of the created identity. Not set if the identity could not be created.
+	//
+	// format: uuid
+	IdentityID *uuid.UUID `json:"identity,omitempty"`
+
+	// The error which prevented the identity from being created.
+	Error *herodot.DefaultError `json:"error,omitempty"`
+}
+
+// swagger:route PATCH /admin/identities v0alpha2 adminBatchCreateIdentities
+//
+// Create multiple Identities
+//
+// This endpoint creates up to 1000 identities, including their credentials, with one request. The identities
+// are inserted in bulk, which makes this endpoint well suited for importing identities from another system.
+//
+// The response contains a result for each identity, in the same order as the identities of the request. The
+// result either contains the ID of the created identity or the error which prevented the identity from being
+// created. An invalid or conflicting identity does not prevent the other identities from being created.
+//
+// Learn how identities work in [Ory Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: adminBatchCreateIdentitiesResponse
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) batchCreate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var body AdminBatchCreateIdentitiesBody
+	if err := jsonx.NewStrictDecoder(r.Body).Decode(&body); err != nil {
+		h.r.Writer().WriteErrorCode(w, r, http.StatusBadRequest, errors.WithStack(err))
+		return
+	}
+
+	if len(body.Identities) > BatchCreateIdentitiesLimit {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf(
+			"At most %d identities can be created with one request but %d were given.", BatchCreateIdentitiesLimit, len(body.Identities))))
+		return
+	}
+
+	results := make([]AdminBatchCreateIdentityResult, len(body.Identities))
+	identities := make([]*Identity, 0, len(body.Identities))
+	positions := make([]int, 0, len(body.Identities))
+	for k := range body.Identities {
+		i, err := h.identityFromCreateBody(r.Context(), &body.Identities[k])
+		if err != nil {
+			results[k].Error = herodot.ToDefaultError(err, "")
+			continue
+		}
+
+		identities = append(identities, i)
+		positions = append(positions, k)
+	}
+
+	for k, err := range h.r.IdentityManager().CreateIdentities(r.Context(), identities) {
+		if err != nil {
+			results[positions[k]].Error = herodot.ToDefaultError(err, "")
+			continue
+		}
+
+		id := identities[k].ID
+		results[positions[k]].IdentityID = &id
+	}
+
+	h.r.Writer().Write(w, r, &AdminBatchCreateIdentitiesResponse{Identities: results})
+}
@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -223,6 +225,102 @@ func TestHandler(t *testing.T) {
 
 			require.NoError(t, hash.Compare(ctx, []byte("123456"), []byte(gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())))
 		})
+
+		for name, hashed := range map[string]string{
+			"argon2i":    "$argon2i$v=19$m=32,t=3,p=4$YzI5dFpYTmhiSFJ6WVd4MA$vOU98KuIqycAL3ECt/lHvQ60BtZw8rbUl5zEQW2pGes",
+			"scrypt":     "$scrypt$ln=14,r=8,p=1$c2dBdnRSUTZiVTh1akxWeQ$BaR7kwB7p80URbdYt4TVRy1t6OgyRk97yOcTjsjFNHY",
+			"firescrypt": "$firescrypt$ln=14,r=8,p=1$F586EFWB7wIzaw==$MmhyJsUPg5K4e3+/5lgu9Ni3rOwtDKQCW8CJ2Noy2SvAVJRIQLfzM6FidmDkZKryVO3Z3o71/BH8B1vanYRTnA==$Bw==$fuX/Cx1lSbxLDwX2d3IpW2KOtDWRt1P5ihlHAiW92UJMGfDxb6lKmWVgwIpXHq2yL3DbhMiTkPDnKfAB8uXNvw==",
+			"ssha":       "{SSHA}AxzHtbtNwdnPBzbptq8ls6oKsMqKHwJ30TMEvg==",
+			"ssha256":    "{SSHA256}AcS6dHq6i/8BJFcznLzNWrEpv2hj7BGccGxq1eiGwqOKHwJ30TMEvg==",
+			"ssha512":    "{SSHA512}XIr+NUOd6XT97spHocuVRUGZoYCD+BUx6GegurSQa6pyRREvp7EY4CjgpO+Vmq6vN8zEeDc1uEs+9MdzOn5JlYofAnfRMwS+",
+			"md5crypt":   "$1$HHkq5gKN$yMIf8ljHWT3aqMg3TNzCR/",
+		} {
+			t.Run("with "+name+" password", func(t *testing.T) {
+				res := send(t, adminTS, "POST", "/identities", http.StatusCreated, identity.AdminCreateIdentityBody{Traits: []byte(`{"email": "import-` + name + `@ory.sh"}`),
+					Credentials: &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+						Config: identity.AdminIdentityImportCredentialsPasswordConfig{HashedPassword: hashed}}}})
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, uuid.FromStringOrNil(res.Get("id").String()))
+				require.NoError(t, err)
+
+				assert.Equal(t, hashed, gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())
+				require.NoError(t, hash.Compare(ctx, []byte("123456"), []byte(hashed)))
+			})
+		}
+
+		t.Run("with unknown password hash format", func(t *testing.T) {
+			res := send(t, adminTS, "POST", "/identities", http.StatusBadRequest, identity.AdminCreateIdentityBody{Traits: []byte(`{"email": "import-unknown@ory.sh"}`),
+				Credentials: &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+					Config: identity.AdminIdentityImportCredentialsPasswordConfig{HashedPassword: "$unknown$e10adc3949ba59abbe56e057f20f883e"}}}})
+			assert.Contains(t, res.Get("error.reason").String(), "does not match any known hash format", "%s", res.Raw)
+		})
+
+		t.Run("with empty password hash segments", func(t *testing.T) {
+			for _, hashed := range []string{"$scrypt$ln=4,r=8,p=1$c2FsdA$", "$firescrypt$ln=4,r=8,p=1$c2FsdA$$c2Fs$"} {
+				res := send(t, adminTS, "POST", "/identities", http.StatusBadRequest, identity.AdminCreateIdentityBody{Traits: []byte(`{"email": "import-empty@ory.sh"}`),
+					Credentials: &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+						Config: identity.AdminIdentityImportCredentialsPasswordConfig{HashedPassword: hashed}}}})
+				assert.Contains(t, res.Get("error.reason").String(), "does not match any known hash format", "%s", res.Raw)
+			}
+		})
+	})
+
+	t.Run("case=should batch create identities", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				conflicting := x.NewUUID().String() + "@ory.sh"
+				res := send(t, ts, "PATCH", "/identities", http.StatusOK, &identity.AdminBatchCreateIdentitiesBody{
+					Identities: []identity.AdminCreateIdentityBody{
+						{SchemaID: "employee", Traits: []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)},
+						{
+							SchemaID: "employee",
+							Traits:   []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`),
+							Credentials: &identity.AdminIdentityImportCredentials{Password: &identity.AdminIdentityImportCredentialsPassword{
+								Config: identity.AdminIdentityImportCredentialsPasswordConfig{Password: "123456"},
+							}},
+						},
+						{SchemaID: "employee", Traits: []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`), State: "invalid-state"},
+						{SchemaID: "employee", Traits: []byte(`{"department": 1}`)},
+						{SchemaID: "does-not-exist", Traits: []byte(`{}`)},
+						{SchemaID: "employee", Traits: []byte(`{"email":"` + conflicting + `"}`)},
+						{SchemaID: "employee", Traits: []byte(`{"email":"` + conflicting + `"}`)},
+					},
+				})
+
+				results := res.Get("identities").Array()
+				require.Len(t, results, 7, "%s", res.Raw)
+				for _, k := range []int{0, 1, 5} {
+					id := results[k].Get("identity").String()
+					assert.NotEmpty(t, id, "%d: %s", k, res.Raw)
+					assert.False(t, results[k].Get("error").Exists(), "%d: %s", k, res.Raw)
+
+					actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(id))
+					require.NoError(t, err)
+					assert.Equal(t, "employee", actual.SchemaID)
+				}
+				for k, reason := range map[int]string{
+					2: "identity state is not valid",
+					3: "expected string, but got number",
+					4: "does-not-exist",
+					6: "",
+				} {
+					assert.False(t, results[k].Get("identity").Exists(), "%d: %s", k, res.Raw)
+					assert.Contains(t, results[k].Get("error.reason").String()+results[k].Get("error.message").String(), reason, "%d: %s", k, res.Raw)
+				}
+				assert.EqualValues(t, http.StatusConflict, results[6].Get("error.code").Int(), "%s", res.Raw)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(results[1].Get("identity").String()))
+				require.NoError(t, err)
+				assert.True(t, hash.IsBcryptHash([]byte(gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())) ||
+					hash.IsArgon2idHash([]byte(gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "hashed_password").String())))
+			})
+		}
+	})
+
+	t.Run("case=should not batch create more identities than allowed", func(t *testing.T) {
+		res := send(t, adminTS, "PATCH", "/identities", http.StatusBadRequest, &identity.AdminBatchCreateIdentitiesBody{
+			Identities: make([]identity.AdminCreateIdentityBody, identity.BatchCreateIdentitiesLimit+1),
+		})
+		assert.Contains(t, res.Get("error.reason").String(), "At most 1000 identities", "%s", res.Raw)
 	})
 
 	t.Run("case=unable to set ID itself", func(t *testing.T) {
@@ -562,6 +660,37 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("case=should create, update, and return public and admin metadata", func(t *testing.T) {
+		res := send(t, adminTS, "POST", "/identities", http.StatusCreated, identity.AdminCreateIdentityBody{
+			Traits:         []byte(`{"email": "metadata-` + x.NewUUID().String() + `@ory.sh"}`),
+			MetadataPublic: []byte(`{"plan":"free"}`),
+			MetadataAdmin:  []byte(`{"fraud_score":0.1}`),
+		})
+		assert.EqualValues(t, "free", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.EqualValues(t, 0.1, res.Get("metadata_admin.fraud_score").Float(), "%s", res.Raw)
+
+		id := res.Get("id").String()
+		res = get(t, adminTS, "/identities/"+id, http.StatusOK)
+		assert.EqualValues(t, "free", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.EqualValues(t, 0.1, res.Get("metadata_admin.fraud_score").Float(), "%s", res.Raw)
+
+		res = get(t, adminTS, "/identities?per_page=500", http.StatusOK)
+		assert.EqualValues(t, 0.1, res.Get(`#(id=="`+id+`").metadata_admin.fraud_score`).Float(), "%s", res.Raw)
+
+		res = send(t, adminTS, "PUT", "/identities/"+id, http.StatusOK, &identity.AdminUpdateIdentityBody{
+			Traits:         []byte(res.Get(`#(id=="` + id + `").traits`).Raw),
+			MetadataPublic: []byte(`{"plan":"pro"}`),
+			MetadataAdmin:  []byte(`{"fraud_score":0.9}`),
+		})
+		assert.EqualValues(t, "pro", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.EqualValues(t, 0.9, res.Get("metadata_admin.fraud_score").Float(), "%s", res.Raw)
+
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(id))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"plan":"pro"}`, string(actual.MetadataPublic))
+		assert.JSONEq(t, `{"fraud_score":0.9}`, string(actual.MetadataAdmin))
+	})
+
 	t.Run("case=should update the schema id and fail because traits are invalid", func(t *testing.T) {
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
@@ -640,6 +769,150 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("case=should patch an identity", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				email := x.NewUUID().String() + "@ory.sh"
+				res := send(t, ts, "POST", "/identities", http.StatusCreated, &identity.AdminCreateIdentityBody{
+					SchemaID: "employee",
+					Traits:   []byte(`{"email":"` + email + `", "department": "ory"}`),
+				})
+				id := res.Get("id").String()
+
+				updatedEmail := x.NewUUID().String() + "@ory.sh"
+				res = send(t, ts, "PATCH", "/identities/"+id, http.StatusOK, []identity.PatchOperation{
+					{Op: "test", Path: "/traits/email", Value: email},
+					{Op: "replace", Path: "/traits/email", Value: updatedEmail},
+					{Op: "replace", Path: "/state", Value: identity.StateInactive},
+					{Op: "add", Path: "/metadata_public", Value: map[string]string{"plan": "pro"}},
+				})
+				assert.EqualValues(t, updatedEmail, res.Get("traits.email").String(), "%s", res.Raw)
+				assert.EqualValues(t, "ory", res.Get("traits.department").String(), "%s", res.Raw)
+				assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+				assert.True(t, res.Get("state_changed_at").Exists(), "%s", res.Raw)
+				assert.EqualValues(t, "pro", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+				assert.EqualValues(t, updatedEmail, res.Get("recovery_addresses.0.value").String(), "%s", res.Raw)
+				assert.EqualValues(t, updatedEmail, res.Get("verifiable_addresses.0.value").String(), "%s", res.Raw)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(id))
+				require.NoError(t, err)
+				assert.EqualValues(t, []string{updatedEmail}, actual.Credentials[identity.CredentialsTypePassword].Identifiers)
+
+				res = send(t, ts, "PATCH", "/identities/"+id, http.StatusOK, []identity.PatchOperation{
+					{Op: "replace", Path: "/verifiable_addresses/0/verified", Value: true},
+				})
+				assert.True(t, res.Get("verifiable_addresses.0.verified").Bool(), "%s", res.Raw)
+
+				actual, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(id))
+				require.NoError(t, err)
+				require.Len(t, actual.VerifiableAddresses, 1)
+				assert.True(t, actual.VerifiableAddresses[0].Verified)
+			})
+		}
+	})
+
+	t.Run("case=should reject address changes which are lost because the address is replaced", func(t *testing.T) {
+		email := x.NewUUID().String() + "@ory.sh"
+		res := send(t, adminTS, "POST", "/identities", http.StatusCreated, &identity.AdminCreateIdentityBody{
+			SchemaID: "employee",
+			Traits:   []byte(`{"email":"` + email + `"}`),
+		})
+		id := res.Get("id").String()
+
+		res = send(t, adminTS, "PATCH", "/identities/"+id, http.StatusBadRequest, []identity.PatchOperation{
+			{Op: "replace", Path: "/traits/email", Value: x.NewUUID().String() + "@ory.sh"},
+			{Op: "replace", Path: "/verifiable_addresses/0/verified", Value: true},
+		})
+		assert.Contains(t, res.Get("error.reason").String(), "no longer matches a trait", "%s", res.Raw)
+
+		res = get(t, adminTS, "/identities/"+id, http.StatusOK)
+		assert.EqualValues(t, email, res.Get("traits.email").String(), "the rejected patch must not be applied: %s", res.Raw)
+		assert.False(t, res.Get("verifiable_addresses.0.verified").Bool(), "%s", res.Raw)
+	})
+
+	t.Run("case=should not overwrite concurrent changes when patching", func(t *testing.T) {
+		res := send(t, adminTS, "POST", "/identities", http.StatusCreated, &identity.AdminCreateIdentityBody{
+			SchemaID:       "employee",
+			Traits:         []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh", "department": "ory"}`),
+			MetadataPublic: []byte(`{}`),
+		})
+		id := res.Get("id").String()
+
+		const patches = 50
+		var wg sync.WaitGroup
+		for k := 0; k < patches; k++ {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				body, err := json.Marshal([]identity.PatchOperation{
+					{Op: "add", Path: fmt.Sprintf("/metadata_public/patch-%d", k), Value: k},
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+				req, err := http.NewRequest("PATCH", adminTS.URL+"/identities/"+id, bytes.NewReader(body))
+				if !assert.NoError(t, err) {
+					return
+				}
+				req.Header.Set("Content-Type", "application/json")
+				res, err := adminTS.Client().Do(req)
+				if !assert.NoError(t, err) {
+					return
+				}
+				defer res.Body.Close()
+				assert.EqualValues(t, http.StatusOK, res.StatusCode)
+			}(k)
+		}
+		wg.Wait()
+
+		res = get(t, adminTS, "/identities/"+id, http.StatusOK)
+		for k := 0; k < patches; k++ {
+			assert.EqualValues(t, k, res.Get(fmt.Sprintf("metadata_public.patch-%d", k)).Int(), "%s", res.Raw)
+		}
+		assert.EqualValues(t, "ory", res.Get("traits.department").String(), "%s", res.Raw)
+	})
+
+	t.Run("case=should fail to patch an identity", func(t *testing.T) {
+		res := send(t, adminTS, "POST", "/identities", http.StatusCreated, &identity.AdminCreateIdentityBody{
+			SchemaID: "employee",
+			Traits:   []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh", "department": "ory"}`),
+		})
+		id := res.Get("id").String()
+
+		for _, tc := range []struct {
+			d      string
+			patch  interface{}
+			reason string
+		}{
+			{d: "test operation fails", patch: []identity.PatchOperation{{Op: "test", Path: "/traits/department", Value: "sales"}}, reason: "testing value /traits/department failed"},
+			{d: "credentials config", patch: []identity.PatchOperation{{Op: "replace", Path: "/credentials/password/config", Value: map[string]string{"hashed_password": "foo"}}}, reason: "can not be patched"},
+			{d: "identity id", patch: []identity.PatchOperation{{Op: "replace", Path: "/id", Value: x.NewUUID().String()}}, reason: "can not be patched"},
+			{d: "move from a protected path", patch: []identity.PatchOperation{{Op: "copy", From: "/credentials/password", Path: "/traits/department"}}, reason: "can not be patched"},
+			{d: "invalid state", patch: []identity.PatchOperation{{Op: "replace", Path: "/state", Value: "invalid-state"}}, reason: "identity state is not valid"},
+			{d: "invalid traits", patch: []identity.PatchOperation{{Op: "replace", Path: "/traits/department", Value: 1}}, reason: "expected string, but got number"},
+			{d: "no array", patch: map[string]string{"op": "replace"}, reason: "The JSON Patch document is invalid"},
+		} {
+			t.Run("case="+tc.d, func(t *testing.T) {
+				res := send(t, adminTS, "PATCH", "/identities/"+id, http.StatusBadRequest, tc.patch)
+				assert.Contains(t, res.Get("error.reason").String(), tc.reason, "%s", res.Raw)
+			})
+		}
+
+		res = get(t, adminTS, "/identities/"+id, http.StatusOK)
+		assert.EqualValues(t, "ory", res.Get("traits.department").String(), "%s", res.Raw)
+		assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+	})
+
+	t.Run("case=should not be able to patch an identity that does not exist", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				_ = send(t, ts, "PATCH", "/identities/"+x.NewUUID().String(), http.StatusNotFound, []identity.PatchOperation{
+					{Op: "replace", Path: "/traits/department", Value: "sales"},
+				})
+			})
+		}
+	})
+
 	t.Run("case=should list all identities", func(t *testing.T) {
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
@@ -650,6 +923,66 @@ func TestHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("case=should list identities using filters", func(t *testing.T) {
+		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
+			t.Run("endpoint="+name, func(t *testing.T) {
+				res := get(t, ts, "/identities?schema_id=employee&per_page=500", http.StatusOK)
+				require.NotEmpty(t, res.Array())
+				for _, i := range res.Array() {
+					assert.EqualValues(t, "employee", i.Get("schema_id").String(), "%s", res.Raw)
+				}
+
+				res = get(t, ts, "/identities?trait_path=bar&trait_value=baz", http.StatusOK)
+				assert.EqualValues(t, "baz", res.Get(`#(traits.bar=="baz").traits.bar`).String(), "%s", res.Raw)
+
+				res = get(t, ts, "/identities?state=inactive&created_after=2100-01-01T00:00:00Z", http.StatusOK)
+				assert.Len(t, res.Array(), 0)
+			})
+		}
+	})
+
+	t.Run("case=should paginate identities using a page token", func(t *testing.T) {
+		expected := len(get(t, adminTS, "/identities?per_page=500", http.StatusOK).Array())
+
+		var seen []string
+		next := adminTS.URL + "/identities?per_page=2&page_token="
+		for next != "" {
+			res, err := adminTS.Client().Get(next)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.Empty(t, res.Header.Get("X-Total-Count"))
+
+			for _, i := range gjson.ParseBytes(body).Array() {
+				seen = append(seen, i.Get("id").String())
+			}
+
+			next = ""
+			if link := res.Header.Get("Link"); link != "" {
+				require.Contains(t, link, `rel="next"`)
+				u := urlx.ParseOrPanic(strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`))
+				next = adminTS.URL + u.Path + "?" + u.RawQuery
+			}
+		}
+		assert.Len(t, seen, expected)
+	})
+
+	t.Run("case=should fail to list identities with invalid filters", func(t *testing.T) {
+		for _, query := range []string{
+			"page_token=not-a-uuid",
+			"state=deleted",
+			"created_after=yesterday",
+			"verifiable_address_verified=maybe",
+			"trait_path=name[0]",
+		} {
+			t.Run("query="+query, func(t *testing.T) {
+				_ = get(t, adminTS, "/identities?"+query, http.StatusBadRequest)
+			})
+		}
+	})
+
 	t.Run("case=should not be able to update an identity that does not exist yet", func(t *testing.T) {
 		for name, ts := range map[string]*httptest.Server{"public": publicTS, "admin": adminTS} {
 			t.Run("endpoint="+name, func(t *testing.T) {
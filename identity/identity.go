@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"encoding/json"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
@@ -119,6 +121,15 @@ type Identity struct {
 	// ---
 	RecoveryAddresses []RecoveryAddress `json:"recovery_addresses,omitempty" faker:"-" has_many:"identity_recovery_addresses" fk_id:"identity_id"`
 
+	// MetadataPublic holds data about the identity which the identity can see, for example when calling
+	// `/sessions/whoami`, but can not change through self-service flows. Do not store sensitive information
+	// (e.g. a fraud score) in this field.
+	MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public,omitempty" faker:"-" db:"metadata_public"`
+
+	// MetadataAdmin holds data about the identity which is only visible and writable through the admin APIs
+	// such as `GET /admin/identities/<id>`.
+	MetadataAdmin sqlxx.NullJSONRawMessage `json:"metadata_admin,omitempty" faker:"-" db:"metadata_admin"`
+
 	// CreatedAt is a helper struct field for gobuffalo.pop.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 
@@ -167,6 +178,18 @@ func (i Identity) TableName(ctx context.Context) string {
 	return corp.ContextualizeTableName(ctx, "identities")
 }
 
+// AfterFind removes metadata which is NULL in the database, as it would otherwise be marshalled as
+// `null` instead of being omitted.
+func (i *Identity) AfterFind(*pop.Connection) error {
+	if bytes.Equal(i.MetadataPublic, []byte("null")) {
+		i.MetadataPublic = nil
+	}
+	if bytes.Equal(i.MetadataAdmin, []byte("null")) {
+		i.MetadataAdmin = nil
+	}
+	return nil
+}
+
 func (i *Identity) lock() *sync.RWMutex {
 	if i.l == nil {
 		i.l = new(sync.RWMutex)
@@ -298,6 +321,7 @@ func (i Identity) GetNID() uuid.UUID {
 func (i Identity) MarshalJSON() ([]byte, error) {
 	type localIdentity Identity
 	i.Credentials = nil
+	i.MetadataAdmin = nil
 	result, err := json.Marshal(localIdentity(i))
 	if err != nil {
 		return nil, err
@@ -312,6 +336,14 @@ func (i *Identity) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+type WithAdminMetadataInJSON Identity
+
+func (i WithAdminMetadataInJSON) MarshalJSON() ([]byte, error) {
+	type localIdentity Identity
+	i.Credentials = nil
+	return json.Marshal(localIdentity(i))
+}
+
 type WithCredentialsInJSON Identity
 
 func (i WithCredentialsInJSON) MarshalJSON() ([]byte, error) {
@@ -162,6 +162,24 @@ func TestMarshalIdentityWithCredentials(t *testing.T) {
 	assert.Equal(t, credentials, i.Credentials, "Original credentials should not be touched by marshalling")
 }
 
+func TestMarshalExcludesAdminMetadata(t *testing.T) {
+	i := NewIdentity(config.DefaultIdentityTraitsSchemaID)
+	i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"free"}`)
+	i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"fraud_score":0.1}`)
+
+	var b bytes.Buffer
+	require.Nil(t, json.NewEncoder(&b).Encode(i))
+	assert.Equal(t, "free", gjson.Get(b.String(), "metadata_public.plan").String())
+	assert.False(t, gjson.Get(b.String(), "metadata_admin").Exists(), "Admin metadata should not be rendered to json")
+
+	b.Reset()
+	require.Nil(t, json.NewEncoder(&b).Encode(WithAdminMetadataInJSON(*i)))
+	assert.Equal(t, 0.1, gjson.Get(b.String(), "metadata_admin.fraud_score").Float())
+
+	// To ensure the original identity is not changed / Marshal has no side effects:
+	require.NotEmpty(t, i.MetadataAdmin)
+}
+
 func TestValidateNID(t *testing.T) {
 	nid := x.NewUUID()
 	for k, tc := range []struct {
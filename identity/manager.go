@@ -14,6 +14,7 @@ import (
 	"github.com/ory/x/errorsx"
 
 	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/event"
 )
 
 var ErrProtectedFieldModified = herodot.ErrForbidden.
@@ -25,6 +26,7 @@ type (
 		courier.Provider
 		ValidationProvider
 		ActiveCredentialsCounterStrategyProvider
+		event.StreamProvider
 	}
 	ManagementProvider interface {
 		IdentityManager() *Manager
@@ -36,6 +38,7 @@ type (
 	managerOptions struct {
 		ExposeValidationErrors    bool
 		AllowWriteProtectedTraits bool
+		AllowWriteMetadata        bool
 	}
 
 	ManagerOption func(*managerOptions)
@@ -53,6 +56,12 @@ func ManagerAllowWriteProtectedTraits(options *managerOptions) {
 	options.AllowWriteProtectedTraits = true
 }
 
+// ManagerAllowWriteMetadata allows changing the identity's public and admin metadata. Without this option,
+// the stored metadata is kept as is, which ensures that self-service flows can not modify it.
+func ManagerAllowWriteMetadata(options *managerOptions) {
+	options.AllowWriteMetadata = true
+}
+
 func newManagerOptions(opts []ManagerOption) *managerOptions {
 	var o managerOptions
 	for _, f := range opts {
@@ -67,7 +76,76 @@ func (m *Manager) Create(ctx context.Context, i *Identity, opts ...ManagerOption
 		return err
 	}
 
-	return m.r.IdentityPool().(PrivilegedPool).CreateIdentity(ctx, i)
+	if err := m.r.IdentityPool().(PrivilegedPool).CreateIdentity(ctx, i); err != nil {
+		return err
+	}
+
+	m.emit(ctx, event.TypeIdentityCreated, i)
+	return nil
+}
+
+// CreateIdentities validates and creates multiple identities. Valid identities are inserted in a single transaction.
+// If that transaction fails, for example because an identifier is already taken, the identities are retried in
+// smaller groups, so that only the conflicting identities are not created.
+//
+// The returned slice contains the error for each identity at the same index, or nil if the identity was created.
+func (m *Manager) CreateIdentities(ctx context.Context, identities []*Identity, opts ...ManagerOption) []error {
+	o := newManagerOptions(opts)
+	errs := make([]error, len(identities))
+
+	valid := make([]pendingIdentity, 0, len(identities))
+	for k, i := range identities {
+		if err := m.validate(ctx, i, o); err != nil {
+			errs[k] = err
+			continue
+		}
+		valid = append(valid, pendingIdentity{index: k, identity: i, original: deepcopy.Copy(i).(*Identity)})
+	}
+
+	if len(valid) == 0 {
+		return errs
+	}
+
+	m.createIdentities(ctx, m.r.IdentityPool().(PrivilegedPool), valid, errs)
+	return errs
+}
+
+// pendingIdentity is an identity of a batch which is yet to be created.
+type pendingIdentity struct {
+	index    int
+	identity *Identity
+	original *Identity
+}
+
+// createIdentities creates the identities in a single transaction. If the transaction fails, the batch is split
+// in half and both halves are retried until the identities causing the failure are isolated. This keeps the
+// number of transactions low if only a few identities of a large batch can not be created.
+func (m *Manager) createIdentities(ctx context.Context, pool PrivilegedPool, batch []pendingIdentity, errs []error) {
+	identities := make([]*Identity, len(batch))
+	for k, p := range batch {
+		identities[k] = p.identity
+	}
+
+	err := pool.CreateIdentities(ctx, identities...)
+	if err == nil {
+		for _, i := range identities {
+			m.emit(ctx, event.TypeIdentityCreated, i)
+		}
+		return
+	}
+
+	// The failed transaction assigned IDs and timestamps which were rolled back, so they must not be reused.
+	for _, p := range batch {
+		*p.identity = *deepcopy.Copy(p.original).(*Identity)
+	}
+
+	if len(batch) == 1 {
+		errs[batch[0].index] = err
+		return
+	}
+
+	m.createIdentities(ctx, pool, batch[:len(batch)/2], errs)
+	m.createIdentities(ctx, pool, batch[len(batch)/2:], errs)
 }
 
 func (m *Manager) requiresPrivilegedAccess(_ context.Context, original, updated *Identity, o *managerOptions) error {
@@ -104,7 +182,12 @@ func (m *Manager) Update(ctx context.Context, updated *Identity, opts ...Manager
 		return err
 	}
 
-	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, updated)
+	if !o.AllowWriteMetadata {
+		updated.MetadataPublic = original.MetadataPublic
+		updated.MetadataAdmin = original.MetadataAdmin
+	}
+
+	return m.update(ctx, updated)
 }
 
 func (m *Manager) UpdateSchemaID(ctx context.Context, id uuid.UUID, schemaID string, opts ...ManagerOption) error {
@@ -123,7 +206,7 @@ func (m *Manager) UpdateSchemaID(ctx context.Context, id uuid.UUID, schemaID str
 		return err
 	}
 
-	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, original)
+	return m.update(ctx, original)
 }
 
 func (m *Manager) SetTraits(ctx context.Context, id uuid.UUID, traits Traits, opts ...ManagerOption) (*Identity, error) {
@@ -153,7 +236,21 @@ func (m *Manager) UpdateTraits(ctx context.Context, id uuid.UUID, traits Traits,
 		return err
 	}
 
-	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, updated)
+	return m.update(ctx, updated)
+}
+
+func (m *Manager) update(ctx context.Context, i *Identity) error {
+	if err := m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, i); err != nil {
+		return err
+	}
+
+	m.emit(ctx, event.TypeIdentityUpdated, i)
+	return nil
+}
+
+// emit sends the identity including its admin metadata, but without credentials, along with the event.
+func (m *Manager) emit(ctx context.Context, t event.Type, i *Identity) {
+	m.r.EventStream().Emit(ctx, event.New(t, i.ID).WithData("identity", WithAdminMetadataInJSON(*i)))
 }
 
 func (m *Manager) validate(ctx context.Context, i *Identity, o *managerOptions) error {
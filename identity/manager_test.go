@@ -7,6 +7,7 @@ import (
 
 	"github.com/ory/kratos/internal/testhelpers"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
 )
 
 func TestManager(t *testing.T) {
@@ -97,6 +99,40 @@ func TestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("method=CreateIdentities", func(t *testing.T) {
+		t.Run("case=should only skip conflicting identities", func(t *testing.T) {
+			existing := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			existing.Traits = newTraits("batch-conflict@ory.sh", "")
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), existing))
+
+			var identities []*identity.Identity
+			for k := 0; k < 5; k++ {
+				email := fmt.Sprintf("batch-%d@ory.sh", k)
+				if k == 3 {
+					email = "batch-conflict@ory.sh"
+				}
+				identities = append(identities, &identity.Identity{SchemaID: config.DefaultIdentityTraitsSchemaID, Traits: newTraits(email, "")})
+			}
+
+			errs := reg.IdentityManager().CreateIdentities(context.Background(), identities)
+			require.Len(t, errs, 5)
+			for k, i := range identities {
+				if k == 3 {
+					require.Error(t, errs[k])
+					// Fields generated by the rolled back transactions must not be kept.
+					assert.Equal(t, uuid.Nil, i.ID)
+					assert.True(t, i.CreatedAt.IsZero())
+					continue
+				}
+
+				require.NoError(t, errs[k])
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+				require.NoError(t, err)
+				checkExtensionFieldsForIdentities(t, fmt.Sprintf("batch-%d@ory.sh", k), actual)
+			}
+		})
+	})
+
 	t.Run("method=Update", func(t *testing.T) {
 		t.Run("case=should update identity and update extension fields", func(t *testing.T) {
 			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
@@ -126,6 +162,30 @@ func TestManager(t *testing.T) {
 			checkExtensionFields(fromStore, "email-update-1@ory.sh")(t)
 		})
 
+		t.Run("case=should not update metadata without option", func(t *testing.T) {
+			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			original.Traits = newTraits("metadata-update@ory.sh", "")
+			original.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"free"}`)
+			original.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"fraud_score":0.1}`)
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), original))
+
+			original.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+			original.MetadataAdmin = nil
+			require.NoError(t, reg.IdentityManager().Update(context.Background(), original, identity.ManagerAllowWriteProtectedTraits))
+
+			fromStore, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), original.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"plan":"free"}`, string(fromStore.MetadataPublic))
+			assert.JSONEq(t, `{"fraud_score":0.1}`, string(fromStore.MetadataAdmin))
+
+			original.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"pro"}`)
+			require.NoError(t, reg.IdentityManager().Update(context.Background(), original, identity.ManagerAllowWriteProtectedTraits, identity.ManagerAllowWriteMetadata))
+
+			fromStore, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), original.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"plan":"pro"}`, string(fromStore.MetadataPublic))
+		})
+
 		t.Run("case=changing recovery address removes it from the store", func(t *testing.T) {
 			originalEmail := x.NewUUID().String() + "@ory.sh"
 			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
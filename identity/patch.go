@@ -0,0 +1,173 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/sqlxx"
+)
+
+// JSON Patch Operation
+//
+// A JSON Patch operation as defined in [RFC 6902](https://datatracker.ietf.org/doc/html/rfc6902).
+//
+// swagger:model jsonPatch
+type PatchOperation struct {
+	// The operation to be performed. One of "add", "remove", "replace", "move", "copy", or "test".
+	//
+	// required: true
+	// example: replace
+	Op string `json:"op"`
+
+	// The path to the target path. Uses the JSON pointer notation.
+	//
+	// required: true
+	// example: /traits/email
+	Path string `json:"path"`
+
+	// The value to be used within the operations.
+	//
+	// example: foo@example.org
+	Value interface{} `json:"value,omitempty"`
+
+	// This field is used together with operation "move" and uses the JSON pointer notation.
+	//
+	// example: /traits/name
+	From string `json:"from,omitempty"`
+}
+
+// patchablePaths are the JSON pointers which may be targeted by a JSON Patch operation. Credentials
+// can only be patched by their identifiers so that secrets such as password hashes can neither be read
+// nor written using a patch.
+var patchablePaths = regexp.MustCompile(`^/(traits|state|schema_id|metadata_public|metadata_admin|verifiable_addresses|recovery_addresses|credentials/[^/]+/identifiers)(/.*)?$`)
+
+type (
+	patchableCredentials struct {
+		Identifiers []string `json:"identifiers"`
+	}
+
+	patchableIdentity struct {
+		SchemaID            string                                   `json:"schema_id"`
+		State               State                                    `json:"state"`
+		Traits              json.RawMessage                          `json:"traits"`
+		MetadataPublic      json.RawMessage                          `json:"metadata_public"`
+		MetadataAdmin       json.RawMessage                          `json:"metadata_admin"`
+		VerifiableAddresses []VerifiableAddress                      `json:"verifiable_addresses"`
+		RecoveryAddresses   []RecoveryAddress                        `json:"recovery_addresses"`
+		Credentials         map[CredentialsType]patchableCredentials `json:"credentials"`
+	}
+)
+
+// ApplyJSONPatch applies the RFC 6902 JSON Patch to the identity. Only the identity's schema ID, state,
+// traits, metadata, addresses, and credential identifiers can be patched. The patched identity
+// is not validated, which is the responsibility of the identity manager.
+func (i *Identity) ApplyJSONPatch(patch json.RawMessage) error {
+	var ops []PatchOperation
+	if err := json.NewDecoder(bytes.NewReader(patch)).Decode(&ops); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Patch document is invalid: %s", err).WithWrap(err))
+	}
+
+	for _, op := range ops {
+		paths := []string{op.Path}
+		if op.From != "" {
+			paths = append(paths, op.From)
+		}
+
+		for _, path := range paths {
+			if !patchablePaths.MatchString(path) {
+				return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Patch operation %q targets path %q which can not be patched.", op.Op, path))
+			}
+		}
+	}
+
+	doc := patchableIdentity{
+		SchemaID:            i.SchemaID,
+		State:               i.State,
+		Traits:              json.RawMessage(i.Traits),
+		MetadataPublic:      json.RawMessage(i.MetadataPublic),
+		MetadataAdmin:       json.RawMessage(i.MetadataAdmin),
+		VerifiableAddresses: i.VerifiableAddresses,
+		RecoveryAddresses:   i.RecoveryAddresses,
+		Credentials:         make(map[CredentialsType]patchableCredentials, len(i.Credentials)),
+	}
+	for t, c := range i.Credentials {
+		doc.Credentials[t] = patchableCredentials{Identifiers: c.Identifiers}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	patched := json.RawMessage(raw)
+	if err := jsonx.ApplyJSONPatch(patch, &patched); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to apply the JSON Patch: %s", err).WithWrap(err))
+	}
+
+	var result patchableIdentity
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The patched identity is invalid: %s", err).WithWrap(err))
+	}
+
+	if result.State != i.State {
+		if err := result.State.IsValid(); err != nil {
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err))
+		}
+
+		stateChangedAt := sqlxx.NullTime(time.Now())
+		i.State = result.State
+		i.StateChangedAt = &stateChangedAt
+	}
+
+	i.SchemaID = result.SchemaID
+	i.Traits = Traits(result.Traits)
+	i.MetadataPublic = nullJSON(result.MetadataPublic)
+	i.MetadataAdmin = nullJSON(result.MetadataAdmin)
+	i.VerifiableAddresses = result.VerifiableAddresses
+	i.RecoveryAddresses = result.RecoveryAddresses
+
+	for t, c := range result.Credentials {
+		cred, ok := i.Credentials[t]
+		if !ok {
+			return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Patch must not add credentials of type %q.", t))
+		}
+		cred.Identifiers = c.Identifiers
+		i.Credentials[t] = cred
+	}
+
+	return nil
+}
+
+// changedVerifiableAddresses returns the addresses in patched which differ from the address with the same ID in
+// original, or which have no ID yet.
+func changedVerifiableAddresses(original, patched []VerifiableAddress) (changed []VerifiableAddress) {
+	for _, p := range patched {
+		var found bool
+		for _, o := range original {
+			if o.ID == p.ID {
+				found = true
+				if o.Value != p.Value || o.Via != p.Via || o.Verified != p.Verified || o.Status != p.Status {
+					changed = append(changed, p)
+				}
+				break
+			}
+		}
+		if !found {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+func nullJSON(raw json.RawMessage) sqlxx.NullJSONRawMessage {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	return sqlxx.NullJSONRawMessage(raw)
+}
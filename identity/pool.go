@@ -2,17 +2,59 @@ package identity
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
 
 type (
+	// ListIdentityParameters filters and paginates the identities returned by Pool.ListIdentities.
+	//
+	// Zero values are ignored. If PageToken is set, keyset pagination is used and Page is ignored.
+	ListIdentityParameters struct {
+		// CredentialsIdentifier matches identities having a credential with exactly this identifier.
+		CredentialsIdentifier string
+
+		// CredentialsIdentifierPrefix matches identities having a credential identifier starting with this prefix.
+		CredentialsIdentifierPrefix string
+
+		// VerifiableAddress matches identities having a verifiable address with this value.
+		VerifiableAddress string
+
+		// VerifiableAddressVerified matches identities having a verifiable address in the given verification status.
+		VerifiableAddressVerified *bool
+
+		// SchemaID matches identities using this identity schema.
+		SchemaID string
+
+		// State matches identities in this state.
+		State State
+
+		// CreatedAfter matches identities created at or after this time.
+		CreatedAfter time.Time
+
+		// CreatedBefore matches identities created before this time.
+		CreatedBefore time.Time
+
+		// TraitPath is a dot-separated path into the traits (e.g. `name.first`) which must equal TraitValue.
+		TraitPath  string
+		TraitValue string
+
+		// PageToken is the ID of the last identity of the previous page. Identities are ordered by ID in descending order.
+		PageToken uuid.NullUUID
+
+		// Page and PerPage control the page-based pagination.
+		Page    int
+		PerPage int
+	}
+
 	Pool interface {
-		// ListIdentities lists all identities in the store given the page and itemsPerPage.
-		ListIdentities(ctx context.Context, page, itemsPerPage int) ([]Identity, error)
+		// ListIdentities lists the identities in the store matching the given parameters.
+		ListIdentities(ctx context.Context, params ListIdentityParameters) ([]Identity, error)
 
-		// CountIdentities counts the number of identities in the store.
-		CountIdentities(ctx context.Context) (int64, error)
+		// CountIdentities counts the number of identities in the store matching the given parameters. Pagination
+		// parameters are ignored.
+		CountIdentities(ctx context.Context, params ListIdentityParameters) (int64, error)
 
 		// GetIdentity returns an identity by its id. Will return an error if the identity does not exist or backend
 		// connectivity is broken.
@@ -50,12 +92,21 @@ type (
 		// if identity exists, backend connectivity is broken, or trait validation fails.
 		CreateIdentity(context.Context, *Identity) error
 
+		// CreateIdentities creates multiple identities in a single transaction. Will return an error and create none
+		// of the identities if one of them exists, backend connectivity is broken, or trait validation fails.
+		CreateIdentities(context.Context, ...*Identity) error
+
 		// UpdateIdentity updates an identity including its confidential / privileged / protected data.
 		UpdateIdentity(context.Context, *Identity) error
 
 		// GetIdentityConfidential returns the identity including it's raw credentials. This should only be used internally.
 		GetIdentityConfidential(context.Context, uuid.UUID) (*Identity, error)
 
+		// LockIdentity runs fn in a transaction which holds a write lock on the identity. Reading the identity
+		// within fn before writing it prevents concurrent read-modify-write cycles from overwriting each other's
+		// changes. Returns an error if the identity does not exist.
+		LockIdentity(ctx context.Context, id uuid.UUID, fn func(ctx context.Context) error) error
+
 		// ListVerifiableAddresses lists all tracked verifiable addresses, regardless of whether they are already verified
 		// or not.
 		ListVerifiableAddresses(ctx context.Context, page, itemsPerPage int) ([]VerifiableAddress, error)
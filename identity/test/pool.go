@@ -114,13 +114,13 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			assert.Equal(t, nid, i.NID)
 			createdIDs = append(createdIDs, i.ID)
 
-			count, err := p.CountIdentities(ctx)
+			count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 			require.NoError(t, err)
 			assert.EqualValues(t, int64(1), count)
 
 			t.Run("different network", func(t *testing.T) {
 				_, p := testhelpers.NewNetwork(t, ctx, p)
-				count, err := p.CountIdentities(ctx)
+				count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 				require.NoError(t, err)
 				assert.EqualValues(t, int64(0), count)
 			})
@@ -139,7 +139,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			assert.Equal(t, defaultSchema.SchemaURL(exampleServerURL).String(), actual.SchemaURL)
 			assertEqual(t, expected, actual)
 
-			count, err := p.CountIdentities(ctx)
+			count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 			require.NoError(t, err)
 			assert.EqualValues(t, 2, count)
 
@@ -148,7 +148,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 				_, err := p.GetIdentity(ctx, expected.ID)
 				require.ErrorIs(t, err, sqlcon.ErrNoRows)
 
-				count, err := p.CountIdentities(ctx)
+				count, err := p.CountIdentities(ctx, identity.ListIdentityParameters{})
 				require.NoError(t, err)
 				assert.EqualValues(t, int64(0), count)
 			})
@@ -271,6 +271,50 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			assert.Contains(t, fmt.Sprintf("%+v", err.Error()), "malformed")
 		})
 
+		t.Run("case=create multiple identities", func(t *testing.T) {
+			_, p := testhelpers.NewNetwork(t, ctx, p)
+
+			expected := []*identity.Identity{
+				passwordIdentity("", "batch-"+x.NewUUID().String()),
+				oidcIdentity("", "batch-"+x.NewUUID().String()),
+				identity.NewIdentity(""),
+			}
+			require.NoError(t, p.CreateIdentities(ctx, expected...))
+
+			for _, e := range expected {
+				actual, err := p.GetIdentityConfidential(ctx, e.ID)
+				require.NoError(t, err)
+				assert.Equal(t, config.DefaultIdentityTraitsSchemaID, actual.SchemaID)
+				assert.Equal(t, identity.StateActive, actual.State)
+				assert.Len(t, actual.Credentials, len(e.Credentials))
+			}
+
+			t.Run("case=create none if one identity conflicts", func(t *testing.T) {
+				conflicting := passwordIdentity("", "batch-"+x.NewUUID().String())
+				notCreated := []*identity.Identity{
+					passwordIdentity("", "batch-"+x.NewUUID().String()),
+					conflicting,
+					passwordIdentity("", conflicting.Credentials[identity.CredentialsTypePassword].Identifiers[0]),
+				}
+				require.ErrorIs(t, p.CreateIdentities(ctx, notCreated...), sqlcon.ErrUniqueViolation)
+
+				for _, i := range notCreated {
+					_, err := p.GetIdentity(ctx, i.ID)
+					require.ErrorIs(t, err, sqlcon.ErrNoRows)
+				}
+			})
+
+			t.Run("case=create none if one identity is invalid", func(t *testing.T) {
+				invalid := oidcIdentity("", x.NewUUID().String())
+				invalid.Traits = identity.Traits(`{"bar":123}`)
+				valid := passwordIdentity("", "batch-"+x.NewUUID().String())
+				require.Error(t, p.CreateIdentities(ctx, valid, invalid))
+
+				_, err := p.GetIdentity(ctx, valid.ID)
+				require.Error(t, err)
+			})
+		})
+
 		t.Run("case=get classified credentials", func(t *testing.T) {
 			initial := oidcIdentity("", x.NewUUID().String())
 			initial.SetCredentials(identity.CredentialsTypeOIDC, identity.Credentials{
@@ -409,6 +453,56 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			require.Error(t, err)
 		})
 
+		t.Run("case=lock an identity", func(t *testing.T) {
+			expected := passwordIdentity("", x.NewUUID().String())
+			require.NoError(t, p.CreateIdentity(ctx, expected))
+			createdIDs = append(createdIDs, expected.ID)
+
+			var called bool
+			require.NoError(t, p.LockIdentity(ctx, expected.ID, func(ctx context.Context) error {
+				called = true
+				actual, err := p.GetIdentityConfidential(ctx, expected.ID)
+				require.NoError(t, err)
+				actual.Traits = identity.Traits(`{"locked":true}`)
+				return p.UpdateIdentity(ctx, actual)
+			}))
+			assert.True(t, called)
+
+			actual, err := p.GetIdentity(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"locked":true}`, string(actual.Traits))
+
+			t.Run("rolls back if the callback fails", func(t *testing.T) {
+				errRollback := fmt.Errorf("rollback")
+				require.ErrorIs(t, p.LockIdentity(ctx, expected.ID, func(ctx context.Context) error {
+					actual, err := p.GetIdentityConfidential(ctx, expected.ID)
+					require.NoError(t, err)
+					actual.Traits = identity.Traits(`{"locked":false}`)
+					require.NoError(t, p.UpdateIdentity(ctx, actual))
+					return errRollback
+				}), errRollback)
+
+				actual, err := p.GetIdentity(ctx, expected.ID)
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"locked":true}`, string(actual.Traits))
+			})
+
+			t.Run("fails if the identity does not exist", func(t *testing.T) {
+				require.ErrorIs(t, p.LockIdentity(ctx, x.NewUUID(), func(context.Context) error {
+					t.Fatal("the callback must not be called")
+					return nil
+				}), sqlcon.ErrNoRows)
+			})
+
+			t.Run("fails on different network", func(t *testing.T) {
+				_, p := testhelpers.NewNetwork(t, ctx, p)
+				require.ErrorIs(t, p.LockIdentity(ctx, expected.ID, func(context.Context) error {
+					t.Fatal("the callback must not be called")
+					return nil
+				}), sqlcon.ErrNoRows)
+			})
+		})
+
 		t.Run("case=create with empty credentials config", func(t *testing.T) {
 			// This test covers a case where the config value of a credentials setting is empty. This causes
 			// issues with postgres' json field.
@@ -423,7 +517,7 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 		})
 
 		t.Run("case=list", func(t *testing.T) {
-			is, err := p.ListIdentities(ctx, 0, 25)
+			is, err := p.ListIdentities(ctx, identity.ListIdentityParameters{PerPage: 25})
 			require.NoError(t, err)
 			assert.Len(t, is, len(createdIDs))
 			for _, id := range createdIDs {
@@ -441,12 +535,96 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 
 			t.Run("no results on other network", func(t *testing.T) {
 				_, p := testhelpers.NewNetwork(t, ctx, p)
-				is, err := p.ListIdentities(ctx, 0, 25)
+				is, err := p.ListIdentities(ctx, identity.ListIdentityParameters{PerPage: 25})
 				require.NoError(t, err)
 				assert.Len(t, is, 0)
 			})
 		})
 
+		t.Run("case=list with filters", func(t *testing.T) {
+			_, p := testhelpers.NewNetwork(t, ctx, p)
+
+			first := passwordIdentity("", "list-filter-first@ory.sh")
+			first.Traits = identity.Traits(`{"bar":"baz","name":{"first":"Ann"}}`)
+			first.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress("list-filter-first@ory.sh", first.ID)}
+			require.NoError(t, p.CreateIdentity(ctx, first))
+
+			second := passwordIdentity(altSchema.ID, "list-filter-second@ory.sh")
+			second.Traits = identity.Traits(`{"name":{"first":"Bob","first-name":"Bobby"}}`)
+			second.State = identity.StateInactive
+			require.NoError(t, p.CreateIdentity(ctx, second))
+
+			third := passwordIdentity("", "other_filter@ory.sh")
+			third.Traits = identity.Traits(`{}`)
+			third.VerifiableAddresses = []identity.VerifiableAddress{*identity.NewVerifiableEmailAddress("other_filter@ory.sh", third.ID)}
+			third.VerifiableAddresses[0].Verified = true
+			require.NoError(t, p.CreateIdentity(ctx, third))
+
+			verified, unverified := true, false
+			for k, tc := range []struct {
+				params   identity.ListIdentityParameters
+				expected []*identity.Identity
+			}{
+				{params: identity.ListIdentityParameters{}, expected: []*identity.Identity{first, second, third}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifier: "list-filter-second@ory.sh"}, expected: []*identity.Identity{second}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifier: "LIST-FILTER-SECOND@ory.sh"}, expected: []*identity.Identity{second}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifier: "list-filter"}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "list-filter"}, expected: []*identity.Identity{first, second}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "other_"}, expected: []*identity.Identity{third}},
+				{params: identity.ListIdentityParameters{CredentialsIdentifierPrefix: "list_"}},
+				{params: identity.ListIdentityParameters{VerifiableAddress: "list-filter-first@ory.sh"}, expected: []*identity.Identity{first}},
+				{params: identity.ListIdentityParameters{VerifiableAddressVerified: &verified}, expected: []*identity.Identity{third}},
+				{params: identity.ListIdentityParameters{VerifiableAddressVerified: &unverified}, expected: []*identity.Identity{first}},
+				{params: identity.ListIdentityParameters{VerifiableAddress: "list-filter-first@ory.sh", VerifiableAddressVerified: &verified}},
+				{params: identity.ListIdentityParameters{SchemaID: altSchema.ID}, expected: []*identity.Identity{second}},
+				{params: identity.ListIdentityParameters{State: identity.StateInactive}, expected: []*identity.Identity{second}},
+				{params: identity.ListIdentityParameters{CreatedAfter: time.Now().Add(time.Hour)}},
+				{params: identity.ListIdentityParameters{CreatedBefore: time.Now().Add(time.Hour)}, expected: []*identity.Identity{first, second, third}},
+				{params: identity.ListIdentityParameters{TraitPath: "bar", TraitValue: "baz"}, expected: []*identity.Identity{first}},
+				{params: identity.ListIdentityParameters{TraitPath: "name.first", TraitValue: "Bob"}, expected: []*identity.Identity{second}},
+				{params: identity.ListIdentityParameters{TraitPath: "name.first", TraitValue: "Carl"}},
+				{params: identity.ListIdentityParameters{TraitPath: "name.first-name", TraitValue: "Bobby"}, expected: []*identity.Identity{second}},
+			} {
+				t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+					tc.params.PerPage = 25
+					is, err := p.ListIdentities(ctx, tc.params)
+					require.NoError(t, err)
+
+					actual := make([]uuid.UUID, len(is))
+					for k := range is {
+						actual[k] = is[k].ID
+					}
+					expected := make([]uuid.UUID, len(tc.expected))
+					for k := range tc.expected {
+						expected[k] = tc.expected[k].ID
+					}
+					assert.ElementsMatch(t, expected, actual)
+
+					count, err := p.CountIdentities(ctx, tc.params)
+					require.NoError(t, err)
+					assert.EqualValues(t, len(tc.expected), count)
+				})
+			}
+
+			t.Run("case=keyset pagination", func(t *testing.T) {
+				var seen []uuid.UUID
+				params := identity.ListIdentityParameters{PerPage: 2, PageToken: uuid.NullUUID{Valid: true}}
+				for {
+					is, err := p.ListIdentities(ctx, params)
+					require.NoError(t, err)
+					if len(is) == 0 {
+						break
+					}
+					require.LessOrEqual(t, len(is), 2)
+					for _, i := range is {
+						seen = append(seen, i.ID)
+					}
+					params.PageToken.UUID = is[len(is)-1].ID
+				}
+				assert.ElementsMatch(t, []uuid.UUID{first.ID, second.ID, third.ID}, seen)
+			})
+		})
+
 		t.Run("case=find identity by its credentials identifier", func(t *testing.T) {
 			expected := passwordIdentity("", "find-credentials-identifier@ory.sh")
 			expected.Traits = identity.Traits(`{}`)
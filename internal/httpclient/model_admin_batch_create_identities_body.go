@@ -0,0 +1,108 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+)
+
+// AdminBatchCreateIdentitiesBody struct for AdminBatchCreateIdentitiesBody
+type AdminBatchCreateIdentitiesBody struct {
+	// The identities to create. At most 1000 identities can be created with one request.
+	Identities []AdminCreateIdentityBody `json:"identities"`
+}
+
+// NewAdminBatchCreateIdentitiesBody instantiates a new AdminBatchCreateIdentitiesBody object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewAdminBatchCreateIdentitiesBody(identities []AdminCreateIdentityBody) *AdminBatchCreateIdentitiesBody {
+	this := AdminBatchCreateIdentitiesBody{}
+	this.Identities = identities
+	return &this
+}
+
+// NewAdminBatchCreateIdentitiesBodyWithDefaults instantiates a new AdminBatchCreateIdentitiesBody object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewAdminBatchCreateIdentitiesBodyWithDefaults() *AdminBatchCreateIdentitiesBody {
+	this := AdminBatchCreateIdentitiesBody{}
+	return &this
+}
+
+// GetIdentities returns the Identities field value
+func (o *AdminBatchCreateIdentitiesBody) GetIdentities() []AdminCreateIdentityBody {
+	if o == nil {
+		var ret []AdminCreateIdentityBody
+		return ret
+	}
+
+	return o.Identities
+}
+
+// GetIdentitiesOk returns a tuple with the Identities field value
+// and a boolean to check if the value has been set.
+func (o *AdminBatchCreateIdentitiesBody) GetIdentitiesOk() (*[]AdminCreateIdentityBody, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Identities, true
+}
+
+// SetIdentities sets field value
+func (o *AdminBatchCreateIdentitiesBody) SetIdentities(v []AdminCreateIdentityBody) {
+	o.Identities = v
+}
+
+func (o AdminBatchCreateIdentitiesBody) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["identities"] = o.Identities
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableAdminBatchCreateIdentitiesBody struct {
+	value *AdminBatchCreateIdentitiesBody
+	isSet bool
+}
+
+func (v NullableAdminBatchCreateIdentitiesBody) Get() *AdminBatchCreateIdentitiesBody {
+	return v.value
+}
+
+func (v *NullableAdminBatchCreateIdentitiesBody) Set(val *AdminBatchCreateIdentitiesBody) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableAdminBatchCreateIdentitiesBody) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableAdminBatchCreateIdentitiesBody) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableAdminBatchCreateIdentitiesBody(val *AdminBatchCreateIdentitiesBody) *NullableAdminBatchCreateIdentitiesBody {
+	return &NullableAdminBatchCreateIdentitiesBody{value: val, isSet: true}
+}
+
+func (v NullableAdminBatchCreateIdentitiesBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableAdminBatchCreateIdentitiesBody) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
@@ -0,0 +1,108 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+)
+
+// AdminBatchCreateIdentitiesResponse struct for AdminBatchCreateIdentitiesResponse
+type AdminBatchCreateIdentitiesResponse struct {
+	// The result for each identity, in the same order as the identities of the request.
+	Identities []AdminBatchCreateIdentityResult `json:"identities"`
+}
+
+// NewAdminBatchCreateIdentitiesResponse instantiates a new AdminBatchCreateIdentitiesResponse object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewAdminBatchCreateIdentitiesResponse(identities []AdminBatchCreateIdentityResult) *AdminBatchCreateIdentitiesResponse {
+	this := AdminBatchCreateIdentitiesResponse{}
+	this.Identities = identities
+	return &this
+}
+
+// NewAdminBatchCreateIdentitiesResponseWithDefaults instantiates a new AdminBatchCreateIdentitiesResponse object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewAdminBatchCreateIdentitiesResponseWithDefaults() *AdminBatchCreateIdentitiesResponse {
+	this := AdminBatchCreateIdentitiesResponse{}
+	return &this
+}
+
+// GetIdentities returns the Identities field value
+func (o *AdminBatchCreateIdentitiesResponse) GetIdentities() []AdminBatchCreateIdentityResult {
+	if o == nil {
+		var ret []AdminBatchCreateIdentityResult
+		return ret
+	}
+
+	return o.Identities
+}
+
+// GetIdentitiesOk returns a tuple with the Identities field value
+// and a boolean to check if the value has been set.
+func (o *AdminBatchCreateIdentitiesResponse) GetIdentitiesOk() (*[]AdminBatchCreateIdentityResult, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Identities, true
+}
+
+// SetIdentities sets field value
+func (o *AdminBatchCreateIdentitiesResponse) SetIdentities(v []AdminBatchCreateIdentityResult) {
+	o.Identities = v
+}
+
+func (o AdminBatchCreateIdentitiesResponse) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["identities"] = o.Identities
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableAdminBatchCreateIdentitiesResponse struct {
+	value *AdminBatchCreateIdentitiesResponse
+	isSet bool
+}
+
+func (v NullableAdminBatchCreateIdentitiesResponse) Get() *AdminBatchCreateIdentitiesResponse {
+	return v.value
+}
+
+func (v *NullableAdminBatchCreateIdentitiesResponse) Set(val *AdminBatchCreateIdentitiesResponse) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableAdminBatchCreateIdentitiesResponse) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableAdminBatchCreateIdentitiesResponse) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableAdminBatchCreateIdentitiesResponse(val *AdminBatchCreateIdentitiesResponse) *NullableAdminBatchCreateIdentitiesResponse {
+	return &NullableAdminBatchCreateIdentitiesResponse{value: val, isSet: true}
+}
+
+func (v NullableAdminBatchCreateIdentitiesResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableAdminBatchCreateIdentitiesResponse) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
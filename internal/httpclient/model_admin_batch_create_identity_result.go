@@ -0,0 +1,151 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+)
+
+// AdminBatchCreateIdentityResult struct for AdminBatchCreateIdentityResult
+type AdminBatchCreateIdentityResult struct {
+	Error *GenericError `json:"error,omitempty"`
+	// The ID of the created identity. Not set if the identity could not be created.
+	Identity *string `json:"identity,omitempty"`
+}
+
+// NewAdminBatchCreateIdentityResult instantiates a new AdminBatchCreateIdentityResult object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewAdminBatchCreateIdentityResult() *AdminBatchCreateIdentityResult {
+	this := AdminBatchCreateIdentityResult{}
+	return &this
+}
+
+// NewAdminBatchCreateIdentityResultWithDefaults instantiates a new AdminBatchCreateIdentityResult object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewAdminBatchCreateIdentityResultWithDefaults() *AdminBatchCreateIdentityResult {
+	this := AdminBatchCreateIdentityResult{}
+	return &this
+}
+
+// GetError returns the Error field value if set, zero value otherwise.
+func (o *AdminBatchCreateIdentityResult) GetError() GenericError {
+	if o == nil || o.Error == nil {
+		var ret GenericError
+		return ret
+	}
+	return *o.Error
+}
+
+// GetErrorOk returns a tuple with the Error field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminBatchCreateIdentityResult) GetErrorOk() (*GenericError, bool) {
+	if o == nil || o.Error == nil {
+		return nil, false
+	}
+	return o.Error, true
+}
+
+// HasError returns a boolean if a field has been set.
+func (o *AdminBatchCreateIdentityResult) HasError() bool {
+	if o != nil && o.Error != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetError gets a reference to the given GenericError and assigns it to the Error field.
+func (o *AdminBatchCreateIdentityResult) SetError(v GenericError) {
+	o.Error = &v
+}
+
+// GetIdentity returns the Identity field value if set, zero value otherwise.
+func (o *AdminBatchCreateIdentityResult) GetIdentity() string {
+	if o == nil || o.Identity == nil {
+		var ret string
+		return ret
+	}
+	return *o.Identity
+}
+
+// GetIdentityOk returns a tuple with the Identity field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *AdminBatchCreateIdentityResult) GetIdentityOk() (*string, bool) {
+	if o == nil || o.Identity == nil {
+		return nil, false
+	}
+	return o.Identity, true
+}
+
+// HasIdentity returns a boolean if a field has been set.
+func (o *AdminBatchCreateIdentityResult) HasIdentity() bool {
+	if o != nil && o.Identity != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetIdentity gets a reference to the given string and assigns it to the Identity field.
+func (o *AdminBatchCreateIdentityResult) SetIdentity(v string) {
+	o.Identity = &v
+}
+
+func (o AdminBatchCreateIdentityResult) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if o.Error != nil {
+		toSerialize["error"] = o.Error
+	}
+	if o.Identity != nil {
+		toSerialize["identity"] = o.Identity
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableAdminBatchCreateIdentityResult struct {
+	value *AdminBatchCreateIdentityResult
+	isSet bool
+}
+
+func (v NullableAdminBatchCreateIdentityResult) Get() *AdminBatchCreateIdentityResult {
+	return v.value
+}
+
+func (v *NullableAdminBatchCreateIdentityResult) Set(val *AdminBatchCreateIdentityResult) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableAdminBatchCreateIdentityResult) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableAdminBatchCreateIdentityResult) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableAdminBatchCreateIdentityResult(val *AdminBatchCreateIdentityResult) *NullableAdminBatchCreateIdentityResult {
+	return &NullableAdminBatchCreateIdentityResult{value: val, isSet: true}
+}
+
+func (v NullableAdminBatchCreateIdentityResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableAdminBatchCreateIdentityResult) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
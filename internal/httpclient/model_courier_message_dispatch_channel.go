@@ -0,0 +1,86 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CourierMessageDispatchChannel The channel a Message Dispatch was sent through
+type CourierMessageDispatchChannel string
+
+// List of courierMessageDispatchChannel
+const (
+	COURIERMESSAGEDISPATCHCHANNEL_SMTP CourierMessageDispatchChannel = "smtp"
+	COURIERMESSAGEDISPATCHCHANNEL_SMS  CourierMessageDispatchChannel = "sms"
+	COURIERMESSAGEDISPATCHCHANNEL_HTTP CourierMessageDispatchChannel = "http"
+	COURIERMESSAGEDISPATCHCHANNEL_NONE CourierMessageDispatchChannel = "none"
+)
+
+func (v *CourierMessageDispatchChannel) UnmarshalJSON(src []byte) error {
+	var value string
+	err := json.Unmarshal(src, &value)
+	if err != nil {
+		return err
+	}
+	enumTypeValue := CourierMessageDispatchChannel(value)
+	for _, existing := range []CourierMessageDispatchChannel{"smtp", "sms", "http", "none"} {
+		if existing == enumTypeValue {
+			*v = enumTypeValue
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%+v is not a valid CourierMessageDispatchChannel", value)
+}
+
+// Ptr returns reference to courierMessageDispatchChannel value
+func (v CourierMessageDispatchChannel) Ptr() *CourierMessageDispatchChannel {
+	return &v
+}
+
+type NullableCourierMessageDispatchChannel struct {
+	value *CourierMessageDispatchChannel
+	isSet bool
+}
+
+func (v NullableCourierMessageDispatchChannel) Get() *CourierMessageDispatchChannel {
+	return v.value
+}
+
+func (v *NullableCourierMessageDispatchChannel) Set(val *CourierMessageDispatchChannel) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableCourierMessageDispatchChannel) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableCourierMessageDispatchChannel) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableCourierMessageDispatchChannel(val *CourierMessageDispatchChannel) *NullableCourierMessageDispatchChannel {
+	return &NullableCourierMessageDispatchChannel{value: val, isSet: true}
+}
+
+func (v NullableCourierMessageDispatchChannel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableCourierMessageDispatchChannel) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
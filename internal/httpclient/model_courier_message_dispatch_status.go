@@ -0,0 +1,84 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CourierMessageDispatchStatus A Message Dispatch's Status  It can either be `failed` or `success`.
+type CourierMessageDispatchStatus string
+
+// List of courierMessageDispatchStatus
+const (
+	COURIERMESSAGEDISPATCHSTATUS_FAILED  CourierMessageDispatchStatus = "failed"
+	COURIERMESSAGEDISPATCHSTATUS_SUCCESS CourierMessageDispatchStatus = "success"
+)
+
+func (v *CourierMessageDispatchStatus) UnmarshalJSON(src []byte) error {
+	var value string
+	err := json.Unmarshal(src, &value)
+	if err != nil {
+		return err
+	}
+	enumTypeValue := CourierMessageDispatchStatus(value)
+	for _, existing := range []CourierMessageDispatchStatus{"failed", "success"} {
+		if existing == enumTypeValue {
+			*v = enumTypeValue
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%+v is not a valid CourierMessageDispatchStatus", value)
+}
+
+// Ptr returns reference to courierMessageDispatchStatus value
+func (v CourierMessageDispatchStatus) Ptr() *CourierMessageDispatchStatus {
+	return &v
+}
+
+type NullableCourierMessageDispatchStatus struct {
+	value *CourierMessageDispatchStatus
+	isSet bool
+}
+
+func (v NullableCourierMessageDispatchStatus) Get() *CourierMessageDispatchStatus {
+	return v.value
+}
+
+func (v *NullableCourierMessageDispatchStatus) Set(val *CourierMessageDispatchStatus) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableCourierMessageDispatchStatus) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableCourierMessageDispatchStatus) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableCourierMessageDispatchStatus(val *CourierMessageDispatchStatus) *NullableCourierMessageDispatchStatus {
+	return &NullableCourierMessageDispatchStatus{value: val, isSet: true}
+}
+
+func (v NullableCourierMessageDispatchStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableCourierMessageDispatchStatus) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
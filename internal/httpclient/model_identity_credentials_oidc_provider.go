@@ -13,15 +13,21 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // IdentityCredentialsOidcProvider struct for IdentityCredentialsOidcProvider
 type IdentityCredentialsOidcProvider struct {
-	InitialAccessToken  *string `json:"initial_access_token,omitempty"`
-	InitialIdToken      *string `json:"initial_id_token,omitempty"`
-	InitialRefreshToken *string `json:"initial_refresh_token,omitempty"`
-	Provider            *string `json:"provider,omitempty"`
-	Subject             *string `json:"subject,omitempty"`
+	// AccessToken and RefreshToken are the most recent tokens issued by the provider. Like the initial tokens, they are encrypted.
+	AccessToken *string `json:"access_token,omitempty"`
+	// AccessTokenExpiresAt is when AccessToken expires. It is not set if the provider did not tell.
+	AccessTokenExpiresAt *time.Time `json:"access_token_expires_at,omitempty"`
+	InitialAccessToken   *string    `json:"initial_access_token,omitempty"`
+	InitialIdToken       *string    `json:"initial_id_token,omitempty"`
+	InitialRefreshToken  *string    `json:"initial_refresh_token,omitempty"`
+	Provider             *string    `json:"provider,omitempty"`
+	RefreshToken         *string    `json:"refresh_token,omitempty"`
+	Subject              *string    `json:"subject,omitempty"`
 }
 
 // NewIdentityCredentialsOidcProvider instantiates a new IdentityCredentialsOidcProvider object
@@ -41,6 +47,70 @@ func NewIdentityCredentialsOidcProviderWithDefaults() *IdentityCredentialsOidcPr
 	return &this
 }
 
+// GetAccessToken returns the AccessToken field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetAccessToken() string {
+	if o == nil || o.AccessToken == nil {
+		var ret string
+		return ret
+	}
+	return *o.AccessToken
+}
+
+// GetAccessTokenOk returns a tuple with the AccessToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetAccessTokenOk() (*string, bool) {
+	if o == nil || o.AccessToken == nil {
+		return nil, false
+	}
+	return o.AccessToken, true
+}
+
+// HasAccessToken returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasAccessToken() bool {
+	if o != nil && o.AccessToken != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAccessToken gets a reference to the given string and assigns it to the AccessToken field.
+func (o *IdentityCredentialsOidcProvider) SetAccessToken(v string) {
+	o.AccessToken = &v
+}
+
+// GetAccessTokenExpiresAt returns the AccessTokenExpiresAt field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetAccessTokenExpiresAt() time.Time {
+	if o == nil || o.AccessTokenExpiresAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.AccessTokenExpiresAt
+}
+
+// GetAccessTokenExpiresAtOk returns a tuple with the AccessTokenExpiresAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetAccessTokenExpiresAtOk() (*time.Time, bool) {
+	if o == nil || o.AccessTokenExpiresAt == nil {
+		return nil, false
+	}
+	return o.AccessTokenExpiresAt, true
+}
+
+// HasAccessTokenExpiresAt returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasAccessTokenExpiresAt() bool {
+	if o != nil && o.AccessTokenExpiresAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetAccessTokenExpiresAt gets a reference to the given time.Time and assigns it to the AccessTokenExpiresAt field.
+func (o *IdentityCredentialsOidcProvider) SetAccessTokenExpiresAt(v time.Time) {
+	o.AccessTokenExpiresAt = &v
+}
+
 // GetInitialAccessToken returns the InitialAccessToken field value if set, zero value otherwise.
 func (o *IdentityCredentialsOidcProvider) GetInitialAccessToken() string {
 	if o == nil || o.InitialAccessToken == nil {
@@ -169,6 +239,38 @@ func (o *IdentityCredentialsOidcProvider) SetProvider(v string) {
 	o.Provider = &v
 }
 
+// GetRefreshToken returns the RefreshToken field value if set, zero value otherwise.
+func (o *IdentityCredentialsOidcProvider) GetRefreshToken() string {
+	if o == nil || o.RefreshToken == nil {
+		var ret string
+		return ret
+	}
+	return *o.RefreshToken
+}
+
+// GetRefreshTokenOk returns a tuple with the RefreshToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityCredentialsOidcProvider) GetRefreshTokenOk() (*string, bool) {
+	if o == nil || o.RefreshToken == nil {
+		return nil, false
+	}
+	return o.RefreshToken, true
+}
+
+// HasRefreshToken returns a boolean if a field has been set.
+func (o *IdentityCredentialsOidcProvider) HasRefreshToken() bool {
+	if o != nil && o.RefreshToken != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetRefreshToken gets a reference to the given string and assigns it to the RefreshToken field.
+func (o *IdentityCredentialsOidcProvider) SetRefreshToken(v string) {
+	o.RefreshToken = &v
+}
+
 // GetSubject returns the Subject field value if set, zero value otherwise.
 func (o *IdentityCredentialsOidcProvider) GetSubject() string {
 	if o == nil || o.Subject == nil {
@@ -203,6 +305,12 @@ func (o *IdentityCredentialsOidcProvider) SetSubject(v string) {
 
 func (o IdentityCredentialsOidcProvider) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.AccessToken != nil {
+		toSerialize["access_token"] = o.AccessToken
+	}
+	if o.AccessTokenExpiresAt != nil {
+		toSerialize["access_token_expires_at"] = o.AccessTokenExpiresAt
+	}
 	if o.InitialAccessToken != nil {
 		toSerialize["initial_access_token"] = o.InitialAccessToken
 	}
@@ -215,6 +323,9 @@ func (o IdentityCredentialsOidcProvider) MarshalJSON() ([]byte, error) {
 	if o.Provider != nil {
 		toSerialize["provider"] = o.Provider
 	}
+	if o.RefreshToken != nil {
+		toSerialize["refresh_token"] = o.RefreshToken
+	}
 	if o.Subject != nil {
 		toSerialize["subject"] = o.Subject
 	}
@@ -0,0 +1,206 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IdentityOidcProviderToken struct for IdentityOidcProviderToken
+type IdentityOidcProviderToken struct {
+	// AccessToken is a currently valid access token issued by the provider.
+	AccessToken string `json:"access_token"`
+	// ExpiresAt is when the access token expires. It is not set if the provider did not tell.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Provider is the ID of the OpenID Connect provider which issued the token.
+	Provider string `json:"provider"`
+	// Subject is the identity's subject at the provider.
+	Subject string `json:"subject"`
+}
+
+// NewIdentityOidcProviderToken instantiates a new IdentityOidcProviderToken object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewIdentityOidcProviderToken(accessToken string, provider string, subject string) *IdentityOidcProviderToken {
+	this := IdentityOidcProviderToken{}
+	this.AccessToken = accessToken
+	this.Provider = provider
+	this.Subject = subject
+	return &this
+}
+
+// NewIdentityOidcProviderTokenWithDefaults instantiates a new IdentityOidcProviderToken object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewIdentityOidcProviderTokenWithDefaults() *IdentityOidcProviderToken {
+	this := IdentityOidcProviderToken{}
+	return &this
+}
+
+// GetAccessToken returns the AccessToken field value
+func (o *IdentityOidcProviderToken) GetAccessToken() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.AccessToken
+}
+
+// GetAccessTokenOk returns a tuple with the AccessToken field value
+// and a boolean to check if the value has been set.
+func (o *IdentityOidcProviderToken) GetAccessTokenOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.AccessToken, true
+}
+
+// SetAccessToken sets field value
+func (o *IdentityOidcProviderToken) SetAccessToken(v string) {
+	o.AccessToken = v
+}
+
+// GetExpiresAt returns the ExpiresAt field value if set, zero value otherwise.
+func (o *IdentityOidcProviderToken) GetExpiresAt() time.Time {
+	if o == nil || o.ExpiresAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.ExpiresAt
+}
+
+// GetExpiresAtOk returns a tuple with the ExpiresAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *IdentityOidcProviderToken) GetExpiresAtOk() (*time.Time, bool) {
+	if o == nil || o.ExpiresAt == nil {
+		return nil, false
+	}
+	return o.ExpiresAt, true
+}
+
+// HasExpiresAt returns a boolean if a field has been set.
+func (o *IdentityOidcProviderToken) HasExpiresAt() bool {
+	if o != nil && o.ExpiresAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetExpiresAt gets a reference to the given time.Time and assigns it to the ExpiresAt field.
+func (o *IdentityOidcProviderToken) SetExpiresAt(v time.Time) {
+	o.ExpiresAt = &v
+}
+
+// GetProvider returns the Provider field value
+func (o *IdentityOidcProviderToken) GetProvider() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Provider
+}
+
+// GetProviderOk returns a tuple with the Provider field value
+// and a boolean to check if the value has been set.
+func (o *IdentityOidcProviderToken) GetProviderOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Provider, true
+}
+
+// SetProvider sets field value
+func (o *IdentityOidcProviderToken) SetProvider(v string) {
+	o.Provider = v
+}
+
+// GetSubject returns the Subject field value
+func (o *IdentityOidcProviderToken) GetSubject() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Subject
+}
+
+// GetSubjectOk returns a tuple with the Subject field value
+// and a boolean to check if the value has been set.
+func (o *IdentityOidcProviderToken) GetSubjectOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Subject, true
+}
+
+// SetSubject sets field value
+func (o *IdentityOidcProviderToken) SetSubject(v string) {
+	o.Subject = v
+}
+
+func (o IdentityOidcProviderToken) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["access_token"] = o.AccessToken
+	}
+	if o.ExpiresAt != nil {
+		toSerialize["expires_at"] = o.ExpiresAt
+	}
+	if true {
+		toSerialize["provider"] = o.Provider
+	}
+	if true {
+		toSerialize["subject"] = o.Subject
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableIdentityOidcProviderToken struct {
+	value *IdentityOidcProviderToken
+	isSet bool
+}
+
+func (v NullableIdentityOidcProviderToken) Get() *IdentityOidcProviderToken {
+	return v.value
+}
+
+func (v *NullableIdentityOidcProviderToken) Set(val *IdentityOidcProviderToken) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableIdentityOidcProviderToken) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableIdentityOidcProviderToken) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableIdentityOidcProviderToken(val *IdentityOidcProviderToken) *NullableIdentityOidcProviderToken {
+	return &NullableIdentityOidcProviderToken{value: val, isSet: true}
+}
+
+func (v NullableIdentityOidcProviderToken) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableIdentityOidcProviderToken) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
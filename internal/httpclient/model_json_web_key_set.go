@@ -0,0 +1,108 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+)
+
+// JsonWebKeySet struct for JsonWebKeySet
+type JsonWebKeySet struct {
+	// The public keys which are used to sign session tokens.
+	Keys []map[string]map[string]interface{} `json:"keys"`
+}
+
+// NewJsonWebKeySet instantiates a new JsonWebKeySet object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewJsonWebKeySet(keys []map[string]map[string]interface{}) *JsonWebKeySet {
+	this := JsonWebKeySet{}
+	this.Keys = keys
+	return &this
+}
+
+// NewJsonWebKeySetWithDefaults instantiates a new JsonWebKeySet object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewJsonWebKeySetWithDefaults() *JsonWebKeySet {
+	this := JsonWebKeySet{}
+	return &this
+}
+
+// GetKeys returns the Keys field value
+func (o *JsonWebKeySet) GetKeys() []map[string]map[string]interface{} {
+	if o == nil {
+		var ret []map[string]map[string]interface{}
+		return ret
+	}
+
+	return o.Keys
+}
+
+// GetKeysOk returns a tuple with the Keys field value
+// and a boolean to check if the value has been set.
+func (o *JsonWebKeySet) GetKeysOk() ([]map[string]map[string]interface{}, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return o.Keys, true
+}
+
+// SetKeys sets field value
+func (o *JsonWebKeySet) SetKeys(v []map[string]map[string]interface{}) {
+	o.Keys = v
+}
+
+func (o JsonWebKeySet) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["keys"] = o.Keys
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableJsonWebKeySet struct {
+	value *JsonWebKeySet
+	isSet bool
+}
+
+func (v NullableJsonWebKeySet) Get() *JsonWebKeySet {
+	return v.value
+}
+
+func (v *NullableJsonWebKeySet) Set(val *JsonWebKeySet) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableJsonWebKeySet) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableJsonWebKeySet) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableJsonWebKeySet(val *JsonWebKeySet) *NullableJsonWebKeySet {
+	return &NullableJsonWebKeySet{value: val, isSet: true}
+}
+
+func (v NullableJsonWebKeySet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableJsonWebKeySet) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
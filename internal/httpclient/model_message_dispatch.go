@@ -0,0 +1,354 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MessageDispatch MessageDispatch represents a single attempt of delivering a courier message.
+type MessageDispatch struct {
+	Channel CourierMessageDispatchChannel `json:"channel"`
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"created_at"`
+	// The error which occurred while delivering the message, if any
+	Error *string `json:"error,omitempty"`
+	// The ID of this message dispatch
+	Id string `json:"id"`
+	// How long the delivery attempt took in milliseconds
+	LatencyMs int64 `json:"latency_ms"`
+	// The ID of the message being dispatched
+	MessageId string `json:"message_id"`
+	// The response code returned by the upstream server, e.g. the SMTP reply code or the HTTP status code. It is zero if no response was received, for example because the upstream server could not be reached.
+	ResponseCode int64                        `json:"response_code"`
+	Status       CourierMessageDispatchStatus `json:"status"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewMessageDispatch instantiates a new MessageDispatch object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewMessageDispatch(channel CourierMessageDispatchChannel, createdAt time.Time, id string, latencyMs int64, messageId string, responseCode int64, status CourierMessageDispatchStatus, updatedAt time.Time) *MessageDispatch {
+	this := MessageDispatch{}
+	this.Channel = channel
+	this.CreatedAt = createdAt
+	this.Id = id
+	this.LatencyMs = latencyMs
+	this.MessageId = messageId
+	this.ResponseCode = responseCode
+	this.Status = status
+	this.UpdatedAt = updatedAt
+	return &this
+}
+
+// NewMessageDispatchWithDefaults instantiates a new MessageDispatch object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewMessageDispatchWithDefaults() *MessageDispatch {
+	this := MessageDispatch{}
+	return &this
+}
+
+// GetChannel returns the Channel field value
+func (o *MessageDispatch) GetChannel() CourierMessageDispatchChannel {
+	if o == nil {
+		var ret CourierMessageDispatchChannel
+		return ret
+	}
+
+	return o.Channel
+}
+
+// GetChannelOk returns a tuple with the Channel field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetChannelOk() (*CourierMessageDispatchChannel, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Channel, true
+}
+
+// SetChannel sets field value
+func (o *MessageDispatch) SetChannel(v CourierMessageDispatchChannel) {
+	o.Channel = v
+}
+
+// GetCreatedAt returns the CreatedAt field value
+func (o *MessageDispatch) GetCreatedAt() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.CreatedAt
+}
+
+// GetCreatedAtOk returns a tuple with the CreatedAt field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetCreatedAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.CreatedAt, true
+}
+
+// SetCreatedAt sets field value
+func (o *MessageDispatch) SetCreatedAt(v time.Time) {
+	o.CreatedAt = v
+}
+
+// GetError returns the Error field value if set, zero value otherwise.
+func (o *MessageDispatch) GetError() string {
+	if o == nil || o.Error == nil {
+		var ret string
+		return ret
+	}
+	return *o.Error
+}
+
+// GetErrorOk returns a tuple with the Error field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetErrorOk() (*string, bool) {
+	if o == nil || o.Error == nil {
+		return nil, false
+	}
+	return o.Error, true
+}
+
+// HasError returns a boolean if a field has been set.
+func (o *MessageDispatch) HasError() bool {
+	if o != nil && o.Error != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetError gets a reference to the given string and assigns it to the Error field.
+func (o *MessageDispatch) SetError(v string) {
+	o.Error = &v
+}
+
+// GetId returns the Id field value
+func (o *MessageDispatch) GetId() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Id
+}
+
+// GetIdOk returns a tuple with the Id field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Id, true
+}
+
+// SetId sets field value
+func (o *MessageDispatch) SetId(v string) {
+	o.Id = v
+}
+
+// GetLatencyMs returns the LatencyMs field value
+func (o *MessageDispatch) GetLatencyMs() int64 {
+	if o == nil {
+		var ret int64
+		return ret
+	}
+
+	return o.LatencyMs
+}
+
+// GetLatencyMsOk returns a tuple with the LatencyMs field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetLatencyMsOk() (*int64, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.LatencyMs, true
+}
+
+// SetLatencyMs sets field value
+func (o *MessageDispatch) SetLatencyMs(v int64) {
+	o.LatencyMs = v
+}
+
+// GetMessageId returns the MessageId field value
+func (o *MessageDispatch) GetMessageId() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.MessageId
+}
+
+// GetMessageIdOk returns a tuple with the MessageId field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetMessageIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.MessageId, true
+}
+
+// SetMessageId sets field value
+func (o *MessageDispatch) SetMessageId(v string) {
+	o.MessageId = v
+}
+
+// GetResponseCode returns the ResponseCode field value
+func (o *MessageDispatch) GetResponseCode() int64 {
+	if o == nil {
+		var ret int64
+		return ret
+	}
+
+	return o.ResponseCode
+}
+
+// GetResponseCodeOk returns a tuple with the ResponseCode field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetResponseCodeOk() (*int64, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.ResponseCode, true
+}
+
+// SetResponseCode sets field value
+func (o *MessageDispatch) SetResponseCode(v int64) {
+	o.ResponseCode = v
+}
+
+// GetStatus returns the Status field value
+func (o *MessageDispatch) GetStatus() CourierMessageDispatchStatus {
+	if o == nil {
+		var ret CourierMessageDispatchStatus
+		return ret
+	}
+
+	return o.Status
+}
+
+// GetStatusOk returns a tuple with the Status field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetStatusOk() (*CourierMessageDispatchStatus, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Status, true
+}
+
+// SetStatus sets field value
+func (o *MessageDispatch) SetStatus(v CourierMessageDispatchStatus) {
+	o.Status = v
+}
+
+// GetUpdatedAt returns the UpdatedAt field value
+func (o *MessageDispatch) GetUpdatedAt() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.UpdatedAt
+}
+
+// GetUpdatedAtOk returns a tuple with the UpdatedAt field value
+// and a boolean to check if the value has been set.
+func (o *MessageDispatch) GetUpdatedAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.UpdatedAt, true
+}
+
+// SetUpdatedAt sets field value
+func (o *MessageDispatch) SetUpdatedAt(v time.Time) {
+	o.UpdatedAt = v
+}
+
+func (o MessageDispatch) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["channel"] = o.Channel
+	}
+	if true {
+		toSerialize["created_at"] = o.CreatedAt
+	}
+	if o.Error != nil {
+		toSerialize["error"] = o.Error
+	}
+	if true {
+		toSerialize["id"] = o.Id
+	}
+	if true {
+		toSerialize["latency_ms"] = o.LatencyMs
+	}
+	if true {
+		toSerialize["message_id"] = o.MessageId
+	}
+	if true {
+		toSerialize["response_code"] = o.ResponseCode
+	}
+	if true {
+		toSerialize["status"] = o.Status
+	}
+	if true {
+		toSerialize["updated_at"] = o.UpdatedAt
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableMessageDispatch struct {
+	value *MessageDispatch
+	isSet bool
+}
+
+func (v NullableMessageDispatch) Get() *MessageDispatch {
+	return v.value
+}
+
+func (v *NullableMessageDispatch) Set(val *MessageDispatch) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableMessageDispatch) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableMessageDispatch) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableMessageDispatch(val *MessageDispatch) *NullableMessageDispatch {
+	return &NullableMessageDispatch{value: val, isSet: true}
+}
+
+func (v NullableMessageDispatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableMessageDispatch) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
@@ -13,11 +13,21 @@ package client
 
 import (
 	"encoding/json"
+	"time"
 )
 
-// SessionDevice struct for SessionDevice
+// SessionDevice Device corresponds to a device (IP address and user agent) a session was used from.
 type SessionDevice struct {
-	// UserAgent of this device
+	// First Seen At  When the session was first used from this device.
+	FirstSeenAt *time.Time `json:"first_seen_at,omitempty"`
+	Id          string     `json:"id"`
+	// The IP address the session was used from.
+	IpAddress *string `json:"ip_address,omitempty"`
+	// Last Seen At  When the session was last issued or refreshed on this device.
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	// The approximate geo location of the IP address, if it could be resolved.
+	Location *string `json:"location,omitempty"`
+	// The user agent of the client the session was used from.
 	UserAgent *string `json:"user_agent,omitempty"`
 }
 
@@ -25,8 +35,9 @@ type SessionDevice struct {
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewSessionDevice() *SessionDevice {
+func NewSessionDevice(id string) *SessionDevice {
 	this := SessionDevice{}
+	this.Id = id
 	return &this
 }
 
@@ -38,6 +49,158 @@ func NewSessionDeviceWithDefaults() *SessionDevice {
 	return &this
 }
 
+// GetFirstSeenAt returns the FirstSeenAt field value if set, zero value otherwise.
+func (o *SessionDevice) GetFirstSeenAt() time.Time {
+	if o == nil || o.FirstSeenAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.FirstSeenAt
+}
+
+// GetFirstSeenAtOk returns a tuple with the FirstSeenAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetFirstSeenAtOk() (*time.Time, bool) {
+	if o == nil || o.FirstSeenAt == nil {
+		return nil, false
+	}
+	return o.FirstSeenAt, true
+}
+
+// HasFirstSeenAt returns a boolean if a field has been set.
+func (o *SessionDevice) HasFirstSeenAt() bool {
+	if o != nil && o.FirstSeenAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetFirstSeenAt gets a reference to the given time.Time and assigns it to the FirstSeenAt field.
+func (o *SessionDevice) SetFirstSeenAt(v time.Time) {
+	o.FirstSeenAt = &v
+}
+
+// GetId returns the Id field value
+func (o *SessionDevice) GetId() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Id
+}
+
+// GetIdOk returns a tuple with the Id field value
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Id, true
+}
+
+// SetId sets field value
+func (o *SessionDevice) SetId(v string) {
+	o.Id = v
+}
+
+// GetIpAddress returns the IpAddress field value if set, zero value otherwise.
+func (o *SessionDevice) GetIpAddress() string {
+	if o == nil || o.IpAddress == nil {
+		var ret string
+		return ret
+	}
+	return *o.IpAddress
+}
+
+// GetIpAddressOk returns a tuple with the IpAddress field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetIpAddressOk() (*string, bool) {
+	if o == nil || o.IpAddress == nil {
+		return nil, false
+	}
+	return o.IpAddress, true
+}
+
+// HasIpAddress returns a boolean if a field has been set.
+func (o *SessionDevice) HasIpAddress() bool {
+	if o != nil && o.IpAddress != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetIpAddress gets a reference to the given string and assigns it to the IpAddress field.
+func (o *SessionDevice) SetIpAddress(v string) {
+	o.IpAddress = &v
+}
+
+// GetLastSeenAt returns the LastSeenAt field value if set, zero value otherwise.
+func (o *SessionDevice) GetLastSeenAt() time.Time {
+	if o == nil || o.LastSeenAt == nil {
+		var ret time.Time
+		return ret
+	}
+	return *o.LastSeenAt
+}
+
+// GetLastSeenAtOk returns a tuple with the LastSeenAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetLastSeenAtOk() (*time.Time, bool) {
+	if o == nil || o.LastSeenAt == nil {
+		return nil, false
+	}
+	return o.LastSeenAt, true
+}
+
+// HasLastSeenAt returns a boolean if a field has been set.
+func (o *SessionDevice) HasLastSeenAt() bool {
+	if o != nil && o.LastSeenAt != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLastSeenAt gets a reference to the given time.Time and assigns it to the LastSeenAt field.
+func (o *SessionDevice) SetLastSeenAt(v time.Time) {
+	o.LastSeenAt = &v
+}
+
+// GetLocation returns the Location field value if set, zero value otherwise.
+func (o *SessionDevice) GetLocation() string {
+	if o == nil || o.Location == nil {
+		var ret string
+		return ret
+	}
+	return *o.Location
+}
+
+// GetLocationOk returns a tuple with the Location field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *SessionDevice) GetLocationOk() (*string, bool) {
+	if o == nil || o.Location == nil {
+		return nil, false
+	}
+	return o.Location, true
+}
+
+// HasLocation returns a boolean if a field has been set.
+func (o *SessionDevice) HasLocation() bool {
+	if o != nil && o.Location != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLocation gets a reference to the given string and assigns it to the Location field.
+func (o *SessionDevice) SetLocation(v string) {
+	o.Location = &v
+}
+
 // GetUserAgent returns the UserAgent field value if set, zero value otherwise.
 func (o *SessionDevice) GetUserAgent() string {
 	if o == nil || o.UserAgent == nil {
@@ -72,6 +235,21 @@ func (o *SessionDevice) SetUserAgent(v string) {
 
 func (o SessionDevice) MarshalJSON() ([]byte, error) {
 	toSerialize := map[string]interface{}{}
+	if o.FirstSeenAt != nil {
+		toSerialize["first_seen_at"] = o.FirstSeenAt
+	}
+	if true {
+		toSerialize["id"] = o.Id
+	}
+	if o.IpAddress != nil {
+		toSerialize["ip_address"] = o.IpAddress
+	}
+	if o.LastSeenAt != nil {
+		toSerialize["last_seen_at"] = o.LastSeenAt
+	}
+	if o.Location != nil {
+		toSerialize["location"] = o.Location
+	}
 	if o.UserAgent != nil {
 		toSerialize["user_agent"] = o.UserAgent
 	}
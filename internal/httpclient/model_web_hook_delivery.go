@@ -0,0 +1,384 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebHookDelivery Delivery is an asynchronous web hook call which is stored in the outbox until it was delivered.
+type WebHookDelivery struct {
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	// The error of the last attempt, if any
+	LastError *string `json:"last_error,omitempty"`
+	// The HTTP status code returned by the last attempt. It is zero if no response was received.
+	LastResponseCode int64 `json:"last_response_code"`
+	// The HTTP method of the web hook
+	Method string `json:"method"`
+	// When the next attempt is due
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// How often the delivery was attempted
+	SendCount int64                 `json:"send_count"`
+	Status    WebHookDeliveryStatus `json:"status"`
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"updated_at"`
+	// The URL of the web hook
+	Url string `json:"url"`
+}
+
+// NewWebHookDelivery instantiates a new WebHookDelivery object
+// This constructor will assign default values to properties that have it defined,
+// and makes sure properties required by API are set, but the set of arguments
+// will change when the set of required properties is changed
+func NewWebHookDelivery(createdAt time.Time, id string, lastResponseCode int64, method string, nextAttemptAt time.Time, sendCount int64, status WebHookDeliveryStatus, updatedAt time.Time, url string) *WebHookDelivery {
+	this := WebHookDelivery{}
+	this.CreatedAt = createdAt
+	this.Id = id
+	this.LastResponseCode = lastResponseCode
+	this.Method = method
+	this.NextAttemptAt = nextAttemptAt
+	this.SendCount = sendCount
+	this.Status = status
+	this.UpdatedAt = updatedAt
+	this.Url = url
+	return &this
+}
+
+// NewWebHookDeliveryWithDefaults instantiates a new WebHookDelivery object
+// This constructor will only assign default values to properties that have it defined,
+// but it doesn't guarantee that properties required by API are set
+func NewWebHookDeliveryWithDefaults() *WebHookDelivery {
+	this := WebHookDelivery{}
+	return &this
+}
+
+// GetCreatedAt returns the CreatedAt field value
+func (o *WebHookDelivery) GetCreatedAt() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.CreatedAt
+}
+
+// GetCreatedAtOk returns a tuple with the CreatedAt field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetCreatedAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.CreatedAt, true
+}
+
+// SetCreatedAt sets field value
+func (o *WebHookDelivery) SetCreatedAt(v time.Time) {
+	o.CreatedAt = v
+}
+
+// GetId returns the Id field value
+func (o *WebHookDelivery) GetId() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Id
+}
+
+// GetIdOk returns a tuple with the Id field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Id, true
+}
+
+// SetId sets field value
+func (o *WebHookDelivery) SetId(v string) {
+	o.Id = v
+}
+
+// GetLastError returns the LastError field value if set, zero value otherwise.
+func (o *WebHookDelivery) GetLastError() string {
+	if o == nil || o.LastError == nil {
+		var ret string
+		return ret
+	}
+	return *o.LastError
+}
+
+// GetLastErrorOk returns a tuple with the LastError field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetLastErrorOk() (*string, bool) {
+	if o == nil || o.LastError == nil {
+		return nil, false
+	}
+	return o.LastError, true
+}
+
+// HasLastError returns a boolean if a field has been set.
+func (o *WebHookDelivery) HasLastError() bool {
+	if o != nil && o.LastError != nil {
+		return true
+	}
+
+	return false
+}
+
+// SetLastError gets a reference to the given string and assigns it to the LastError field.
+func (o *WebHookDelivery) SetLastError(v string) {
+	o.LastError = &v
+}
+
+// GetLastResponseCode returns the LastResponseCode field value
+func (o *WebHookDelivery) GetLastResponseCode() int64 {
+	if o == nil {
+		var ret int64
+		return ret
+	}
+
+	return o.LastResponseCode
+}
+
+// GetLastResponseCodeOk returns a tuple with the LastResponseCode field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetLastResponseCodeOk() (*int64, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.LastResponseCode, true
+}
+
+// SetLastResponseCode sets field value
+func (o *WebHookDelivery) SetLastResponseCode(v int64) {
+	o.LastResponseCode = v
+}
+
+// GetMethod returns the Method field value
+func (o *WebHookDelivery) GetMethod() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Method
+}
+
+// GetMethodOk returns a tuple with the Method field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetMethodOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Method, true
+}
+
+// SetMethod sets field value
+func (o *WebHookDelivery) SetMethod(v string) {
+	o.Method = v
+}
+
+// GetNextAttemptAt returns the NextAttemptAt field value
+func (o *WebHookDelivery) GetNextAttemptAt() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.NextAttemptAt
+}
+
+// GetNextAttemptAtOk returns a tuple with the NextAttemptAt field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetNextAttemptAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.NextAttemptAt, true
+}
+
+// SetNextAttemptAt sets field value
+func (o *WebHookDelivery) SetNextAttemptAt(v time.Time) {
+	o.NextAttemptAt = v
+}
+
+// GetSendCount returns the SendCount field value
+func (o *WebHookDelivery) GetSendCount() int64 {
+	if o == nil {
+		var ret int64
+		return ret
+	}
+
+	return o.SendCount
+}
+
+// GetSendCountOk returns a tuple with the SendCount field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetSendCountOk() (*int64, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.SendCount, true
+}
+
+// SetSendCount sets field value
+func (o *WebHookDelivery) SetSendCount(v int64) {
+	o.SendCount = v
+}
+
+// GetStatus returns the Status field value
+func (o *WebHookDelivery) GetStatus() WebHookDeliveryStatus {
+	if o == nil {
+		var ret WebHookDeliveryStatus
+		return ret
+	}
+
+	return o.Status
+}
+
+// GetStatusOk returns a tuple with the Status field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetStatusOk() (*WebHookDeliveryStatus, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Status, true
+}
+
+// SetStatus sets field value
+func (o *WebHookDelivery) SetStatus(v WebHookDeliveryStatus) {
+	o.Status = v
+}
+
+// GetUpdatedAt returns the UpdatedAt field value
+func (o *WebHookDelivery) GetUpdatedAt() time.Time {
+	if o == nil {
+		var ret time.Time
+		return ret
+	}
+
+	return o.UpdatedAt
+}
+
+// GetUpdatedAtOk returns a tuple with the UpdatedAt field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetUpdatedAtOk() (*time.Time, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.UpdatedAt, true
+}
+
+// SetUpdatedAt sets field value
+func (o *WebHookDelivery) SetUpdatedAt(v time.Time) {
+	o.UpdatedAt = v
+}
+
+// GetUrl returns the Url field value
+func (o *WebHookDelivery) GetUrl() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.Url
+}
+
+// GetUrlOk returns a tuple with the Url field value
+// and a boolean to check if the value has been set.
+func (o *WebHookDelivery) GetUrlOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.Url, true
+}
+
+// SetUrl sets field value
+func (o *WebHookDelivery) SetUrl(v string) {
+	o.Url = v
+}
+
+func (o WebHookDelivery) MarshalJSON() ([]byte, error) {
+	toSerialize := map[string]interface{}{}
+	if true {
+		toSerialize["created_at"] = o.CreatedAt
+	}
+	if true {
+		toSerialize["id"] = o.Id
+	}
+	if o.LastError != nil {
+		toSerialize["last_error"] = o.LastError
+	}
+	if true {
+		toSerialize["last_response_code"] = o.LastResponseCode
+	}
+	if true {
+		toSerialize["method"] = o.Method
+	}
+	if true {
+		toSerialize["next_attempt_at"] = o.NextAttemptAt
+	}
+	if true {
+		toSerialize["send_count"] = o.SendCount
+	}
+	if true {
+		toSerialize["status"] = o.Status
+	}
+	if true {
+		toSerialize["updated_at"] = o.UpdatedAt
+	}
+	if true {
+		toSerialize["url"] = o.Url
+	}
+	return json.Marshal(toSerialize)
+}
+
+type NullableWebHookDelivery struct {
+	value *WebHookDelivery
+	isSet bool
+}
+
+func (v NullableWebHookDelivery) Get() *WebHookDelivery {
+	return v.value
+}
+
+func (v *NullableWebHookDelivery) Set(val *WebHookDelivery) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableWebHookDelivery) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableWebHookDelivery) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableWebHookDelivery(val *WebHookDelivery) *NullableWebHookDelivery {
+	return &NullableWebHookDelivery{value: val, isSet: true}
+}
+
+func (v NullableWebHookDelivery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableWebHookDelivery) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
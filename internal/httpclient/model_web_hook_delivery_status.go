@@ -0,0 +1,85 @@
+/*
+ * Ory Kratos API
+ *
+ * Documentation for all public and administrative Ory Kratos APIs. Public and administrative APIs are exposed on different ports. Public APIs can face the public internet without any protection while administrative APIs should never be exposed without prior authorization. To protect the administative API port you should use something like Nginx, Ory Oathkeeper, or any other technology capable of authorizing incoming requests.
+ *
+ * API version: 1.0.0
+ * Contact: hi@ory.sh
+ */
+
+// Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebHookDeliveryStatus A Web Hook Delivery's Status  It is `queued` until the web hook was delivered (`delivered`) or all retries were exhausted (`abandoned`).
+type WebHookDeliveryStatus string
+
+// List of webHookDeliveryStatus
+const (
+	WEBHOOKDELIVERYSTATUS_QUEUED    WebHookDeliveryStatus = "queued"
+	WEBHOOKDELIVERYSTATUS_DELIVERED WebHookDeliveryStatus = "delivered"
+	WEBHOOKDELIVERYSTATUS_ABANDONED WebHookDeliveryStatus = "abandoned"
+)
+
+func (v *WebHookDeliveryStatus) UnmarshalJSON(src []byte) error {
+	var value string
+	err := json.Unmarshal(src, &value)
+	if err != nil {
+		return err
+	}
+	enumTypeValue := WebHookDeliveryStatus(value)
+	for _, existing := range []WebHookDeliveryStatus{"queued", "delivered", "abandoned"} {
+		if existing == enumTypeValue {
+			*v = enumTypeValue
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%+v is not a valid WebHookDeliveryStatus", value)
+}
+
+// Ptr returns reference to webHookDeliveryStatus value
+func (v WebHookDeliveryStatus) Ptr() *WebHookDeliveryStatus {
+	return &v
+}
+
+type NullableWebHookDeliveryStatus struct {
+	value *WebHookDeliveryStatus
+	isSet bool
+}
+
+func (v NullableWebHookDeliveryStatus) Get() *WebHookDeliveryStatus {
+	return v.value
+}
+
+func (v *NullableWebHookDeliveryStatus) Set(val *WebHookDeliveryStatus) {
+	v.value = val
+	v.isSet = true
+}
+
+func (v NullableWebHookDeliveryStatus) IsSet() bool {
+	return v.isSet
+}
+
+func (v *NullableWebHookDeliveryStatus) Unset() {
+	v.value = nil
+	v.isSet = false
+}
+
+func NewNullableWebHookDeliveryStatus(val *WebHookDeliveryStatus) *NullableWebHookDeliveryStatus {
+	return &NullableWebHookDeliveryStatus{value: val, isSet: true}
+}
+
+func (v NullableWebHookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func (v *NullableWebHookDeliveryStatus) UnmarshalJSON(src []byte) error {
+	v.isSet = true
+	return json.Unmarshal(src, &v.value)
+}
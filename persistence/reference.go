@@ -2,6 +2,8 @@ package persistence
 
 import (
 	"context"
+	"time"
+
 	"github.com/ory/kratos/selfservice/strategy/code"
 
 	"github.com/ory/x/networkx"
@@ -23,6 +25,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 type Provider interface {
@@ -35,6 +38,7 @@ type Persister interface {
 	identity.PrivilegedPool
 	registration.FlowPersister
 	login.FlowPersister
+	login.AttemptPersister
 	settings.FlowPersister
 	courier.Persister
 	session.Persister
@@ -44,6 +48,11 @@ type Persister interface {
 	link.RecoveryTokenPersister
 	link.VerificationTokenPersister
 	code.CodePersister
+	webhook.Persister
+
+	// CleanupDatabase deletes expired flows, sessions, tokens, codes, continuity containers and delivered or abandoned web hooks which are older
+	// than olderThan in batches of batchSize, waiting between batches. It stops once the context is done.
+	CleanupDatabase(ctx context.Context, wait, olderThan time.Duration, batchSize int) error
 
 	Close(context.Context) error
 	Ping() error
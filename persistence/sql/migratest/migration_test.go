@@ -130,7 +130,7 @@ func TestMigrations(t *testing.T) {
 				)
 
 				t.Run("case=identity", func(t *testing.T) {
-					ids, err := d.PrivilegedIdentityPool().ListIdentities(context.Background(), 0, 1000)
+					ids, err := d.PrivilegedIdentityPool().ListIdentities(context.Background(), identity.ListIdentityParameters{PerPage: 1000})
 					require.NoError(t, err)
 					require.NotEmpty(t, ids)
 
@@ -0,0 +1,2 @@
+INSERT INTO courier_message_dispatches (id, message_id, status, channel, response_code, error, latency_ms, nid, created_at, updated_at) VALUES
+('0d41e52e-2b1a-4f6c-b3b6-0e2b6f2d7a51', 'b821adf0-a067-4b3c-9f90-cac496d02a92', 'failed', 'smtp', 550, '550 5.1.1 mailbox unavailable', 42, '884f556e-eb3a-4b9f-bee3-11345642c6c0', '2013-10-07 08:23:19', '2013-10-07 08:23:19');
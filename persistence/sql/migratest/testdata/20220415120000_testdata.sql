@@ -0,0 +1,2 @@
+INSERT INTO selfservice_login_attempts (id, identifier, credentials_type, address, nid, created_at, updated_at) VALUES
+('5b1d3c8e-5f0c-4f4e-9d0a-2c1b7f6e9a41', 'foo@ory.sh', 'password', '192.0.2.1', '884f556e-eb3a-4b9f-bee3-11345642c6c0', '2013-10-07 08:23:19', '2013-10-07 08:23:19');
@@ -0,0 +1,2 @@
+INSERT INTO session_devices (id, nid, session_id, ip_address, user_agent, location, created_at, updated_at) VALUES
+('6a3dfe8d-8e4e-4b8c-9f2d-1e5b3c7a9d02', '884f556e-eb3a-4b9f-bee3-11345642c6c0', 'dcde5aaa-f789-4d3d-ae1f-76da8d57e67c', '192.0.2.1', 'Mozilla/5.0 (X11; Linux x86_64; rv:99.0) Gecko/20100101 Firefox/99.0', 'Munich, DE', '2013-10-07 08:23:19', '2013-10-07 08:23:19');
@@ -0,0 +1,2 @@
+INSERT INTO webhook_deliveries (id, nid, status, method, url, config, payload, send_count, last_response_code, last_error, next_attempt_at, created_at, updated_at) VALUES
+('2f5c8a3e-7b1d-4e6a-9c0f-3d8e1a5b7c24', '884f556e-eb3a-4b9f-bee3-11345642c6c0', 'abandoned', 'POST', 'https://crm.example.org/hooks/registration', '{"url":"https://crm.example.org/hooks/registration","method":"POST","body":"file://./body.jsonnet","response":{"ignore":true}}', '{"flow":{"id":"a5b3b5ec-1ea5-4da3-8e6c-5b2cbc2d9b0a"}}', 11, 503, 'web hook failed with status code 503', '2013-10-07 08:23:19', '2013-10-07 08:23:19', '2013-10-07 08:23:19');
@@ -0,0 +1,15 @@
+CREATE TABLE "courier_message_dispatches" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"message_id" UUID NOT NULL,
+"status" VARCHAR (7) NOT NULL,
+"channel" VARCHAR (32) NOT NULL,
+"response_code" int NOT NULL,
+"error" text,
+"latency_ms" int NOT NULL,
+"nid" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "courier_message_dispatches_message_id_fk" FOREIGN KEY ("message_id") REFERENCES "courier_messages" ("id") ON DELETE cascade,
+CONSTRAINT "courier_message_dispatches_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1,15 @@
+CREATE TABLE `courier_message_dispatches` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`message_id` char(36) NOT NULL,
+`status` VARCHAR (7) NOT NULL,
+`channel` VARCHAR (32) NOT NULL,
+`response_code` INTEGER NOT NULL,
+`error` TEXT,
+`latency_ms` INTEGER NOT NULL,
+`nid` char(36) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`message_id`) REFERENCES `courier_messages` (`id`) ON DELETE cascade,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
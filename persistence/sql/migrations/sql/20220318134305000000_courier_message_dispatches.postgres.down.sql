@@ -0,0 +1 @@
+DROP TABLE "courier_message_dispatches";
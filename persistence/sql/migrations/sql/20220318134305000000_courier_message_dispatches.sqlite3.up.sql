@@ -0,0 +1,14 @@
+CREATE TABLE "courier_message_dispatches" (
+"id" TEXT PRIMARY KEY,
+"message_id" char(36) NOT NULL,
+"status" TEXT NOT NULL,
+"channel" TEXT NOT NULL,
+"response_code" INTEGER NOT NULL,
+"error" TEXT,
+"latency_ms" INTEGER NOT NULL,
+"nid" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (message_id) REFERENCES courier_messages (id) ON DELETE cascade,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
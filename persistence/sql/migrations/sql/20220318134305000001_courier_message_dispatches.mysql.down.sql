@@ -0,0 +1 @@
+DROP INDEX `courier_message_dispatches_message_id_idx` ON `courier_message_dispatches`;
@@ -0,0 +1 @@
+CREATE INDEX "courier_message_dispatches_message_id_idx" ON "courier_message_dispatches" (message_id, created_at);
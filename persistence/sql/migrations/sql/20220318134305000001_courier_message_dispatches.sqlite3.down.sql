@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "courier_message_dispatches_message_id_idx";
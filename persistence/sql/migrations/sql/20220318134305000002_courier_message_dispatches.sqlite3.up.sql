@@ -0,0 +1 @@
+ALTER TABLE "courier_messages" ADD COLUMN "send_count" INTEGER NOT NULL DEFAULT 0;
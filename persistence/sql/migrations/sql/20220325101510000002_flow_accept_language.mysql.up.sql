@@ -0,0 +1 @@
+ALTER TABLE `selfservice_registration_flows` ADD COLUMN `accept_language` TEXT NULL;
@@ -0,0 +1 @@
+ALTER TABLE "selfservice_registration_flows" DROP COLUMN "accept_language";
@@ -0,0 +1 @@
+ALTER TABLE `selfservice_recovery_flows` DROP COLUMN `accept_language`;
@@ -0,0 +1 @@
+ALTER TABLE `selfservice_verification_flows` ADD COLUMN `accept_language` TEXT NULL;
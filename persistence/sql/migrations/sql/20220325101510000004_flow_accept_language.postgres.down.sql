@@ -0,0 +1 @@
+ALTER TABLE "selfservice_verification_flows" DROP COLUMN "accept_language";
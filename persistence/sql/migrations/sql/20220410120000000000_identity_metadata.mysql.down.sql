@@ -0,0 +1 @@
+ALTER TABLE `identities` DROP COLUMN `metadata_public`;
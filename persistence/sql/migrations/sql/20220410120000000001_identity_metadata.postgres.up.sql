@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "metadata_admin" JSONB NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE "selfservice_login_attempts" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"identifier" VARCHAR (255) NOT NULL,
+"credentials_type" VARCHAR (32) NOT NULL,
+"address" VARCHAR (64) NOT NULL,
+"nid" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "selfservice_login_attempts_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+CREATE TABLE "selfservice_login_attempts" (
+"id" TEXT PRIMARY KEY,
+"identifier" TEXT NOT NULL,
+"credentials_type" TEXT NOT NULL,
+"address" TEXT NOT NULL,
+"nid" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
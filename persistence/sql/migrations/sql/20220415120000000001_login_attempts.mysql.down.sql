@@ -0,0 +1 @@
+DROP INDEX `selfservice_login_attempts_identifier_idx` ON `selfservice_login_attempts`;
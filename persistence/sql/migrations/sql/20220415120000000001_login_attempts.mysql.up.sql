@@ -0,0 +1 @@
+CREATE INDEX `selfservice_login_attempts_identifier_idx` ON `selfservice_login_attempts` (`nid`, `identifier`, `created_at`);
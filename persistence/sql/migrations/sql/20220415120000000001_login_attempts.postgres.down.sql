@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "selfservice_login_attempts_identifier_idx";
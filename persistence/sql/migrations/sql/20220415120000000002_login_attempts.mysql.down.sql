@@ -0,0 +1 @@
+DROP INDEX `selfservice_login_attempts_address_idx` ON `selfservice_login_attempts`;
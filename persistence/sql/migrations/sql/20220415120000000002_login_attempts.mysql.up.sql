@@ -0,0 +1 @@
+CREATE INDEX `selfservice_login_attempts_address_idx` ON `selfservice_login_attempts` (`nid`, `address`, `created_at`);
@@ -0,0 +1,13 @@
+CREATE TABLE "session_devices" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"ip_address" VARCHAR (50) DEFAULT '',
+"user_agent" VARCHAR (512) DEFAULT '',
+"location" VARCHAR (512) DEFAULT '',
+"nid" UUID NOT NULL,
+"session_id" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "session_devices_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE,
+CONSTRAINT "session_devices_session_id_fk" FOREIGN KEY ("session_id") REFERENCES "sessions" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE `session_devices` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`ip_address` VARCHAR (50) DEFAULT '',
+`user_agent` VARCHAR (512) DEFAULT '',
+`location` VARCHAR (512) DEFAULT '',
+`nid` char(36) NOT NULL,
+`session_id` char(36) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE,
+FOREIGN KEY (`session_id`) REFERENCES `sessions` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
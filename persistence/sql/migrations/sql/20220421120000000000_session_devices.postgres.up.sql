@@ -0,0 +1,13 @@
+CREATE TABLE "session_devices" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"ip_address" VARCHAR (50) DEFAULT '',
+"user_agent" VARCHAR (512) DEFAULT '',
+"location" VARCHAR (512) DEFAULT '',
+"nid" UUID NOT NULL,
+"session_id" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE,
+FOREIGN KEY ("session_id") REFERENCES "sessions" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
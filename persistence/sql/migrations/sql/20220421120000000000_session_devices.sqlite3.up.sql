@@ -0,0 +1,12 @@
+CREATE TABLE "session_devices" (
+"id" TEXT PRIMARY KEY,
+"ip_address" TEXT DEFAULT '',
+"user_agent" TEXT DEFAULT '',
+"location" TEXT DEFAULT '',
+"nid" char(36) NOT NULL,
+"session_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE,
+FOREIGN KEY (session_id) REFERENCES sessions (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
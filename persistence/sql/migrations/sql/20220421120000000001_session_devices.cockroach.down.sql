@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "session_devices_session_id_nid_idx";
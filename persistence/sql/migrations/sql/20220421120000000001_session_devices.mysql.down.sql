@@ -0,0 +1 @@
+DROP INDEX `session_devices_session_id_nid_idx` ON `session_devices`;
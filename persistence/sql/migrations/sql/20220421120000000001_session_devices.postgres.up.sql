@@ -0,0 +1 @@
+CREATE INDEX "session_devices_session_id_nid_idx" ON "session_devices" (session_id, nid);
@@ -0,0 +1,17 @@
+CREATE TABLE "webhook_deliveries" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"status" VARCHAR (16) NOT NULL,
+"method" VARCHAR (16) NOT NULL,
+"url" VARCHAR (2048) NOT NULL,
+"config" text NOT NULL,
+"payload" text NOT NULL,
+"send_count" int NOT NULL DEFAULT 0,
+"last_response_code" int NOT NULL DEFAULT 0,
+"last_error" text,
+"next_attempt_at" timestamp NOT NULL,
+"nid" UUID NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL,
+CONSTRAINT "webhook_deliveries_nid_fk" FOREIGN KEY ("nid") REFERENCES "networks" ("id") ON UPDATE RESTRICT ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+CREATE TABLE `webhook_deliveries` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`status` VARCHAR (16) NOT NULL,
+`method` VARCHAR (16) NOT NULL,
+`url` VARCHAR (2048) NOT NULL,
+`config` TEXT NOT NULL,
+`payload` MEDIUMTEXT NOT NULL,
+`send_count` INTEGER NOT NULL DEFAULT 0,
+`last_response_code` INTEGER NOT NULL DEFAULT 0,
+`last_error` TEXT,
+`next_attempt_at` DATETIME NOT NULL,
+`nid` char(36) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL,
+FOREIGN KEY (`nid`) REFERENCES `networks` (`id`) ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,16 @@
+CREATE TABLE "webhook_deliveries" (
+"id" TEXT PRIMARY KEY,
+"status" TEXT NOT NULL,
+"method" TEXT NOT NULL,
+"url" TEXT NOT NULL,
+"config" TEXT NOT NULL,
+"payload" TEXT NOT NULL,
+"send_count" INTEGER NOT NULL DEFAULT 0,
+"last_response_code" INTEGER NOT NULL DEFAULT 0,
+"last_error" TEXT,
+"next_attempt_at" DATETIME NOT NULL,
+"nid" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+FOREIGN KEY (nid) REFERENCES networks (id) ON UPDATE RESTRICT ON DELETE CASCADE
+);
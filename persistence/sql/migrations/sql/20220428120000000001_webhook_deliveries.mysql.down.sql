@@ -0,0 +1 @@
+DROP INDEX `webhook_deliveries_nid_status_next_attempt_at_idx` ON `webhook_deliveries`;
@@ -206,3 +206,19 @@ func (p *Persister) delete(ctx context.Context, v interface{}, id uuid.UUID) err
 	}
 	return nil
 }
+
+// deleteExpired deletes at most limit rows of the table which match the where clause and returns
+// the number of deleted rows.
+func (p *Persister) deleteExpired(ctx context.Context, table, where string, limit int, args ...interface{}) (int, error) {
+	// The nested sub-select is required because MySQL does not support LIMIT in IN sub-queries
+	// and does not allow selecting from the table which is being deleted from.
+	/* #nosec G201 TableName and where clause are static */
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id IN (SELECT id FROM (SELECT id FROM %s WHERE %s LIMIT %d) AS expired)",
+		table, table, where, limit,
+	), args...).ExecWithCount()
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
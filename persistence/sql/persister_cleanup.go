@@ -0,0 +1,77 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/selfservice/strategy/code"
+)
+
+type cleanupTask struct {
+	name   string
+	delete func(ctx context.Context, expiresAt time.Time, limit int) (int, error)
+	// keep is the minimum time records are kept for, regardless of how old they have to be to be deleted.
+	// Records which are still in use, for example to enforce a limit, must not be deleted too early.
+	keep time.Duration
+}
+
+func (p *Persister) CleanupDatabase(ctx context.Context, wait, olderThan time.Duration, batchSize int) error {
+	if batchSize < 1 {
+		return errors.Errorf("the cleanup batch size must be at least 1 but got %d", batchSize)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(-olderThan)
+	p.r.Logger().WithField("expires_at", expiresAt).Info("Cleaning up expired records.")
+
+	// Tokens are deleted before the flows they belong to.
+	for _, task := range []cleanupTask{
+		{name: "sessions", delete: p.DeleteExpiredSessions},
+		{name: "continuity containers", delete: p.DeleteExpiredContinuitySessions},
+		{name: "login flows", delete: p.DeleteExpiredLoginFlows},
+		{name: "registration flows", delete: p.DeleteExpiredRegistrationFlows},
+		{name: "settings flows", delete: p.DeleteExpiredSettingsFlows},
+		{name: "recovery tokens", delete: p.DeleteExpiredRecoveryTokens},
+		{name: "recovery flows", delete: p.DeleteExpiredRecoveryFlows},
+		{name: "verification tokens", delete: p.DeleteExpiredVerificationTokens},
+		{name: "verification flows", delete: p.DeleteExpiredVerificationFlows},
+		{name: "codes", delete: p.DeleteExpiredCodes, keep: code.SendLimitWindow},
+		{name: "web hook deliveries", delete: p.DeleteExpiredDeliveries},
+		{name: "login attempts", delete: p.DeleteExpiredLoginAttempts, keep: p.r.Config(ctx).SelfServiceFlowLoginLockout().Window},
+	} {
+		taskExpiresAt := expiresAt
+		if keepUntil := now.Add(-task.keep); keepUntil.Before(taskExpiresAt) {
+			taskExpiresAt = keepUntil
+		}
+
+		var total int
+		for {
+			count, err := task.delete(ctx, taskExpiresAt, batchSize)
+			if err != nil {
+				return err
+			}
+
+			total += count
+			if count < batchSize {
+				break
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				p.r.Logger().WithField("deleted", total).Infof("Stopped cleaning up expired %s.", task.name)
+				return errors.WithStack(ctx.Err())
+			}
+		}
+
+		p.r.Logger().WithField("deleted", total).Infof("Cleaned up expired %s.", task.name)
+		if err := ctx.Err(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	p.r.Logger().Info("Successfully cleaned up expired records.")
+	return nil
+}
@@ -0,0 +1,224 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/strategy/code"
+	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func testCleanupDatabase(ctx context.Context, conf *config.Config, p persistence.Persister) func(t *testing.T) {
+	return func(t *testing.T) {
+		_, p := testhelpers.NewNetwork(t, ctx, p)
+		_, other := testhelpers.NewNetwork(t, ctx, p)
+
+		expired := time.Now().UTC().Add(-2 * time.Hour)
+		active := time.Now().UTC().Add(time.Hour)
+
+		newLoginFlow := func(t *testing.T, p persistence.Persister, expiresAt time.Time) *login.Flow {
+			var f login.Flow
+			require.NoError(t, faker.FakeData(&f))
+			f.ExpiresAt = expiresAt
+			require.NoError(t, p.CreateLoginFlow(ctx, &f))
+			return &f
+		}
+
+		newRegistrationFlow := func(t *testing.T, expiresAt time.Time) *registration.Flow {
+			var f registration.Flow
+			require.NoError(t, faker.FakeData(&f))
+			f.ExpiresAt = expiresAt
+			require.NoError(t, p.CreateRegistrationFlow(ctx, &f))
+			return &f
+		}
+
+		newVerificationFlow := func(t *testing.T, expiresAt time.Time) *verification.Flow {
+			var f verification.Flow
+			require.NoError(t, faker.FakeData(&f))
+			f.ExpiresAt = expiresAt
+			require.NoError(t, p.CreateVerificationFlow(ctx, &f))
+			return &f
+		}
+
+		newRecoveryFlow := func(t *testing.T, expiresAt time.Time) *recovery.Flow {
+			var f recovery.Flow
+			require.NoError(t, faker.FakeData(&f))
+			f.ExpiresAt = expiresAt
+			require.NoError(t, p.CreateRecoveryFlow(ctx, &f))
+			return &f
+		}
+
+		i := identity.NewIdentity("")
+		i.RecoveryAddresses = []identity.RecoveryAddress{{Value: "cleanup@ory.sh", Via: identity.RecoveryAddressTypeEmail}}
+		require.NoError(t, p.CreateIdentity(ctx, i))
+
+		newSession := func(t *testing.T, expiresAt time.Time, isActive bool) *session.Session {
+			s, err := session.NewActiveSession(i, conf, time.Now().UTC(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+			require.NoError(t, err)
+			s.ExpiresAt = expiresAt
+			s.Active = isActive
+			require.NoError(t, p.UpsertSession(ctx, s))
+			return s
+		}
+
+		newContainer := func(t *testing.T, expiresAt time.Time) *continuity.Container {
+			c := &continuity.Container{Name: "cleanup", IdentityID: x.PointToUUID(i.ID), ExpiresAt: expiresAt}
+			require.NoError(t, p.SaveContinuitySession(ctx, c))
+			return c
+		}
+
+		newCode := func(t *testing.T, expiresAt time.Time) *code.Code {
+			var c code.Code
+			require.NoError(t, faker.FakeData(&c))
+			c.FlowId = x.NewUUID()
+			c.ExpiresAt = expiresAt
+			require.NoError(t, p.CreateCode(ctx, &c))
+			return &c
+		}
+
+		newRecoveryToken := func(t *testing.T, expiresAt time.Time) *link.RecoveryToken {
+			f := newRecoveryFlow(t, active)
+			token := &link.RecoveryToken{
+				Token:           x.NewUUID().String(),
+				FlowID:          uuid.NullUUID{UUID: f.ID, Valid: true},
+				RecoveryAddress: &i.RecoveryAddresses[0],
+				ExpiresAt:       expiresAt,
+				IssuedAt:        time.Now(),
+				IdentityID:      i.ID,
+			}
+			require.NoError(t, p.CreateRecoveryToken(ctx, token))
+			return token
+		}
+
+		newLoginAttempt := func(t *testing.T, createdAt time.Time) *login.Attempt {
+			a := &login.Attempt{ID: x.NewUUID(), Identifier: x.NewUUID().String(), CredentialsType: identity.CredentialsTypePassword, Address: "192.0.2.1", CreatedAt: createdAt}
+			require.NoError(t, p.CreateLoginAttempt(ctx, a))
+			return a
+		}
+
+		countLoginAttempts := func(t *testing.T, a *login.Attempt) int {
+			count, _, err := p.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: a.Identifier, Since: a.CreatedAt.Add(-time.Minute)})
+			require.NoError(t, err)
+			return count
+		}
+
+		expiredLogins := []*login.Flow{newLoginFlow(t, p, expired), newLoginFlow(t, p, expired), newLoginFlow(t, p, expired)}
+		activeLogin := newLoginFlow(t, p, active)
+		otherLogin := newLoginFlow(t, other, expired)
+		expiredRegistration, activeRegistration := newRegistrationFlow(t, expired), newRegistrationFlow(t, active)
+		expiredVerification, activeVerification := newVerificationFlow(t, expired), newVerificationFlow(t, active)
+		expiredRecovery, activeRecovery := newRecoveryFlow(t, expired), newRecoveryFlow(t, active)
+		expiredSession, revokedSession, activeSession := newSession(t, expired, true), newSession(t, active, false), newSession(t, active, true)
+		expiredContainer, activeContainer := newContainer(t, expired), newContainer(t, active)
+		expiredCode, recentCode, activeCode := newCode(t, expired.Add(-code.SendLimitWindow)), newCode(t, expired), newCode(t, active)
+		expiredToken, activeToken := newRecoveryToken(t, expired), newRecoveryToken(t, active)
+		expiredAttempt, activeAttempt := newLoginAttempt(t, expired), newLoginAttempt(t, time.Now().UTC())
+
+		t.Run("case=rejects invalid batch size", func(t *testing.T) {
+			require.Error(t, p.CleanupDatabase(ctx, 0, time.Hour, 0))
+		})
+
+		t.Run("case=stops once the context is done", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			require.ErrorIs(t, p.CleanupDatabase(ctx, time.Minute, time.Hour, 1), context.Canceled)
+		})
+
+		t.Run("case=deletes expired records older than the given duration", func(t *testing.T) {
+			require.NoError(t, p.CleanupDatabase(ctx, 0, time.Hour, 1))
+
+			for _, f := range expiredLogins {
+				_, err := p.GetLoginFlow(ctx, f.ID)
+				assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			}
+			_, err := p.GetRegistrationFlow(ctx, expiredRegistration.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.GetVerificationFlow(ctx, expiredVerification.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.GetRecoveryFlow(ctx, expiredRecovery.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.GetSession(ctx, expiredSession.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.GetContinuitySession(ctx, expiredContainer.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.UseRecoveryToken(ctx, expiredToken.Token)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+			actualCode, err := p.FindActiveCode(ctx, expiredCode.FlowId, expiredCode.ExpiresAt.Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Nil(t, actualCode)
+			assert.Zero(t, countLoginAttempts(t, expiredAttempt))
+
+			_, err = p.GetLoginFlow(ctx, activeLogin.ID)
+			assert.NoError(t, err)
+			_, err = p.GetRegistrationFlow(ctx, activeRegistration.ID)
+			assert.NoError(t, err)
+			_, err = p.GetVerificationFlow(ctx, activeVerification.ID)
+			assert.NoError(t, err)
+			_, err = p.GetRecoveryFlow(ctx, activeRecovery.ID)
+			assert.NoError(t, err)
+			_, err = p.GetSession(ctx, activeSession.ID)
+			assert.NoError(t, err)
+			_, err = p.GetContinuitySession(ctx, activeContainer.ID)
+			assert.NoError(t, err)
+			_, err = p.UseRecoveryToken(ctx, activeToken.Token)
+			assert.NoError(t, err)
+			actualCode, err = p.FindActiveCode(ctx, activeCode.FlowId, time.Now())
+			require.NoError(t, err)
+			assert.NotNil(t, actualCode)
+			assert.Equal(t, 1, countLoginAttempts(t, activeAttempt))
+
+			t.Run("keeps codes counting towards the send limit", func(t *testing.T) {
+				actual, err := p.FindActiveCode(ctx, recentCode.FlowId, expired.Add(-time.Hour))
+				require.NoError(t, err)
+				assert.NotNil(t, actual)
+			})
+
+			t.Run("keeps recently revoked sessions", func(t *testing.T) {
+				_, err := p.GetSession(ctx, revokedSession.ID)
+				assert.NoError(t, err)
+			})
+
+			t.Run("keeps records of other networks", func(t *testing.T) {
+				_, err := other.GetLoginFlow(ctx, otherLogin.ID)
+				assert.NoError(t, err)
+			})
+		})
+
+		t.Run("case=keeps login attempts within the lockout window", func(t *testing.T) {
+			conf.MustSet(config.ViperKeySelfServiceLoginLockoutWindow, "3h")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeySelfServiceLoginLockoutWindow, "1h")
+			})
+
+			attempt := newLoginAttempt(t, expired)
+			require.NoError(t, p.CleanupDatabase(ctx, 0, time.Hour, 100))
+			assert.Equal(t, 1, countLoginAttempts(t, attempt))
+		})
+
+		t.Run("case=deletes revoked sessions", func(t *testing.T) {
+			require.NoError(t, p.CleanupDatabase(ctx, 0, -2*time.Hour, 100))
+
+			_, err := p.GetSession(ctx, revokedSession.ID)
+			assert.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+	}
+}
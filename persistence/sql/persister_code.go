@@ -12,12 +12,23 @@ import (
 var _ code.CodePersister = new(Persister)
 
 func (p *Persister) CreateCode(ctx context.Context, code *code.Code) error {
-	return p.GetConnection(ctx).Create(code)
+	c := code.Code
+	code.Code = p.hmacValue(ctx, c)
+	if err := p.GetConnection(ctx).Create(code); err != nil {
+		return err
+	}
+
+	code.Code = c
+	return nil
+}
+
+func (p *Persister) CompareCode(ctx context.Context, code *code.Code, value string) bool {
+	return p.hmacConstantCompare(ctx, value, code.Code)
 }
 
 func (p *Persister) FindActiveCode(ctx context.Context, flowId uuid.UUID, expiresAfter time.Time) (*code.Code, error) {
 	var r []code.Code
-	if err := p.GetConnection(ctx).Where("flow_id = ? AND expires_at > ?", flowId, expiresAfter).All(&r); err != nil {
+	if err := p.GetConnection(ctx).Where("flow_id = ? AND expires_at > ?", flowId, expiresAfter).Order("created_at DESC").All(&r); err != nil {
 		return nil, sqlcon.HandleError(err)
 	}
 	if len(r) > 0 {
@@ -26,7 +37,50 @@ func (p *Persister) FindActiveCode(ctx context.Context, flowId uuid.UUID, expire
 	return nil, nil
 }
 
+func (p *Persister) IncrementCodeAttempts(ctx context.Context, id uuid.UUID, max int) (bool, error) {
+	// The condition is part of the update so that concurrent attempts can not exceed the maximum.
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET attempts = attempts + 1 WHERE id = ? AND attempts < ?", new(code.Code).TableName(ctx)),
+		id, max).ExecWithCount()
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return count > 0, nil
+}
+
+func (p *Persister) DeleteCode(ctx context.Context, id uuid.UUID) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"DELETE FROM %s WHERE id=?", new(code.Code).TableName(ctx)), id).Exec())
+}
+
 func (p *Persister) DeleteCodes(ctx context.Context, identifier string) error {
 	return p.GetConnection(ctx).RawQuery(fmt.Sprintf(
 		"DELETE FROM %s WHERE identifier=?", new(code.Code).TableName(ctx)), identifier).Exec()
 }
+
+func (p *Persister) ExpireCodes(ctx context.Context, identifier string, expiresAt time.Time) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf(
+		"UPDATE %s SET expires_at=? WHERE identifier=? AND expires_at > ?", new(code.Code).TableName(ctx)),
+		expiresAt, identifier, expiresAt).Exec())
+}
+
+func (p *Persister) ListCodesByIdentifier(ctx context.Context, identifier string, createdAfter time.Time) ([]code.Code, error) {
+	var r []code.Code
+	if err := p.GetConnection(ctx).Where("identifier = ? AND created_at > ?", identifier, createdAfter).Order("created_at ASC").All(&r); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return r, nil
+}
+
+func (p *Persister) CountCodesByFlow(ctx context.Context, flowID uuid.UUID) (int, error) {
+	count, err := p.GetConnection(ctx).Where("flow_id = ?", flowID).Count(new(code.Code))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return count, nil
+}
+
+// DeleteExpiredCodes deletes expired codes of all networks because codes are not scoped to a network.
+func (p *Persister) DeleteExpiredCodes(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(code.Code).TableName(ctx), "expires_at < ?", limit, expiresAt)
+}
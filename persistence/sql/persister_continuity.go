@@ -3,6 +3,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -41,3 +42,8 @@ func (p *Persister) DeleteContinuitySession(ctx context.Context, id uuid.UUID) e
 	}
 	return nil
 }
+
+func (p *Persister) DeleteExpiredContinuitySessions(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(continuity.Container).TableName(ctx), "nid = ? AND expires_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
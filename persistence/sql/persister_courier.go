@@ -105,3 +105,117 @@ func (p *Persister) SetMessageStatus(ctx context.Context, id uuid.UUID, ms couri
 
 	return nil
 }
+
+func (p *Persister) ListMessages(ctx context.Context, filter courier.ListMessagesFilter, page, itemsPerPage int) ([]courier.Message, int64, error) {
+	q := p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid))
+
+	if filter.Status != 0 {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.Type != 0 {
+		q = q.Where("type = ?", filter.Type)
+	}
+	if filter.Recipient != "" {
+		q = q.Where("recipient = ?", filter.Recipient)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		q = q.Where("created_at >= ?", filter.CreatedAfter.UTC())
+	}
+	if !filter.CreatedBefore.IsZero() {
+		q = q.Where("created_at < ?", filter.CreatedBefore.UTC())
+	}
+
+	count, err := q.Count(new(courier.Message))
+	if err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	messages := make([]courier.Message, 0)
+	if err := q.Order("created_at DESC").Paginate(page, itemsPerPage).All(&messages); err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	return messages, int64(count), nil
+}
+
+func (p *Persister) FetchMessage(ctx context.Context, id uuid.UUID) (*courier.Message, error) {
+	var m courier.Message
+	if err := p.GetConnection(ctx).
+		Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		First(&m); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	m.Dispatches = make([]courier.MessageDispatch, 0)
+	if err := p.GetConnection(ctx).
+		Where("message_id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		Order("created_at ASC").
+		All(&m.Dispatches); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &m, nil
+}
+
+func (p *Persister) CancelMessage(ctx context.Context, id uuid.UUID) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ? WHERE id = ? AND nid = ? AND status = ?",
+			corp.ContextualizeTableName(ctx, "courier_messages"),
+		),
+		courier.MessageStatusCancelled,
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+		courier.MessageStatusQueued,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		if _, err := p.FetchMessage(ctx, id); err != nil {
+			return err
+		}
+		return errors.WithStack(courier.ErrMessageNotCancellable)
+	}
+
+	return nil
+}
+
+func (p *Persister) RecordDispatch(ctx context.Context, dispatch *courier.MessageDispatch) error {
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		exists, err := tx.Where("id = ? AND nid = ?", dispatch.MessageID, corp.ContextualizeNID(ctx, p.nid)).Exists(new(courier.Message))
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		if !exists {
+			return errors.WithStack(sqlcon.ErrNoRows)
+		}
+
+		dispatch.NID = corp.ContextualizeNID(ctx, p.nid)
+		return sqlcon.HandleError(tx.Create(dispatch))
+	})
+}
+
+func (p *Persister) IncrementMessageSendCount(ctx context.Context, id uuid.UUID) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET send_count = send_count + 1 WHERE id = ? AND nid = ?",
+			corp.ContextualizeTableName(ctx, "courier_messages"),
+		),
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
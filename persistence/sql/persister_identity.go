@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/errorsx"
@@ -221,8 +223,13 @@ func (p *Persister) findRecoveryAddresses(ctx context.Context, i *identity.Ident
 	return nil
 }
 
-func (p *Persister) CountIdentities(ctx context.Context) (int64, error) {
-	count, err := p.c.WithContext(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).Count(new(identity.Identity))
+func (p *Persister) CountIdentities(ctx context.Context, params identity.ListIdentityParameters) (int64, error) {
+	q, err := p.identityListQuery(ctx, p.c.WithContext(ctx), params)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := q.Count(new(identity.Identity))
 	if err != nil {
 		return 0, sqlcon.HandleError(err)
 	}
@@ -230,6 +237,35 @@ func (p *Persister) CountIdentities(ctx context.Context) (int64, error) {
 }
 
 func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) error {
+	if err := p.prepareIdentityForCreation(ctx, i); err != nil {
+		return err
+	}
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		return p.createIdentity(ctx, tx, i)
+	})
+}
+
+// CreateIdentities creates all identities in a single transaction. If one of the identities can not be created,
+// none of them are.
+func (p *Persister) CreateIdentities(ctx context.Context, identities ...*identity.Identity) error {
+	for _, i := range identities {
+		if err := p.prepareIdentityForCreation(ctx, i); err != nil {
+			return err
+		}
+	}
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		for _, i := range identities {
+			if err := p.createIdentity(ctx, tx, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (p *Persister) prepareIdentityForCreation(ctx context.Context, i *identity.Identity) error {
 	i.NID = corp.ContextualizeNID(ctx, p.nid)
 
 	if i.SchemaID == "" {
@@ -250,35 +286,167 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 		return err
 	}
 
-	if err := p.validateIdentity(ctx, i); err != nil {
-		return err
+	return p.validateIdentity(ctx, i)
+}
+
+func (p *Persister) createIdentity(ctx context.Context, tx *pop.Connection, i *identity.Identity) error {
+	if err := tx.Create(i); err != nil {
+		return sqlcon.HandleError(err)
 	}
 
-	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
-		if err := tx.Create(i); err != nil {
-			return sqlcon.HandleError(err)
+	if err := p.createVerifiableAddresses(ctx, i); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if err := p.createRecoveryAddresses(ctx, i); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	return p.createIdentityCredentials(ctx, i)
+}
+
+// identityListQuery applies the filters of the given parameters to a query on the identities table.
+func (p *Persister) identityListQuery(ctx context.Context, c *pop.Connection, params identity.ListIdentityParameters) (*pop.Query, error) {
+	nid := corp.ContextualizeNID(ctx, p.nid)
+	q := c.Where("nid = ?", nid)
+
+	if len(params.CredentialsIdentifier) > 0 || len(params.CredentialsIdentifierPrefix) > 0 {
+		// Password identifiers are stored lower-cased, which is why both variants are looked up.
+		identifierCondition, args := "ici.identifier IN (?, ?)", []interface{}{params.CredentialsIdentifier, strings.ToLower(strings.TrimSpace(params.CredentialsIdentifier))}
+		if len(params.CredentialsIdentifierPrefix) > 0 {
+			identifierCondition, args = "(ici.identifier LIKE ? ESCAPE '!' OR ici.identifier LIKE ? ESCAPE '!')", []interface{}{
+				likePrefix(params.CredentialsIdentifierPrefix), likePrefix(strings.ToLower(params.CredentialsIdentifierPrefix))}
 		}
 
-		if err := p.createVerifiableAddresses(ctx, i); err != nil {
-			return sqlcon.HandleError(err)
+		// #nosec G201 TableName is static
+		q = q.Where(fmt.Sprintf(`id IN (SELECT ic.identity_id FROM %s ic
+         INNER JOIN %s ici ON ic.id = ici.identity_credential_id
+WHERE ic.nid = ? AND ici.nid = ? AND %s)`,
+			corp.ContextualizeTableName(ctx, "identity_credentials"),
+			corp.ContextualizeTableName(ctx, "identity_credential_identifiers"),
+			identifierCondition,
+		), append([]interface{}{nid, nid}, args...)...)
+	}
+
+	if len(params.VerifiableAddress) > 0 || params.VerifiableAddressVerified != nil {
+		conditions, args := []string{"nid = ?"}, []interface{}{nid}
+		if len(params.VerifiableAddress) > 0 {
+			conditions, args = append(conditions, "value = ?"), append(args, params.VerifiableAddress)
+		}
+		if params.VerifiableAddressVerified != nil {
+			conditions, args = append(conditions, "verified = ?"), append(args, *params.VerifiableAddressVerified)
 		}
 
-		if err := p.createRecoveryAddresses(ctx, i); err != nil {
-			return sqlcon.HandleError(err)
+		// #nosec G201 TableName is static
+		q = q.Where(fmt.Sprintf(`id IN (SELECT identity_id FROM %s WHERE %s)`,
+			new(identity.VerifiableAddress).TableName(ctx),
+			strings.Join(conditions, " AND "),
+		), args...)
+	}
+
+	if len(params.SchemaID) > 0 {
+		q = q.Where("schema_id = ?", params.SchemaID)
+	}
+
+	if len(params.State) > 0 {
+		q = q.Where("state = ?", params.State)
+	}
+
+	if !params.CreatedAfter.IsZero() {
+		q = q.Where("created_at >= ?", params.CreatedAfter.UTC())
+	}
+
+	if !params.CreatedBefore.IsZero() {
+		q = q.Where("created_at < ?", params.CreatedBefore.UTC())
+	}
+
+	if len(params.TraitPath) > 0 {
+		segments := strings.Split(params.TraitPath, ".")
+		switch c.Dialect.Name() {
+		case "postgres", "cockroach":
+			q = q.Where("traits #>> ?::text[] = ?", "{"+strings.Join(segments, ",")+"}", params.TraitValue)
+		case "mysql":
+			q = q.Where("JSON_UNQUOTE(JSON_EXTRACT(traits, ?)) = ?", mysqlJSONPath(segments), params.TraitValue)
+		default:
+			// SQLite is not compiled with JSON support, which is why the traits are matched here instead.
+			ids, err := p.findIdentityIDsByTrait(ctx, c, params)
+			if err != nil {
+				return nil, err
+			}
+			if len(ids) == 0 {
+				return q.Where("1 = 0"), nil
+			}
+			q = q.Where("id IN (?)", ids...)
 		}
+	}
 
-		return p.createIdentityCredentials(ctx, i)
-	})
+	return q, nil
+}
+
+func (p *Persister) findIdentityIDsByTrait(ctx context.Context, c *pop.Connection, params identity.ListIdentityParameters) ([]interface{}, error) {
+	path, value := params.TraitPath, params.TraitValue
+	params.TraitPath, params.TraitValue = "", ""
+
+	q, err := p.identityListQuery(ctx, c, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var is []identity.Identity
+	if err := q.Select("id", "traits").All(&is); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	ids := make([]interface{}, 0, len(is))
+	for _, i := range is {
+		if match := gjson.GetBytes(i.Traits, path); match.Exists() && match.String() == value {
+			ids = append(ids, i.ID)
+		}
+	}
+	return ids, nil
+}
+
+// mysqlJSONPath builds a MySQL JSON path from the segments of a trait path. Keys are quoted because MySQL only
+// accepts unquoted keys which are valid ECMAScript identifiers, which rules out keys such as `first-name`. Numeric
+// segments address array elements, as they do for PostgreSQL and gjson.
+func mysqlJSONPath(segments []string) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			b.WriteString("[" + segment + "]")
+			continue
+		}
+		b.WriteString(`."` + segment + `"`)
+	}
+	return b.String()
 }
 
-func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]identity.Identity, error) {
+// likePrefix escapes the LIKE wildcards in prefix and appends a trailing wildcard.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix) + "%"
+}
+
+func (p *Persister) ListIdentities(ctx context.Context, params identity.ListIdentityParameters) ([]identity.Identity, error) {
 	is := make([]identity.Identity, 0)
 
-	/* #nosec G201 TableName is static */
-	if err := sqlcon.HandleError(p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).
-		EagerPreload("VerifiableAddresses", "RecoveryAddresses").
-		Paginate(page, perPage).Order("id DESC").
-		All(&is)); err != nil {
+	q, err := p.identityListQuery(ctx, p.GetConnection(ctx), params)
+	if err != nil {
+		return nil, err
+	}
+
+	q = q.EagerPreload("VerifiableAddresses", "RecoveryAddresses").Order("id DESC")
+	if params.PageToken.Valid {
+		// Keyset pagination does not need to skip rows and thus stays fast on large tables.
+		if params.PageToken.UUID != uuid.Nil {
+			q = q.Where("id < ?", params.PageToken.UUID)
+		}
+		q = q.Limit(x.MaxItemsPerPage(params.PerPage))
+	} else {
+		q = q.Paginate(params.Page, params.PerPage)
+	}
+
+	if err := sqlcon.HandleError(q.All(&is)); err != nil {
 		return nil, err
 	}
 
@@ -373,6 +541,24 @@ func (p *Persister) GetIdentity(ctx context.Context, id uuid.UUID) (*identity.Id
 	return &i, nil
 }
 
+func (p *Persister) LockIdentity(ctx context.Context, id uuid.UUID, fn func(ctx context.Context) error) error {
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		// #nosec G201
+		query := fmt.Sprintf("SELECT id FROM %s WHERE id = ? AND nid = ?", corp.ContextualizeTableName(ctx, "identities"))
+		// SQLite does not support row locks, but pop never runs two transactions on SQLite at the same time.
+		if tx.Dialect.Name() != "sqlite3" {
+			query += " FOR UPDATE"
+		}
+
+		var locked identity.Identity
+		if err := tx.RawQuery(query, id, corp.ContextualizeNID(ctx, p.nid)).First(&locked); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return fn(ctx)
+	})
+}
+
 func (p *Persister) GetIdentityConfidential(ctx context.Context, id uuid.UUID) (*identity.Identity, error) {
 	var i identity.Identity
 
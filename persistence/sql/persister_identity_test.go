@@ -0,0 +1,23 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLJSONPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"email":             `$."email"`,
+		"name.first":        `$."name"."first"`,
+		"first-name":        `$."first-name"`,
+		"name.first-name":   `$."name"."first-name"`,
+		"emails.0.value":    `$."emails"[0]."value"`,
+		"snake_case.nested": `$."snake_case"."nested"`,
+	} {
+		t.Run("path="+path, func(t *testing.T) {
+			assert.Equal(t, expected, mysqlJSONPath(strings.Split(path, ".")))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"context"
+	"time"
 
 	"github.com/ory/kratos/corp"
 
@@ -51,3 +52,70 @@ func (p *Persister) ForceLoginFlow(ctx context.Context, id uuid.UUID) error {
 		return tx.Save(lr, "nid")
 	})
 }
+
+var _ login.AttemptPersister = new(Persister)
+
+func (p *Persister) CreateLoginAttempt(ctx context.Context, a *login.Attempt) error {
+	a.NID = corp.ContextualizeNID(ctx, p.nid)
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(a))
+}
+
+func (p *Persister) CountLoginAttempts(ctx context.Context, filter login.AttemptFilter) (int, time.Time, error) {
+	q := p.GetConnection(ctx).Where("nid = ? AND created_at > ?", corp.ContextualizeNID(ctx, p.nid), filter.Since)
+	if filter.Identifier != "" {
+		q = q.Where("identifier = ?", filter.Identifier)
+	}
+	if filter.CredentialsType != "" {
+		q = q.Where("credentials_type = ?", filter.CredentialsType)
+	}
+	if filter.Address != "" {
+		q = q.Where("address = ?", filter.Address)
+	}
+
+	count, err := q.Count(new(login.Attempt))
+	if err != nil {
+		return 0, time.Time{}, sqlcon.HandleError(err)
+	} else if count == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	var latest login.Attempt
+	if err := q.Order("created_at DESC").First(&latest); err != nil {
+		return 0, time.Time{}, sqlcon.HandleError(err)
+	}
+
+	return count, latest.CreatedAt, nil
+}
+
+func (p *Persister) DeleteLoginAttempts(ctx context.Context, identifiers ...string) error {
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(identifiers))
+	for k, identifier := range identifiers {
+		args[k] = identifier
+	}
+
+	return sqlcon.HandleError(p.GetConnection(ctx).
+		Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).
+		Where("identifier IN (?)", args...).
+		Delete(new(login.Attempt)))
+}
+
+func (p *Persister) DeleteLoginAttempt(ctx context.Context, id uuid.UUID) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).
+		Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		Delete(new(login.Attempt)))
+}
+
+// DeleteExpiredLoginAttempts deletes at most limit login attempts which were made before the given time.
+func (p *Persister) DeleteExpiredLoginAttempts(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(login.Attempt).TableName(ctx), "nid = ? AND created_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
+
+func (p *Persister) DeleteExpiredLoginFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(login.Flow).TableName(ctx), "nid = ? AND expires_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
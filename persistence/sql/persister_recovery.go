@@ -93,3 +93,13 @@ func (p *Persister) DeleteRecoveryToken(ctx context.Context, token string) error
 	/* #nosec G201 TableName is static */
 	return p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE token=? AND nid = ?", new(link.RecoveryToken).TableName(ctx)), token, corp.ContextualizeNID(ctx, p.nid)).Exec()
 }
+
+func (p *Persister) DeleteExpiredRecoveryFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(recovery.Flow).TableName(ctx), "nid = ? AND expires_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
+
+func (p *Persister) DeleteExpiredRecoveryTokens(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(link.RecoveryToken).TableName(ctx), "nid = ? AND (expires_at < ? OR used_at < ?)", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt, expiresAt)
+}
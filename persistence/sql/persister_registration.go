@@ -2,6 +2,7 @@ package sql
 
 import (
 	"context"
+	"time"
 
 	"github.com/ory/kratos/corp"
 
@@ -34,3 +35,8 @@ func (p *Persister) GetRegistrationFlow(ctx context.Context, id uuid.UUID) (*reg
 
 	return &r, nil
 }
+
+func (p *Persister) DeleteExpiredRegistrationFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(registration.Flow).TableName(ctx), "nid = ? AND expires_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
@@ -3,7 +3,10 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gobuffalo/pop/v6"
 
@@ -15,6 +18,7 @@ import (
 
 	"github.com/ory/x/sqlcon"
 
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/session"
 )
 
@@ -35,6 +39,9 @@ func (p *Persister) GetSession(ctx context.Context, sid uuid.UUID) (*session.Ses
 	}
 
 	s.Identity = i
+	if err := p.injectSessionDevices(ctx, &s); err != nil {
+		return nil, err
+	}
 	return &s, nil
 }
 
@@ -63,7 +70,37 @@ func (p *Persister) ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, a
 
 			s.Identity = i
 		}
-		return nil
+		return p.injectSessionDevices(ctx, s...)
+	}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ListSessions retrieves the sessions of all identities matching the given parameters from the store.
+func (p *Persister) ListSessions(ctx context.Context, params session.ListSessionsParameters) ([]*session.Session, error) {
+	s := make([]*session.Session, 0)
+
+	if err := p.Transaction(ctx, func(ctx context.Context, c *pop.Connection) error {
+		q, err := p.sessionListQuery(ctx, c, params)
+		if err != nil {
+			return err
+		}
+
+		if err := q.Order("authenticated_at DESC, id DESC").Paginate(params.Page, params.PerPage).All(&s); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		for _, s := range s {
+			i, err := p.GetIdentity(ctx, s.IdentityID)
+			if err != nil {
+				return err
+			}
+
+			s.Identity = i
+		}
+		return p.injectSessionDevices(ctx, s...)
 	}); err != nil {
 		return nil, err
 	}
@@ -71,6 +108,64 @@ func (p *Persister) ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, a
 	return s, nil
 }
 
+// CountSessions counts the sessions matching the given parameters.
+func (p *Persister) CountSessions(ctx context.Context, params session.ListSessionsParameters) (int64, error) {
+	q, err := p.sessionListQuery(ctx, p.GetConnection(ctx), params)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := q.Count(new(session.Session))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+	return int64(count), nil
+}
+
+func (p *Persister) sessionListQuery(ctx context.Context, c *pop.Connection, params session.ListSessionsParameters) (*pop.Query, error) {
+	q := c.Where("nid = ?", corp.ContextualizeNID(ctx, p.nid))
+
+	if params.Active != nil {
+		// Expired sessions keep their active flag, which is why the expiry decides as well.
+		if *params.Active {
+			q = q.Where("active = ? AND expires_at > ?", true, time.Now().UTC())
+		} else {
+			q = q.Where("(active = ? OR expires_at <= ?)", false, time.Now().UTC())
+		}
+	}
+
+	if len(params.AAL) > 0 {
+		q = q.Where("aal = ?", params.AAL)
+	}
+
+	if len(params.AuthenticationMethod) > 0 {
+		method, err := json.Marshal(map[string]identity.CredentialsType{"method": params.AuthenticationMethod})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		switch c.Dialect.Name() {
+		case "postgres", "cockroach":
+			q = q.Where("authentication_methods @> ?::jsonb", "["+string(method)+"]")
+		case "mysql":
+			q = q.Where("JSON_CONTAINS(authentication_methods, ?)", "["+string(method)+"]")
+		default:
+			// SQLite stores the authentication methods as compact JSON text, which is why a substring match suffices.
+			q = q.Where("authentication_methods LIKE ? ESCAPE '!'", "%"+likePrefix(strings.Trim(string(method), "{}")))
+		}
+	}
+
+	if !params.AuthenticatedAfter.IsZero() {
+		q = q.Where("authenticated_at >= ?", params.AuthenticatedAfter.UTC())
+	}
+
+	if !params.AuthenticatedBefore.IsZero() {
+		q = q.Where("authenticated_at < ?", params.AuthenticatedBefore.UTC())
+	}
+
+	return q, nil
+}
+
 func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) error {
 	s.NID = corp.ContextualizeNID(ctx, p.nid)
 
@@ -85,6 +180,63 @@ func (p *Persister) UpsertSession(ctx context.Context, s *session.Session) error
 	return p.GetConnection(ctx).Update(s)
 }
 
+// UpsertDevice inserts or updates a device of a session.
+func (p *Persister) UpsertDevice(ctx context.Context, d *session.Device) error {
+	d.NID = corp.ContextualizeNID(ctx, p.nid)
+
+	// Pop only manages fields named CreatedAt and UpdatedAt, which is why the timestamps are set here.
+	d.LastSeenAt = time.Now().UTC()
+	if d.ID == uuid.Nil {
+		d.FirstSeenAt = d.LastSeenAt
+		return sqlcon.HandleError(p.GetConnection(ctx).Create(d))
+	}
+
+	// The first seen time is written as well, because the row might be reused for another device, which pop's
+	// Update does not allow.
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf("UPDATE %s SET ip_address = ?, user_agent = ?, location = ?, created_at = ?, updated_at = ? WHERE id = ? AND nid = ?", d.TableName(ctx)),
+		d.IPAddress, d.UserAgent, d.Location, d.FirstSeenAt, d.LastSeenAt, d.ID, d.NID,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	} else if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+	return nil
+}
+
+// injectSessionDevices loads the devices of the given sessions, most recently seen first.
+func (p *Persister) injectSessionDevices(ctx context.Context, ss ...*session.Session) error {
+	if len(ss) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(ss))
+	for k, s := range ss {
+		ids[k] = s.ID
+	}
+
+	var devices []session.Device
+	if err := p.GetConnection(ctx).
+		Where("nid = ?", corp.ContextualizeNID(ctx, p.nid)).
+		Where("session_id IN (?)", ids...).
+		Order("updated_at DESC").
+		All(&devices); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	for _, s := range ss {
+		s.Devices = make([]session.Device, 0)
+		for _, d := range devices {
+			if d.SessionID == s.ID {
+				s.Devices = append(s.Devices, d)
+			}
+		}
+	}
+	return nil
+}
+
 func (p *Persister) DeleteSession(ctx context.Context, sid uuid.UUID) error {
 	return p.delete(ctx, new(session.Session), sid)
 }
@@ -123,6 +275,9 @@ func (p *Persister) GetSessionByToken(ctx context.Context, token string) (*sessi
 		return nil, err
 	}
 	s.Identity = i
+	if err := p.injectSessionDevices(ctx, &s); err != nil {
+		return nil, err
+	}
 	return &s, nil
 }
 
@@ -196,3 +351,8 @@ func (p *Persister) RevokeSessionsIdentityExcept(ctx context.Context, iID, sID u
 	}
 	return count, nil
 }
+
+func (p *Persister) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(session.Session).TableName(ctx), "nid = ? AND (expires_at < ? OR (active = ? AND updated_at < ?))", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt, false, expiresAt)
+}
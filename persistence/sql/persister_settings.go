@@ -2,6 +2,7 @@ package sql
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -42,3 +43,8 @@ func (p *Persister) UpdateSettingsFlow(ctx context.Context, r *settings.Flow) er
 	cp.NID = corp.ContextualizeNID(ctx, p.nid)
 	return p.update(ctx, cp)
 }
+
+func (p *Persister) DeleteExpiredSettingsFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(settings.Flow).TableName(ctx), "nid = ? AND expires_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), expiresAt)
+}
@@ -44,6 +44,7 @@ import (
 	code "github.com/ory/kratos/selfservice/strategy/code/test"
 	link "github.com/ory/kratos/selfservice/strategy/link/test"
 	session "github.com/ory/kratos/session/test"
+	webhook "github.com/ory/kratos/webhook/test"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlcon/dockertest"
@@ -264,6 +265,14 @@ func TestPersister(t *testing.T) {
 				pop.SetLogger(pl(t))
 				code.TestCodePersister(ctx, p)(t)
 			})
+			t.Run("contract=webhook.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				webhook.TestPersister(ctx, p)(t)
+			})
+			t.Run("case=cleanup database", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				testCleanupDatabase(ctx, conf, p)(t)
+			})
 		})
 	}
 }
@@ -2,18 +2,61 @@ package sql
 
 import (
 	"context"
+	"strings"
 
 	"github.com/ory/x/popx"
+	"github.com/ory/x/sqlcon"
 
 	"github.com/gobuffalo/pop/v6"
+
+	"github.com/ory/kratos/x"
 )
 
 func WithTransaction(ctx context.Context, tx *pop.Connection) context.Context {
 	return popx.WithTransaction(ctx, tx)
 }
 
+// Transaction runs the callback in a transaction, or in the transaction of the context if there is one. The functions
+// passed to x.OnCommit by the callback run once the outermost transaction was committed.
 func (p *Persister) Transaction(ctx context.Context, callback func(ctx context.Context, connection *pop.Connection) error) error {
-	return popx.Transaction(ctx, p.c.WithContext(ctx), callback)
+	if p.GetConnection(ctx).TX != nil {
+		return popx.Transaction(ctx, p.c.WithContext(ctx), callback)
+	}
+
+	var commit func()
+	if err := popx.Transaction(ctx, p.c.WithContext(ctx), func(ctx context.Context, connection *pop.Connection) error {
+		// CockroachDB retries the callback, which is why every attempt collects its own functions.
+		ctx, commit = x.WithCommitHooks(ctx)
+		return callback(ctx, connection)
+	}); err != nil {
+		return err
+	}
+
+	commit()
+	return nil
+}
+
+// savepoint runs the callback in a savepoint if the context carries a transaction. If the callback fails, only the
+// savepoint is rolled back, so that the error does not abort the transaction.
+func (p *Persister) savepoint(ctx context.Context, callback func(ctx context.Context) error) error {
+	c := p.GetConnection(ctx)
+	if c.TX == nil {
+		return callback(ctx)
+	}
+
+	name := "kratos_" + strings.ReplaceAll(x.NewUUID().String(), "-", "")
+	if err := c.RawQuery("SAVEPOINT " + name).Exec(); err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if err := callback(ctx); err != nil {
+		if rollbackErr := c.RawQuery("ROLLBACK TO SAVEPOINT " + name).Exec(); rollbackErr != nil {
+			return sqlcon.HandleError(rollbackErr)
+		}
+		return err
+	}
+
+	return sqlcon.HandleError(c.RawQuery("RELEASE SAVEPOINT " + name).Exec())
 }
 
 func (p *Persister) GetConnection(ctx context.Context) *pop.Connection {
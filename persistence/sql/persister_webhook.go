@@ -0,0 +1,143 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/webhook"
+)
+
+var _ webhook.Persister = new(Persister)
+
+func (p *Persister) AddDelivery(ctx context.Context, d *webhook.Delivery) error {
+	d.NID = corp.ContextualizeNID(ctx, p.nid)
+	d.Status = webhook.DeliveryStatusQueued
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = time.Now().UTC()
+	}
+	// A failing insert must not abort the transaction of the action the delivery belongs to.
+	return p.savepoint(ctx, func(ctx context.Context) error {
+		return sqlcon.HandleError(p.GetConnection(ctx).Create(d))
+	})
+}
+
+func (p *Persister) NextDeliveries(ctx context.Context, limit uint8, lease time.Duration) ([]webhook.Delivery, error) {
+	now := time.Now().UTC()
+
+	var due []webhook.Delivery
+	if err := p.GetConnection(ctx).
+		Where("nid = ? AND status = ? AND next_attempt_at <= ?",
+			corp.ContextualizeNID(ctx, p.nid),
+			webhook.DeliveryStatusQueued,
+			now,
+		).
+		Order("next_attempt_at ASC").
+		Limit(int(limit)).
+		All(&due); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(due))
+	for _, d := range due {
+		// The delivery is only claimed if it is still due. Otherwise, another worker claimed it since it was read.
+		count, err := p.GetConnection(ctx).RawQuery(
+			// #nosec G201
+			fmt.Sprintf(
+				"UPDATE %s SET next_attempt_at = ? WHERE id = ? AND nid = ? AND status = ? AND next_attempt_at <= ?",
+				d.TableName(ctx),
+			),
+			now.Add(lease),
+			d.ID,
+			corp.ContextualizeNID(ctx, p.nid),
+			webhook.DeliveryStatusQueued,
+			now,
+		).ExecWithCount()
+		if err != nil {
+			return nil, sqlcon.HandleError(err)
+		} else if count == 0 {
+			continue
+		}
+
+		d.NextAttemptAt = now.Add(lease)
+		deliveries = append(deliveries, d)
+	}
+
+	if len(deliveries) == 0 {
+		return nil, errors.WithStack(webhook.ErrQueueEmpty)
+	}
+
+	return deliveries, nil
+}
+
+func (p *Persister) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	return p.update(ctx, d, "status", "send_count", "last_response_code", "last_error", "next_attempt_at")
+}
+
+func (p *Persister) ListDeliveries(ctx context.Context, status webhook.DeliveryStatus, page, itemsPerPage int) ([]webhook.Delivery, int64, error) {
+	q := p.GetConnection(ctx).Where("nid = ?", corp.ContextualizeNID(ctx, p.nid))
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	count, err := q.Count(new(webhook.Delivery))
+	if err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	deliveries := make([]webhook.Delivery, 0)
+	if err := q.Order("created_at DESC").Paginate(page, itemsPerPage).All(&deliveries); err != nil {
+		return nil, 0, sqlcon.HandleError(err)
+	}
+
+	return deliveries, int64(count), nil
+}
+
+func (p *Persister) FetchDelivery(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	if err := p.GetConnection(ctx).
+		Where("id = ? AND nid = ?", id, corp.ContextualizeNID(ctx, p.nid)).
+		First(&d); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &d, nil
+}
+
+func (p *Persister) RetryDelivery(ctx context.Context, id uuid.UUID) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET status = ?, send_count = 0, next_attempt_at = ? WHERE id = ? AND nid = ? AND status = ?",
+			new(webhook.Delivery).TableName(ctx),
+		),
+		webhook.DeliveryStatusQueued,
+		time.Now().UTC(),
+		id,
+		corp.ContextualizeNID(ctx, p.nid),
+		webhook.DeliveryStatusAbandoned,
+	).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		if _, err := p.FetchDelivery(ctx, id); err != nil {
+			return err
+		}
+		return errors.WithStack(webhook.ErrDeliveryNotRetryable)
+	}
+
+	return nil
+}
+
+func (p *Persister) DeleteExpiredDeliveries(ctx context.Context, expiresAt time.Time, limit int) (int, error) {
+	return p.deleteExpired(ctx, new(webhook.Delivery).TableName(ctx), "nid = ? AND status IN (?, ?) AND updated_at < ?", limit,
+		corp.ContextualizeNID(ctx, p.nid), webhook.DeliveryStatusDelivered, webhook.DeliveryStatusAbandoned, expiresAt)
+}
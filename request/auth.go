@@ -9,22 +9,24 @@ import (
 
 type (
 	AuthStrategy interface {
-		apply(req *retryablehttp.Request)
+		apply(req *retryablehttp.Request) error
 	}
 
-	authStrategyFactory func(c json.RawMessage) (AuthStrategy, error)
+	authStrategyFactory func(c json.RawMessage, client *retryablehttp.Client) (AuthStrategy, error)
 )
 
 var strategyFactories = map[string]authStrategyFactory{
-	"":           newNoopAuthStrategy,
-	"api_key":    newApiKeyStrategy,
-	"basic_auth": newBasicAuthStrategy,
+	"":                          newNoopAuthStrategy,
+	"api_key":                   newApiKeyStrategy,
+	"basic_auth":                newBasicAuthStrategy,
+	"hmac":                      newHMACStrategy,
+	"oauth2_client_credentials": newOAuth2ClientCredentialsStrategy,
 }
 
-func authStrategy(name string, config json.RawMessage) (AuthStrategy, error) {
+func authStrategy(name string, config json.RawMessage, client *retryablehttp.Client) (AuthStrategy, error) {
 	strategyFactory, ok := strategyFactories[name]
 	if ok {
-		return strategyFactory(config)
+		return strategyFactory(config, client)
 	}
 
 	return nil, fmt.Errorf("unsupported auth type: %s", name)
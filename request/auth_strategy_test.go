@@ -1,8 +1,16 @@
 package request
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 
@@ -67,3 +75,109 @@ func TestApiKeyInCookieStrategy(t *testing.T) {
 	assert.Equal(t, "my-api-key-name", cookies[0].Name)
 	assert.Equal(t, "my-api-key-value", cookies[0].Value)
 }
+
+func TestHMACStrategy(t *testing.T) {
+	hmacNow = func() time.Time { return time.Unix(1600000000, 0) }
+	t.Cleanup(func() { hmacNow = time.Now })
+
+	sign := func(secret, payload string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write([]byte(payload))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	t.Run("case=signs timestamp and body", func(t *testing.T) {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		require.NoError(t, req.SetBody([]byte(`{"foo":"bar"}`)))
+		auth, err := newHMACStrategy(json.RawMessage(`{"secret":"my-secret"}`), nil)
+		require.NoError(t, err)
+
+		require.NoError(t, auth.apply(&req))
+
+		assert.Equal(t, "t=1600000000,v1="+sign("my-secret", `1600000000.{"foo":"bar"}`), req.Header.Get("X-Kratos-Signature"))
+
+		body, err := req.BodyBytes()
+		require.NoError(t, err)
+		assert.Equal(t, `{"foo":"bar"}`, string(body), "the body must still be readable after signing")
+	})
+
+	t.Run("case=signs request without body in custom header", func(t *testing.T) {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		auth, err := newHMACStrategy(json.RawMessage(`{"secret":"my-secret","header":"My-Signature"}`), nil)
+		require.NoError(t, err)
+
+		require.NoError(t, auth.apply(&req))
+
+		assert.Equal(t, "t=1600000000,v1="+sign("my-secret", "1600000000."), req.Header.Get("My-Signature"))
+	})
+
+	t.Run("case=requires secret", func(t *testing.T) {
+		_, err := newHMACStrategy(json.RawMessage(`{}`), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestOAuth2ClientCredentialsStrategy(t *testing.T) {
+	newTokenServer := func(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "webhooks", r.PostForm.Get("scope"))
+			user, pass, _ := r.BasicAuth()
+			assert.Equal(t, "kratos", user)
+			assert.Equal(t, "secret", pass)
+
+			n := atomic.AddInt32(&calls, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+		}))
+		t.Cleanup(ts.Close)
+		return ts, &calls
+	}
+
+	apply := func(t *testing.T, ts *httptest.Server) *retryablehttp.Request {
+		req := retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}
+		auth, err := newOAuth2ClientCredentialsStrategy(json.RawMessage(fmt.Sprintf(`{
+			"client_id": "kratos",
+			"client_secret": "secret",
+			"token_url": "%s",
+			"scope": ["webhooks"]
+		}`, ts.URL)), retryablehttp.NewClient())
+		require.NoError(t, err)
+		require.NoError(t, auth.apply(&req))
+		return &req
+	}
+
+	t.Run("case=caches the access token", func(t *testing.T) {
+		ts, calls := newTokenServer(t, 3600)
+
+		assert.Equal(t, "Bearer token-1", apply(t, ts).Header.Get("Authorization"))
+		assert.Equal(t, "Bearer token-1", apply(t, ts).Header.Get("Authorization"))
+		assert.EqualValues(t, 1, atomic.LoadInt32(calls))
+	})
+
+	t.Run("case=refreshes the access token before it expires", func(t *testing.T) {
+		ts, calls := newTokenServer(t, 5)
+
+		assert.Equal(t, "Bearer token-1", apply(t, ts).Header.Get("Authorization"))
+		assert.Equal(t, "Bearer token-2", apply(t, ts).Header.Get("Authorization"))
+		assert.EqualValues(t, 2, atomic.LoadInt32(calls))
+	})
+
+	t.Run("case=fails if the token can not be fetched", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		t.Cleanup(ts.Close)
+
+		auth, err := newOAuth2ClientCredentialsStrategy(json.RawMessage(fmt.Sprintf(`{"client_id":"kratos","token_url":"%s"}`, ts.URL)), nil)
+		require.NoError(t, err)
+		require.Error(t, auth.apply(&retryablehttp.Request{Request: &http.Request{Header: map[string][]string{}}}))
+	})
+
+	t.Run("case=requires client id and token url", func(t *testing.T) {
+		_, err := newOAuth2ClientCredentialsStrategy(json.RawMessage(`{"client_id":"kratos"}`), nil)
+		require.Error(t, err)
+	})
+}
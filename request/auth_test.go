@@ -45,9 +45,25 @@ func TestAuthStrategy(t *testing.T) {
 			}`,
 			expected: &apiKeyStrategy{},
 		},
+		"hmac": {
+			name: "hmac",
+			config: `{
+				"secret": "secret"
+			}`,
+			expected: &hmacStrategy{},
+		},
+		"oauth2_client_credentials": {
+			name: "oauth2_client_credentials",
+			config: `{
+				"client_id": "kratos",
+				"client_secret": "secret",
+				"token_url": "https://auth.ory.sh/oauth2/token"
+			}`,
+			expected: &oauth2ClientCredentialsStrategy{},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			strategy, err := authStrategy(tc.name, json.RawMessage(tc.config))
+			strategy, err := authStrategy(tc.name, json.RawMessage(tc.config), nil)
 			require.NoError(t, err)
 
 			assert.IsTypef(t, tc.expected, strategy, "auth strategy should be of the expected type")
@@ -54,14 +54,12 @@ func NewBuilder(config json.RawMessage, client *retryablehttp.Client, l *logrusx
 func (b *Builder) addAuth() error {
 	authConfig := b.conf.Auth
 
-	strategy, err := authStrategy(authConfig.Type, authConfig.Config)
+	strategy, err := authStrategy(authConfig.Type, authConfig.Config, b.fetchClient)
 	if err != nil {
 		return err
 	}
 
-	strategy.apply(b.r)
-
-	return nil
+	return strategy.apply(b.r)
 }
 
 func (b *Builder) addBody(body interface{}) error {
@@ -120,11 +118,7 @@ func (b *Builder) addJSONBody(template *bytes.Buffer, body interface{}) error {
 		return errors.WithStack(err)
 	}
 
-	rb := strings.NewReader(res)
-	b.r.Body = io.NopCloser(rb)
-	b.r.ContentLength = int64(rb.Len())
-
-	return nil
+	return b.setBody(res)
 }
 
 func (b *Builder) addURLEncodedBody(template *bytes.Buffer, body interface{}) error {
@@ -156,17 +150,20 @@ func (b *Builder) addURLEncodedBody(template *bytes.Buffer, body interface{}) er
 		u.Add(key, value)
 	}
 
-	rb := strings.NewReader(u.Encode())
-	b.r.Body = io.NopCloser(rb)
+	return b.setBody(u.Encode())
+}
 
+// setBody sets the body such that the HTTP client can send it again when it retries the request.
+func (b *Builder) setBody(body string) error {
+	if err := b.r.SetBody([]byte(body)); err != nil {
+		return errors.WithStack(err)
+	}
+	b.r.Body = io.NopCloser(strings.NewReader(body))
 	return nil
 }
 
 func (b *Builder) BuildRequest(body interface{}) (*retryablehttp.Request, error) {
 	b.r.Header = b.conf.Header
-	if err := b.addAuth(); err != nil {
-		return nil, err
-	}
 
 	// According to the HTTP spec any request method, but TRACE is allowed to
 	// have a body. Even this is a bad practice for some of them, like for GET
@@ -176,6 +173,11 @@ func (b *Builder) BuildRequest(body interface{}) (*retryablehttp.Request, error)
 		}
 	}
 
+	// Auth is added last because some strategies sign the request body.
+	if err := b.addAuth(); err != nil {
+		return nil, err
+	}
+
 	return b.r, nil
 }
 
@@ -1,12 +1,16 @@
 package request
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	_ "embed"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -276,6 +280,39 @@ func TestBuildRequest(t *testing.T) {
 		_, err = rb.BuildRequest(json.RawMessage(`{}`))
 		require.ErrorIs(t, err, ErrCancel)
 	})
+
+	t.Run("signs the rendered body", func(t *testing.T) {
+		l := logrusx.New("kratos", "test")
+
+		rb, err := NewBuilder(json.RawMessage(`{
+	"url": "https://test.kratos.ory.sh/my_endpoint7",
+	"method": "POST",
+	"body": "file://./stub/test_body.jsonnet",
+	"auth": {
+		"type": "hmac",
+		"config": {
+			"secret": "my-secret"
+		}
+	}
+}`), nil, l)
+		require.NoError(t, err)
+
+		req, err := rb.BuildRequest(&testRequestBody{To: "+14134242223", From: "+13104661805", Body: "test-sms-body"})
+		require.NoError(t, err)
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NotEmpty(t, body)
+
+		signature := req.Header.Get("X-Kratos-Signature")
+		require.Regexp(t, `^t=\d+,v1=[0-9a-f]{64}$`, signature)
+		timestamp := signature[2:strings.Index(signature, ",")]
+
+		mac := hmac.New(sha256.New, []byte("my-secret"))
+		_, _ = mac.Write([]byte(timestamp + "."))
+		_, _ = mac.Write(body)
+		assert.Equal(t, "t="+timestamp+",v1="+hex.EncodeToString(mac.Sum(nil)), signature)
+	})
 }
 
 func mustContainHeader(t *testing.T, expected http.Header, actual http.Header) {
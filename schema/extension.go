@@ -25,7 +25,8 @@ type (
 				Identifier bool `json:"identifier"`
 			} `json:"webauthn"`
 			Code struct {
-				Identifier bool `json:"identifier"`
+				Identifier bool   `json:"identifier"`
+				Via        string `json:"via"`
 			} `json:"code"`
 			TOTP struct {
 				AccountName bool `json:"account_name"`
@@ -44,6 +45,9 @@ type (
 				} `json:"traits"`
 			} `json:"identity"`
 		} `json:"mappings"`
+
+		// Format is the JSON Schema format of the trait this extension is attached to, if any.
+		Format string `json:"-"`
 	}
 
 	Extension interface {
@@ -85,6 +89,7 @@ func NewExtensionRunner(ctx context.Context, runners ...Extension) (*ExtensionRu
 			if err := json.NewDecoder(&b).Decode(&e); err != nil {
 				return nil, errors.WithStack(err)
 			}
+			e.Format, _ = m["format"].(string)
 
 			return &e, nil
 		}
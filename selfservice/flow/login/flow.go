@@ -77,6 +77,10 @@ type Flow struct {
 	// ReturnTo contains the requested return_to URL.
 	ReturnTo string `json:"return_to,omitempty" db:"-"`
 
+	// AcceptLanguage contains the Accept-Language header of the request which initiated this flow. It is used
+	// to pick the language of messages sent during the flow.
+	AcceptLanguage sqlxx.NullString `json:"-" faker:"-" db:"accept_language"`
+
 	// The active login method
 	//
 	// If set contains the login method used. If the flow is new, it is unset.
@@ -129,10 +133,11 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(), RouteSubmitFlow), id).String(),
 		},
-		RequestURL: requestURL,
-		CSRFToken:  csrf,
-		Type:       flowType,
-		Refresh:    r.URL.Query().Get("refresh") == "true",
+		RequestURL:     requestURL,
+		AcceptLanguage: sqlxx.NullString(r.Header.Get("Accept-Language")),
+		CSRFToken:      csrf,
+		Type:           flowType,
+		Refresh:        r.URL.Query().Get("refresh") == "true",
 		RequestedAAL: identity.AuthenticatorAssuranceLevel(strings.ToLower(stringsx.Coalesce(
 			r.URL.Query().Get("aal"),
 			string(identity.AuthenticatorAssuranceLevel1)))),
@@ -167,6 +172,10 @@ func (f Flow) GetID() uuid.UUID {
 	return f.ID
 }
 
+func (f Flow) GetAcceptLanguage() string {
+	return string(f.AcceptLanguage)
+}
+
 func (f *Flow) IsForced() bool {
 	return f.Refresh
 }
@@ -36,6 +36,8 @@ const (
 	RouteGetFlow = "/self-service/login/flows"
 
 	RouteSubmitFlow = "/self-service/login"
+
+	AdminRouteIdentityLockout = "/identities/:id/lockout"
 )
 
 type (
@@ -51,6 +53,8 @@ type (
 		x.CSRFProvider
 		config.Provider
 		ErrorHandlerProvider
+		LockoutManagerProvider
+		identity.PrivilegedPoolProvider
 	}
 	HandlerProvider interface {
 		LoginHandler() *Handler
@@ -84,6 +88,8 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 
 	admin.POST(RouteSubmitFlow, x.RedirectToPublicRoute(h.d))
 	admin.GET(RouteSubmitFlow, x.RedirectToPublicRoute(h.d))
+
+	admin.DELETE(AdminRouteIdentityLockout, h.adminDeleteIdentityLockout)
 }
 
 func (h *Handler) NewLoginFlow(w http.ResponseWriter, r *http.Request, ft flow.Type) (*Flow, error) {
@@ -616,3 +622,53 @@ continueLogin:
 		return
 	}
 }
+
+// swagger:parameters adminDeleteIdentityLockout
+// nolint:deadcode,unused
+type adminDeleteIdentityLockout struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/identities/{id}/lockout v0alpha2 adminDeleteIdentityLockout
+//
+// Lift a Login Lockout
+//
+// Calling this endpoint removes all failed login attempts recorded for the identifiers of the given identity,
+// which lifts a temporary lockout caused by too many failed password, TOTP, or lookup secret attempts.
+//
+// Lockouts of IP addresses are not affected.
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminDeleteIdentityLockout(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	iID, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.d.Writer().WriteError(w, r, herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	i, err := h.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), iID)
+	if err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.d.LoginLockoutManager().Clear(r.Context(), i); err != nil {
+		h.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -125,7 +125,7 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, a *
 	}
 
 	if a.Type == flow.TypeAPI {
-		if err := e.d.SessionPersister().UpsertSession(r.Context(), s); err != nil {
+		if err := e.d.SessionManager().UpsertAndTrackDevice(r.Context(), r, s); err != nil {
 			return errors.WithStack(err)
 		}
 		e.d.Audit().
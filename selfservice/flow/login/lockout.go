@@ -0,0 +1,283 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/jsonschema/v3"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/driver/clock"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	// maxAttemptIdentifierLength and maxAttemptAddressLength are the lengths of the identifier and address columns.
+	maxAttemptIdentifierLength = 255
+	maxAttemptAddressLength    = 64
+)
+
+// Attempt is a failed login attempt which counts towards the brute-force protection limits.
+type Attempt struct {
+	// ID is the attempt's unique identifier.
+	ID uuid.UUID `json:"id" db:"id"`
+
+	// Identifier is the (normalized) identifier the attempt was made for, for example
+	// an email address or, for second factors, the identity's ID.
+	Identifier string `json:"identifier" db:"identifier"`
+
+	// CredentialsType is the type of credentials which were used in the attempt.
+	CredentialsType identity.CredentialsType `json:"credentials_type" db:"credentials_type"`
+
+	// Address is the IP address the attempt was made from.
+	Address string `json:"address" db:"address"`
+
+	NID       uuid.UUID `json:"-" faker:"-" db:"nid"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (a Attempt) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_login_attempts")
+}
+
+// AttemptFilter selects the failed login attempts which are counted. Empty fields are ignored.
+type AttemptFilter struct {
+	Identifier      string
+	CredentialsType identity.CredentialsType
+	Address         string
+	Since           time.Time
+}
+
+type ValidationErrorContextLockedOut struct{}
+
+func (r *ValidationErrorContextLockedOut) AddContext(_, _ string) {}
+
+func (r *ValidationErrorContextLockedOut) FinishInstanceContext() {}
+
+// LockedOutError is returned if too many failed login attempts were made for an identifier or from an IP address.
+type LockedOutError struct {
+	*schema.ValidationError
+}
+
+func (e LockedOutError) Error() string {
+	return e.ValidationError.Error()
+}
+
+func (e LockedOutError) Unwrap() error {
+	return e.ValidationError
+}
+
+func (e LockedOutError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+func NewLockedOutError(wait time.Duration) error {
+	return errors.WithStack(LockedOutError{
+		ValidationError: &schema.ValidationError{
+			ValidationError: &jsonschema.ValidationError{
+				Message:     `too many failed login attempts`,
+				InstancePtr: "#/",
+				Context:     &ValidationErrorContextLockedOut{},
+			},
+			Messages: new(text.Messages).Add(text.NewErrorValidationLoginLockedOut(wait)),
+		}})
+}
+
+type (
+	lockoutDependencies interface {
+		config.Provider
+		clock.Provider
+		AttemptPersistenceProvider
+		event.StreamProvider
+	}
+	LockoutManagerProvider interface {
+		LoginLockoutManager() *LockoutManager
+	}
+
+	// LockoutManager protects login methods against brute-force attacks.
+	//
+	// Once the configured number of failed attempts for an identifier or from an IP address was reached
+	// within the window, further attempts are rejected until the lockout duration has passed since the
+	// latest failed attempt. Attempts which are not locked out are delayed progressively based on the
+	// number of failed attempts for the identifier.
+	LockoutManager struct {
+		d lockoutDependencies
+	}
+)
+
+func NewLockoutManager(d lockoutDependencies) *LockoutManager {
+	return &LockoutManager{d: d}
+}
+
+func normalizeAttemptIdentifier(identifier string) string {
+	return truncateAttemptField(strings.ToLower(strings.TrimSpace(identifier)), maxAttemptIdentifierLength)
+}
+
+// truncateAttemptField removes invalid UTF-8 and truncates the value to at most n bytes without splitting a rune,
+// so that identifiers and addresses of any length can be recorded.
+func truncateAttemptField(v string, n int) string {
+	v = strings.ToValidUTF8(v, "")
+	if len(v) <= n {
+		return v
+	}
+
+	for n > 0 && !utf8.RuneStart(v[n]) {
+		n--
+	}
+	return v[:n]
+}
+
+// Check returns a LockedOutError if the identifier or the request's IP address is locked out
+// and otherwise applies the progressive delay.
+//
+// The attempt is recorded before the credentials are compared and counts as failed unless the login succeeds
+// and Reset is called. Counting the attempts again after recording it ensures that parallel attempts can not
+// exceed the limits.
+func (m *LockoutManager) Check(ctx context.Context, r *http.Request, ct identity.CredentialsType, identifier string) error {
+	c := m.d.Config(ctx).SelfServiceFlowLoginLockout()
+	if !c.Enabled {
+		return nil
+	}
+
+	now := m.d.Clock().Now().UTC()
+	since := now.Add(-c.Window)
+	attempt := &Attempt{
+		ID:              x.NewUUID(),
+		Identifier:      normalizeAttemptIdentifier(identifier),
+		CredentialsType: ct,
+		Address:         truncateAttemptField(x.ClientIP(r, m.d.Config(ctx).PublicTrustedProxies()), maxAttemptAddressLength),
+	}
+
+	limits := []*attemptLimit{
+		{max: c.MaxAttempts, filter: AttemptFilter{Identifier: attempt.Identifier, CredentialsType: ct, Since: since}},
+		{max: c.MaxAttemptsPerAddress, filter: AttemptFilter{Address: attempt.Address, Since: since}},
+	}
+	for _, l := range limits {
+		count, latest, err := m.d.LoginAttemptPersister().CountLoginAttempts(ctx, l.filter)
+		if err != nil {
+			return err
+		}
+		if wait := lockedOutFor(l.max, count, latest.Add(c.Duration).Sub(now)); wait > 0 {
+			return NewLockedOutError(wait)
+		}
+		l.count, l.latest = count, latest
+	}
+
+	if err := m.d.LoginAttemptPersister().CreateLoginAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	for _, l := range limits {
+		if l.max <= 0 {
+			continue
+		}
+
+		// Only attempts made after the ones counted above are racing with this attempt.
+		filter := l.filter
+		if l.latest.After(filter.Since) {
+			filter.Since = l.latest
+		}
+		racing, _, err := m.d.LoginAttemptPersister().CountLoginAttempts(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if racing > l.remaining() {
+			if err := m.d.LoginAttemptPersister().DeleteLoginAttempt(ctx, attempt.ID); err != nil {
+				return err
+			}
+			return NewLockedOutError(c.Duration)
+		}
+	}
+
+	if delay := progressiveDelay(c.Delay, c.MaxDelay, limits[0].count); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// attemptLimit is a limit of failed attempts and the attempts counted towards it.
+type attemptLimit struct {
+	max    int
+	filter AttemptFilter
+	count  int
+	latest time.Time
+}
+
+// remaining returns how many attempts may be made in parallel. Once a lockout passed, a single attempt is
+// allowed before the next failure locks out again.
+func (l *attemptLimit) remaining() int {
+	if l.count >= l.max {
+		return 1
+	}
+	return l.max - l.count
+}
+
+func lockedOutFor(max, count int, remaining time.Duration) time.Duration {
+	if max <= 0 || count < max {
+		return 0
+	}
+	return remaining
+}
+
+func progressiveDelay(base, max time.Duration, failures int) time.Duration {
+	if base <= 0 || failures == 0 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < failures && (max <= 0 || delay < max); i++ {
+		delay *= 2
+	}
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
+
+// RecordFailure emits a login failed event. The attempt itself was already recorded by Check and is kept.
+// The identity's ID is uuid.Nil if no identity matches the identifier.
+func (m *LockoutManager) RecordFailure(ctx context.Context, f *Flow, ct identity.CredentialsType, identifier string, identityID uuid.UUID) error {
+	m.d.EventStream().Emit(ctx, event.New(event.TypeLoginFailed, identityID).
+		WithFlowID(f.ID).
+		WithData("method", ct).
+		WithData("identifier", identifier))
+	return nil
+}
+
+// Reset removes the failed login attempts of the identifier after a successful login.
+func (m *LockoutManager) Reset(ctx context.Context, identifier string) error {
+	if !m.d.Config(ctx).SelfServiceFlowLoginLockout().Enabled {
+		return nil
+	}
+
+	return m.d.LoginAttemptPersister().DeleteLoginAttempts(ctx, normalizeAttemptIdentifier(identifier))
+}
+
+// Clear removes the failed login attempts of all identifiers of the identity, lifting any lockout.
+func (m *LockoutManager) Clear(ctx context.Context, i *identity.Identity) error {
+	identifiers := []string{i.ID.String()}
+	for _, c := range i.Credentials {
+		for _, id := range c.Identifiers {
+			identifiers = append(identifiers, normalizeAttemptIdentifier(id))
+		}
+	}
+
+	return m.d.LoginAttemptPersister().DeleteLoginAttempts(ctx, identifiers...)
+}
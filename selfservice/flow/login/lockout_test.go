@@ -0,0 +1,46 @@
+package login_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/flow/login"
+)
+
+func TestLockoutManager(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, true)
+	conf.MustSet(config.ViperKeySelfServiceLoginLockoutDelay, "1ms")
+
+	t.Run("case=records attempts with identifiers and addresses which exceed the column length", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/self-service/login", nil)
+		r.RemoteAddr = strings.Repeat("b", 100)
+		identifier := strings.Repeat("a", 254) + "ü"
+
+		require.NoError(t, reg.LoginLockoutManager().Check(ctx, r, identity.CredentialsTypePassword, identifier))
+
+		since := time.Now().Add(-time.Hour)
+		count, _, err := reg.LoginAttemptPersister().CountLoginAttempts(ctx, login.AttemptFilter{Identifier: strings.Repeat("a", 254), Since: since})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		count, _, err = reg.LoginAttemptPersister().CountLoginAttempts(ctx, login.AttemptFilter{Address: strings.Repeat("b", 64), Since: since})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		require.NoError(t, reg.LoginLockoutManager().Reset(ctx, identifier))
+		count, _, err = reg.LoginAttemptPersister().CountLoginAttempts(ctx, login.AttemptFilter{Identifier: strings.Repeat("a", 254), Since: since})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
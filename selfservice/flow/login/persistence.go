@@ -2,6 +2,7 @@ package login
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -12,8 +13,21 @@ type (
 		CreateLoginFlow(context.Context, *Flow) error
 		GetLoginFlow(context.Context, uuid.UUID) (*Flow, error)
 		ForceLoginFlow(ctx context.Context, id uuid.UUID) error
+		// DeleteExpiredLoginFlows deletes at most limit login flows which expired before the given time.
+		DeleteExpiredLoginFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 	FlowPersistenceProvider interface {
 		LoginFlowPersister() FlowPersister
 	}
+	AttemptPersister interface {
+		CreateLoginAttempt(context.Context, *Attempt) error
+		// CountLoginAttempts returns the number of failed login attempts matching the filter and when the latest one was made.
+		CountLoginAttempts(context.Context, AttemptFilter) (count int, latest time.Time, err error)
+		DeleteLoginAttempts(ctx context.Context, identifiers ...string) error
+		// DeleteLoginAttempt deletes a single login attempt, for example one which was rejected by the lockout.
+		DeleteLoginAttempt(ctx context.Context, id uuid.UUID) error
+	}
+	AttemptPersistenceProvider interface {
+		LoginAttemptPersister() AttemptPersister
+	}
 )
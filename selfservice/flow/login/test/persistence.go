@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/persistence"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
@@ -135,6 +136,59 @@ func TestFlowPersister(ctx context.Context, p persistence.Persister) func(t *tes
 			}
 		})
 
+		t.Run("case=should count and delete login attempts", func(t *testing.T) {
+			identifier := x.NewUUID().String()
+			since := time.Now().Add(-time.Minute)
+			for _, a := range []login.Attempt{
+				{ID: x.NewUUID(), Identifier: identifier, CredentialsType: identity.CredentialsTypePassword, Address: "192.0.2.1"},
+				{ID: x.NewUUID(), Identifier: identifier, CredentialsType: identity.CredentialsTypePassword, Address: "192.0.2.2"},
+				{ID: x.NewUUID(), Identifier: identifier, CredentialsType: identity.CredentialsTypeTOTP, Address: "192.0.2.1"},
+			} {
+				a := a
+				require.NoError(t, p.CreateLoginAttempt(ctx, &a))
+			}
+
+			count, latest, err := p.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: identifier, CredentialsType: identity.CredentialsTypePassword, Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+			assert.True(t, latest.After(since))
+
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Address: "192.0.2.1", Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: identifier, Since: time.Now().Add(time.Minute)})
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+
+			_, other := testhelpers.NewNetwork(t, ctx, p)
+			count, _, err = other.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: identifier, Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+
+			require.NoError(t, other.DeleteLoginAttempts(ctx, identifier))
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: identifier, Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 3, count)
+
+			single := login.Attempt{ID: x.NewUUID(), Identifier: identifier, CredentialsType: identity.CredentialsTypeTOTP, Address: "192.0.2.3"}
+			require.NoError(t, p.CreateLoginAttempt(ctx, &single))
+			require.NoError(t, other.DeleteLoginAttempt(ctx, single.ID))
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Address: "192.0.2.3", Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			require.NoError(t, p.DeleteLoginAttempt(ctx, single.ID))
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Address: "192.0.2.3", Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+
+			require.NoError(t, p.DeleteLoginAttempts(ctx, identifier, x.NewUUID().String()))
+			count, _, err = p.CountLoginAttempts(ctx, login.AttemptFilter{Identifier: identifier, Since: since})
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+		})
+
 		t.Run("case=network", func(t *testing.T) {
 			id := x.NewUUID()
 			nid, p := testhelpers.NewNetwork(t, ctx, p)
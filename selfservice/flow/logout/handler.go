@@ -30,7 +30,6 @@ type (
 		x.WriterProvider
 		x.CSRFProvider
 		session.ManagementProvider
-		session.PersistenceProvider
 		errorx.ManagementProvider
 		config.Provider
 	}
@@ -182,7 +181,7 @@ func (h *Handler) submitSelfServiceLogoutFlowWithoutBrowser(w http.ResponseWrite
 		return
 	}
 
-	if err := h.d.SessionPersister().RevokeSessionByToken(r.Context(), p.SessionToken); err != nil {
+	if err := h.d.SessionManager().RevokeSessionByToken(r.Context(), p.SessionToken); err != nil {
 		if errors.Is(err, sqlcon.ErrNoRows) {
 			h.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrForbidden.WithReason("The provided Ory Session Token could not be found, is invalid, or otherwise malformed.")))
 			return
@@ -60,6 +60,10 @@ type Flow struct {
 	// ReturnTo contains the requested return_to URL.
 	ReturnTo string `json:"return_to,omitempty" db:"-"`
 
+	// AcceptLanguage contains the Accept-Language header of the request which initiated this flow. It is used
+	// to pick the language of messages sent during the flow.
+	AcceptLanguage sqlxx.NullString `json:"-" faker:"-" db:"accept_language"`
+
 	// Active, if set, contains the registration method that is being used. It is initially
 	// not set.
 	Active sqlxx.NullString `json:"active,omitempty" faker:"-" db:"active_method"`
@@ -109,10 +113,11 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 	}
 
 	req := &Flow{
-		ID:         id,
-		ExpiresAt:  now.Add(exp),
-		IssuedAt:   now,
-		RequestURL: requestURL,
+		ID:             id,
+		ExpiresAt:      now.Add(exp),
+		IssuedAt:       now,
+		RequestURL:     requestURL,
+		AcceptLanguage: sqlxx.NullString(r.Header.Get("Accept-Language")),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(), RouteSubmitFlow), id).String(),
@@ -162,6 +167,10 @@ func (f Flow) GetID() uuid.UUID {
 	return f.ID
 }
 
+func (f Flow) GetAcceptLanguage() string {
+	return string(f.AcceptLanguage)
+}
+
 func (f Flow) GetNID() uuid.UUID {
 	return f.NID
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
@@ -43,6 +44,7 @@ type (
 		HooksProvider
 		x.LoggingProvider
 		x.WriterProvider
+		event.StreamProvider
 	}
 
 	HookExecutor struct {
@@ -83,5 +85,7 @@ func (e *HookExecutor) PostRecoveryHook(w http.ResponseWriter, r *http.Request,
 		WithField("identity_id", s.Identity.ID).
 		Debug("Post verification execution hooks completed successfully.")
 
+	e.d.EventStream().Emit(r.Context(), event.New(event.TypeRecoveryCompleted, s.Identity.ID).WithSessionID(s.ID).WithFlowID(a.ID))
+
 	return nil
 }
@@ -2,6 +2,7 @@ package recovery
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -11,6 +12,8 @@ type (
 		CreateRecoveryFlow(context.Context, *Flow) error
 		GetRecoveryFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
 		UpdateRecoveryFlow(context.Context, *Flow) error
+		// DeleteExpiredRecoveryFlows deletes at most limit recovery flows which expired before the given time.
+		DeleteExpiredRecoveryFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 	FlowPersistenceProvider interface {
 		RecoveryFlowPersister() FlowPersister
@@ -62,6 +62,10 @@ type Flow struct {
 	// ReturnTo contains the requested return_to URL.
 	ReturnTo string `json:"return_to,omitempty" db:"-"`
 
+	// AcceptLanguage contains the Accept-Language header of the request which initiated this flow. It is used
+	// to pick the language of messages sent during the flow.
+	AcceptLanguage sqlxx.NullString `json:"-" faker:"-" db:"accept_language"`
+
 	// Active, if set, contains the registration method that is being used. It is initially
 	// not set.
 	Active identity.CredentialsType `json:"active,omitempty" faker:"identity_credentials_type" db:"active_method"`
@@ -99,10 +103,11 @@ func NewFlow(conf *config.Config, exp time.Duration, csrf string, r *http.Reques
 	}
 
 	return &Flow{
-		ID:         id,
-		ExpiresAt:  now.Add(exp),
-		IssuedAt:   now,
-		RequestURL: requestURL,
+		ID:             id,
+		ExpiresAt:      now.Add(exp),
+		IssuedAt:       now,
+		RequestURL:     requestURL,
+		AcceptLanguage: sqlxx.NullString(r.Header.Get("Accept-Language")),
 		UI: &container.Container{
 			Method: "POST",
 			Action: flow.AppendFlowTo(urlx.AppendPaths(conf.SelfPublicURL(), RouteSubmitFlow), id).String(),
@@ -121,6 +126,10 @@ func (f Flow) GetID() uuid.UUID {
 	return f.ID
 }
 
+func (f Flow) GetAcceptLanguage() string {
+	return string(f.AcceptLanguage)
+}
+
 func (f Flow) GetNID() uuid.UUID {
 	return f.NID
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/pkg/errors"
 
 	"github.com/ory/x/sqlcon"
@@ -34,6 +35,13 @@ type (
 	}
 	PostHookPrePersistExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, i *identity.Identity) error
 
+	// PostHookTransactionalExecutor may be implemented by post persist hooks which write to the database, for
+	// example to queue a web hook delivery. It is called with the transaction which creates the identity, so
+	// that either both or neither are stored.
+	PostHookTransactionalExecutor interface {
+		ExecutePostRegistrationTransactionalHook(r *http.Request, a *Flow, i *identity.Identity) error
+	}
+
 	HooksProvider interface {
 		PreRegistrationHooks(ctx context.Context) []PreHookExecutor
 		PostRegistrationPrePersistHooks(ctx context.Context, credentialsType identity.CredentialsType) []PostHookPrePersistExecutor
@@ -69,6 +77,7 @@ type (
 		HooksProvider
 		x.LoggingProvider
 		x.WriterProvider
+		x.TransactionPersistenceProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -118,7 +127,20 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		return err
 		// We're now creating the identity because any of the hooks could trigger a "redirect" or a "session" which
 		// would imply that the identity has to exist already.
-	} else if err := e.d.IdentityManager().Create(r.Context(), i); err != nil {
+	} else if err := e.d.TransactionPersister().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := e.d.IdentityManager().Create(ctx, i); err != nil {
+			return err
+		}
+
+		for _, executor := range e.d.PostRegistrationPostPersistHooks(ctx, ct) {
+			if executor, ok := executor.(PostHookTransactionalExecutor); ok {
+				if err := executor.ExecutePostRegistrationTransactionalHook(r.WithContext(ctx), a, i); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
 		if errors.Is(err, sqlcon.ErrUniqueViolation) {
 			return schema.NewDuplicateCredentialsError()
 		}
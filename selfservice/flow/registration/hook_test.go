@@ -2,6 +2,7 @@ package registration_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
@@ -98,6 +99,59 @@ func TestRegistrationExecutor(t *testing.T) {
 					require.Error(t, err)
 				})
 
+				for _, scope := range []string{strategy, config.HookGlobal} {
+					t.Run("case=persists the identity patched by a web hook/scope="+scope, func(t *testing.T) {
+						t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+						wh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							_, _ = w.Write([]byte(`{"identity":{"traits":{"bar":"patched"},"metadata_public":{"plan":"free"},"metadata_admin":{"fraud_score":0.1}}}`))
+						}))
+						t.Cleanup(wh.Close)
+						viperSetPost(t, conf, scope, []config.SelfServiceHook{{Name: "web_hook", Config: []byte(fmt.Sprintf(`{"url":%q,"method":"POST","body":"base64://ZnVuY3Rpb24oY3R4KSB7fQ==","response":{"parse":true}}`, wh.URL))}})
+
+						i := testhelpers.SelfServiceHookFakeIdentity(t)
+						i.Traits = identity.Traits(`{"foo":"kept"}`)
+						i.MetadataPublic, i.MetadataAdmin = nil, nil
+
+						res, body := makeRequestPost(t, newServer(t, i, flow.TypeAPI), true, url.Values{})
+						require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+						actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+						require.NoError(t, err)
+						assert.JSONEq(t, `{"foo":"kept","bar":"patched"}`, string(actual.Traits))
+						assert.JSONEq(t, `{"plan":"free"}`, string(actual.MetadataPublic))
+						assert.JSONEq(t, `{"fraud_score":0.1}`, string(actual.MetadataAdmin))
+					})
+				}
+
+				t.Run("case=queues an asynchronous web hook in the transaction creating the identity", func(t *testing.T) {
+					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+					webHook := config.SelfServiceHook{Name: "web_hook", Config: []byte(`{"url":"https://www.ory.sh/","method":"POST","body":"base64://ZnVuY3Rpb24oY3R4KSB7fQ==","response":{"ignore":true}}`)}
+					countDeliveries := func(t *testing.T) int64 {
+						_, total, err := reg.WebHookPersister().ListDeliveries(context.Background(), "", 1, 1)
+						require.NoError(t, err)
+						return total
+					}
+					before := countDeliveries(t)
+
+					viperSetPost(t, conf, strategy, []config.SelfServiceHook{webHook, {Name: "err", Config: []byte(`{"ExecutePostRegistrationTransactionalHook": "err"}`)}})
+					i := testhelpers.SelfServiceHookFakeIdentity(t)
+					res, body := makeRequestPost(t, newServer(t, i, flow.TypeAPI), true, url.Values{})
+					assert.NotEqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+					_, err := reg.IdentityPool().GetIdentity(context.Background(), i.ID)
+					require.Error(t, err)
+					assert.Equal(t, before, countDeliveries(t), "the delivery must be rolled back together with the identity")
+
+					viperSetPost(t, conf, strategy, []config.SelfServiceHook{webHook})
+					i = testhelpers.SelfServiceHookFakeIdentity(t)
+					res, body = makeRequestPost(t, newServer(t, i, flow.TypeAPI), true, url.Values{})
+					require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+					_, err = reg.IdentityPool().GetIdentity(context.Background(), i.ID)
+					require.NoError(t, err)
+					assert.Equal(t, before+1, countDeliveries(t))
+				})
+
 				t.Run("case=use return_to value", func(t *testing.T) {
 					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
 					conf.MustSet(config.ViperKeyURLsAllowedReturnToDomains, []string{"https://www.ory.sh/"})
@@ -2,6 +2,7 @@ package registration
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -10,6 +11,8 @@ type FlowPersister interface {
 	UpdateRegistrationFlow(context.Context, *Flow) error
 	CreateRegistrationFlow(context.Context, *Flow) error
 	GetRegistrationFlow(context.Context, uuid.UUID) (*Flow, error)
+	// DeleteExpiredRegistrationFlows deletes at most limit registration flows which expired before the given time.
+	DeleteExpiredRegistrationFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 }
 
 type FlowPersistenceProvider interface {
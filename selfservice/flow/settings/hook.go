@@ -13,6 +13,7 @@ import (
 
 	"github.com/ory/kratos/schema"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
@@ -31,7 +32,14 @@ type (
 		ExecuteSettingsPostPersistHook(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
 	}
 	PostHookPostPersistExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
-	HooksProvider                   interface {
+
+	// PostHookTransactionalExecutor may be implemented by post persist hooks which write to the database, for
+	// example to queue a web hook delivery. It is called with the transaction which updates the identity, so
+	// that either both or neither are stored.
+	PostHookTransactionalExecutor interface {
+		ExecuteSettingsTransactionalHook(r *http.Request, a *Flow, i *identity.Identity) error
+	}
+	HooksProvider interface {
 		PostSettingsPrePersistHooks(ctx context.Context, settingsType string) []PostHookPrePersistExecutor
 		PostSettingsPostPersistHooks(ctx context.Context, settingsType string) []PostHookPostPersistExecutor
 	}
@@ -46,6 +54,7 @@ type (
 
 		x.LoggingProvider
 		x.WriterProvider
+		x.TransactionPersistenceProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -121,6 +130,8 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 		f(hookOptions)
 	}
 
+	// Only pre-persist hooks, such as web hooks parsing their response, may change the identity's metadata.
+	metadataPublic, metadataAdmin := string(i.MetadataPublic), string(i.MetadataAdmin)
 	for k, executor := range e.d.PostSettingsPrePersistHooks(r.Context(), settingsType) {
 		logFields := logrus.Fields{
 			"executor":          fmt.Sprintf("%T", executor),
@@ -147,8 +158,24 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 	if ctxUpdate.Session.AuthenticatedAt.Add(ttl).After(time.Now()) {
 		options = append(options, identity.ManagerAllowWriteProtectedTraits)
 	}
+	if string(i.MetadataPublic) != metadataPublic || string(i.MetadataAdmin) != metadataAdmin {
+		options = append(options, identity.ManagerAllowWriteMetadata)
+	}
 
-	if err := e.d.IdentityManager().Update(r.Context(), i, options...); err != nil {
+	if err := e.d.TransactionPersister().Transaction(r.Context(), func(ctx context.Context, _ *pop.Connection) error {
+		if err := e.d.IdentityManager().Update(ctx, i, options...); err != nil {
+			return err
+		}
+
+		for _, executor := range e.d.PostSettingsPostPersistHooks(ctx, settingsType) {
+			if executor, ok := executor.(PostHookTransactionalExecutor); ok {
+				if err := executor.ExecuteSettingsTransactionalHook(r.WithContext(ctx), ctxUpdate.Flow, i); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
 		if errors.Is(err, identity.ErrProtectedFieldModified) {
 			e.d.Logger().WithError(err).Debug("Modifying protected field requires re-authentication.")
 			return errors.WithStack(NewFlowNeedsReAuth())
@@ -1,6 +1,8 @@
 package settings_test
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
@@ -144,6 +146,47 @@ func TestSettingsExecutor(t *testing.T) {
 					assert.NotEmpty(t, gjson.Get(body, "identity.id"))
 				})
 
+				for _, scope := range []string{strategy, config.HookGlobal} {
+					t.Run("case=persists the identity patched by a web hook/scope="+scope, func(t *testing.T) {
+						t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+						wh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							_, _ = w.Write([]byte(`{"identity":{"traits":{"stringy":"patched"},"metadata_public":{"plan":"free"},"metadata_admin":{"fraud_score":0.1}}}`))
+						}))
+						t.Cleanup(wh.Close)
+						viperSetPost(scope, []config.SelfServiceHook{{Name: "web_hook", Config: []byte(fmt.Sprintf(`{"url":%q,"method":"POST","body":"base64://ZnVuY3Rpb24oY3R4KSB7fQ==","response":{"parse":true}}`, wh.URL))}})
+
+						res, body := makeRequestPost(t, newServer(t, flow.TypeAPI), true, url.Values{})
+						require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+
+						actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), x.ParseUUID(gjson.Get(body, "identity.id").String()))
+						require.NoError(t, err)
+						assert.Equal(t, "patched", gjson.GetBytes(actual.Traits, "stringy").String())
+						assert.Equal(t, "free", gjson.GetBytes(actual.MetadataPublic, "plan").String())
+						assert.Equal(t, 0.1, gjson.GetBytes(actual.MetadataAdmin, "fraud_score").Float())
+					})
+				}
+
+				t.Run("case=queues an asynchronous web hook in the transaction updating the identity", func(t *testing.T) {
+					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+					webHook := config.SelfServiceHook{Name: "web_hook", Config: []byte(`{"url":"https://www.ory.sh/","method":"POST","body":"base64://ZnVuY3Rpb24oY3R4KSB7fQ==","response":{"ignore":true}}`)}
+					countDeliveries := func(t *testing.T) int64 {
+						_, total, err := reg.WebHookPersister().ListDeliveries(context.Background(), "", 1, 1)
+						require.NoError(t, err)
+						return total
+					}
+					before := countDeliveries(t)
+
+					viperSetPost(strategy, []config.SelfServiceHook{webHook, {Name: "err", Config: []byte(`{"ExecuteSettingsTransactionalHook": "err"}`)}})
+					res, body := makeRequestPost(t, newServer(t, flow.TypeAPI), true, url.Values{})
+					assert.NotEqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+					assert.Equal(t, before, countDeliveries(t), "the delivery must be rolled back together with the identity")
+
+					viperSetPost(strategy, []config.SelfServiceHook{webHook})
+					res, body = makeRequestPost(t, newServer(t, flow.TypeAPI), true, url.Values{})
+					require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+					assert.Equal(t, before+1, countDeliveries(t))
+				})
+
 				t.Run("case=pass without hooks for browser flow with application/json", func(t *testing.T) {
 					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
 
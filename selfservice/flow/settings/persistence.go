@@ -2,6 +2,7 @@ package settings
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -11,6 +12,8 @@ type (
 		CreateSettingsFlow(context.Context, *Flow) error
 		GetSettingsFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
 		UpdateSettingsFlow(context.Context, *Flow) error
+		// DeleteExpiredSettingsFlows deletes at most limit settings flows which expired before the given time.
+		DeleteExpiredSettingsFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 	FlowPersistenceProvider interface {
 		SettingsFlowPersister() FlowPersister
@@ -2,6 +2,7 @@ package verification
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -14,5 +15,7 @@ type (
 		CreateVerificationFlow(context.Context, *Flow) error
 		GetVerificationFlow(ctx context.Context, id uuid.UUID) (*Flow, error)
 		UpdateVerificationFlow(context.Context, *Flow) error
+		// DeleteExpiredVerificationFlows deletes at most limit verification flows which expired before the given time.
+		DeleteExpiredVerificationFlows(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 )
@@ -19,15 +19,17 @@ import (
 )
 
 var (
-	_ registration.PostHookPrePersistExecutor  = new(Error)
-	_ registration.PostHookPostPersistExecutor = new(Error)
-	_ registration.PreHookExecutor             = new(Error)
+	_ registration.PostHookPrePersistExecutor    = new(Error)
+	_ registration.PostHookPostPersistExecutor   = new(Error)
+	_ registration.PostHookTransactionalExecutor = new(Error)
+	_ registration.PreHookExecutor               = new(Error)
 
 	_ login.PreHookExecutor  = new(Error)
 	_ login.PostHookExecutor = new(Error)
 
-	_ settings.PostHookPostPersistExecutor = new(Error)
-	_ settings.PostHookPrePersistExecutor  = new(Error)
+	_ settings.PostHookPostPersistExecutor   = new(Error)
+	_ settings.PostHookPrePersistExecutor    = new(Error)
+	_ settings.PostHookTransactionalExecutor = new(Error)
 )
 
 type Error struct {
@@ -52,6 +54,10 @@ func (e Error) ExecuteSettingsPostPersistHook(w http.ResponseWriter, r *http.Req
 	return e.err("ExecuteSettingsPostPersistHook", settings.ErrHookAbortRequest)
 }
 
+func (e Error) ExecuteSettingsTransactionalHook(r *http.Request, a *settings.Flow, s *identity.Identity) error {
+	return e.err("ExecuteSettingsTransactionalHook", settings.ErrHookAbortRequest)
+}
+
 func (e Error) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, a *login.Flow, s *session.Session) error {
 	return e.err("ExecuteLoginPostHook", login.ErrHookAbortFlow)
 }
@@ -68,6 +74,10 @@ func (e Error) ExecutePostRegistrationPostPersistHook(w http.ResponseWriter, r *
 	return e.err("ExecutePostRegistrationPostPersistHook", registration.ErrHookAbortFlow)
 }
 
+func (e Error) ExecutePostRegistrationTransactionalHook(r *http.Request, a *registration.Flow, i *identity.Identity) error {
+	return e.err("ExecutePostRegistrationTransactionalHook", registration.ErrHookAbortFlow)
+}
+
 func (e Error) ExecutePostRegistrationPrePersistHook(w http.ResponseWriter, r *http.Request, a *registration.Flow, i *identity.Identity) error {
 	return e.err("ExecutePostRegistrationPrePersistHook", registration.ErrHookAbortFlow)
 }
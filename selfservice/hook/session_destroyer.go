@@ -3,6 +3,7 @@ package hook
 import (
 	"net/http"
 
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 
 	"github.com/ory/kratos/selfservice/flow/login"
@@ -16,6 +17,7 @@ type (
 	sessionDestroyerDependencies interface {
 		session.ManagementProvider
 		session.PersistenceProvider
+		event.StreamProvider
 	}
 	SessionDestroyer struct {
 		r sessionDestroyerDependencies
@@ -27,15 +29,18 @@ func NewSessionDestroyer(r sessionDestroyerDependencies) *SessionDestroyer {
 }
 
 func (e *SessionDestroyer) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, _ *login.Flow, s *session.Session) error {
-	if _, err := e.r.SessionPersister().RevokeSessionsIdentityExcept(r.Context(), s.Identity.ID, s.ID); err != nil {
-		return err
-	}
-	return nil
+	return e.revokeOtherSessions(r, s)
 }
 
 func (e *SessionDestroyer) ExecutePostRecoveryHook(_ http.ResponseWriter, r *http.Request, _ *recovery.Flow, s *session.Session) error {
+	return e.revokeOtherSessions(r, s)
+}
+
+func (e *SessionDestroyer) revokeOtherSessions(r *http.Request, s *session.Session) error {
 	if _, err := e.r.SessionPersister().RevokeSessionsIdentityExcept(r.Context(), s.Identity.ID, s.ID); err != nil {
 		return err
 	}
+
+	e.r.EventStream().Emit(r.Context(), event.New(event.TypeSessionRevoked, s.Identity.ID).WithData("except_session_id", s.ID))
 	return nil
 }
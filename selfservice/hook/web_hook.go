@@ -1,16 +1,25 @@
 package hook
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/request"
+	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
@@ -18,17 +27,32 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/webhook"
 	"github.com/ory/kratos/x"
 )
 
+var _ registration.PostHookPrePersistExecutor = new(WebHook)
 var _ registration.PostHookPostPersistExecutor = new(WebHook)
+var _ registration.PostHookTransactionalExecutor = new(WebHook)
+var _ settings.PostHookPrePersistExecutor = new(WebHook)
+var _ settings.PostHookTransactionalExecutor = new(WebHook)
 var _ verification.PostHookExecutor = new(WebHook)
 var _ recovery.PostHookExecutor = new(WebHook)
 
+// maxWebHookResponseSize limits how much of a web hook's response is read when parsing it.
+const maxWebHookResponseSize = 1 << 20
+
+// sessionHeaders authenticate the user's request. They are not stored with queued deliveries, where they would
+// outlive the request they were sent with.
+var sessionHeaders = []string{"Authorization", "Cookie", "X-Session-Cookie", "X-Session-Token"}
+
 type (
 	webHookDependencies interface {
 		x.LoggingProvider
 		x.HTTPClientProvider
+		webhook.PersistenceProvider
+		cipher.Provider
 	}
 
 	templateContext struct {
@@ -43,8 +67,43 @@ type (
 		deps webHookDependencies
 		conf json.RawMessage
 	}
+
+	// webHookResponse is the response a web hook may return if `response.parse` is enabled.
+	webHookResponse struct {
+		// Messages are rendered into the flow's UI. They are only taken into account if the web hook
+		// responded with a status code of 400 or higher.
+		Messages []webHookResponseMessage `json:"messages"`
+
+		// Identity contains JSON Merge Patches (RFC 7386) which are applied to the identity's traits and
+		// metadata before it is persisted. Fields which are not set are left untouched.
+		Identity *webHookResponseIdentity `json:"identity"`
+	}
+
+	webHookResponseMessage struct {
+		InstancePtr string        `json:"instance_ptr"`
+		Messages    text.Messages `json:"messages"`
+	}
+
+	webHookResponseIdentity struct {
+		Traits         json.RawMessage `json:"traits"`
+		MetadataPublic json.RawMessage `json:"metadata_public"`
+		MetadataAdmin  json.RawMessage `json:"metadata_admin"`
+	}
 )
 
+// MarshalJSON includes the identity's admin metadata, which is otherwise omitted, because web hooks
+// are configured by the operator.
+func (t *templateContext) MarshalJSON() ([]byte, error) {
+	type localContext templateContext
+	return json.Marshal(&struct {
+		*localContext
+		Identity *identity.WithAdminMetadataInJSON `json:"identity"`
+	}{
+		localContext: (*localContext)(t),
+		Identity:     (*identity.WithAdminMetadataInJSON)(t.Identity),
+	})
+}
+
 func NewWebHook(r webHookDependencies, c json.RawMessage) *WebHook {
 	return &WebHook{deps: r, conf: c}
 }
@@ -97,7 +156,44 @@ func (e *WebHook) ExecuteRegistrationPreHook(_ http.ResponseWriter, req *http.Re
 	})
 }
 
+// ExecutePostRegistrationPrePersistHook only calls the web hook if its response should be parsed, because
+// only then the web hook can still modify the identity or abort the flow before the identity is created.
+func (e *WebHook) ExecutePostRegistrationPrePersistHook(_ http.ResponseWriter, req *http.Request, flow *registration.Flow, identity *identity.Identity) error {
+	if !e.parsesResponse() {
+		return nil
+	}
+
+	return e.executeAndParse(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       identity,
+	})
+}
+
+// ExecutePostRegistrationTransactionalHook queues the web hook if its response is ignored, so that the delivery
+// is only stored if the identity is created.
+func (e *WebHook) ExecutePostRegistrationTransactionalHook(req *http.Request, flow *registration.Flow, identity *identity.Identity) error {
+	if e.parsesResponse() || !e.ignoresResponse() {
+		return nil
+	}
+
+	return e.enqueue(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       identity,
+	})
+}
+
 func (e *WebHook) ExecutePostRegistrationPostPersistHook(_ http.ResponseWriter, req *http.Request, flow *registration.Flow, session *session.Session) error {
+	if e.parsesResponse() || e.ignoresResponse() {
+		// The web hook was already called before the identity was persisted or queued together with it.
+		return nil
+	}
+
 	return e.execute(req.Context(), &templateContext{
 		Flow:           flow,
 		RequestHeaders: req.Header,
@@ -107,7 +203,44 @@ func (e *WebHook) ExecutePostRegistrationPostPersistHook(_ http.ResponseWriter,
 	})
 }
 
+// ExecuteSettingsPrePersistHook only calls the web hook if its response should be parsed, because
+// only then the web hook can still modify the identity or abort the flow before the identity is updated.
+func (e *WebHook) ExecuteSettingsPrePersistHook(_ http.ResponseWriter, req *http.Request, flow *settings.Flow, identity *identity.Identity) error {
+	if !e.parsesResponse() {
+		return nil
+	}
+
+	return e.executeAndParse(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       identity,
+	})
+}
+
+// ExecuteSettingsTransactionalHook queues the web hook if its response is ignored, so that the delivery is only
+// stored if the identity is updated.
+func (e *WebHook) ExecuteSettingsTransactionalHook(req *http.Request, flow *settings.Flow, identity *identity.Identity) error {
+	if e.parsesResponse() || !e.ignoresResponse() {
+		return nil
+	}
+
+	return e.enqueue(req.Context(), &templateContext{
+		Flow:           flow,
+		RequestHeaders: req.Header,
+		RequestMethod:  req.Method,
+		RequestUrl:     req.RequestURI,
+		Identity:       identity,
+	})
+}
+
 func (e *WebHook) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, req *http.Request, flow *settings.Flow, identity *identity.Identity) error {
+	if e.parsesResponse() || e.ignoresResponse() {
+		// The web hook was already called before the identity was persisted or queued together with it.
+		return nil
+	}
+
 	return e.execute(req.Context(), &templateContext{
 		Flow:           flow,
 		RequestHeaders: req.Header,
@@ -117,44 +250,162 @@ func (e *WebHook) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, req *htt
 	})
 }
 
-func (e *WebHook) execute(ctx context.Context, data *templateContext) error {
+func (e *WebHook) parsesResponse() bool {
+	return gjson.GetBytes(e.conf, "response.parse").Bool()
+}
+
+func (e *WebHook) ignoresResponse() bool {
+	return gjson.GetBytes(e.conf, "response.ignore").Bool()
+}
+
+// buildRequest renders the web hook's request. It returns nil if the template canceled the request.
+func (e *WebHook) buildRequest(ctx context.Context, data *templateContext) (*retryablehttp.Request, error) {
 	builder, err := request.NewBuilder(e.conf, e.deps.HTTPClient(ctx), e.deps.Logger())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := builder.BuildRequest(data)
 	if errors.Is(err, request.ErrCancel) {
-		return nil
+		return nil, nil
 	} else if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// executeAndParse calls the web hook synchronously and parses its response. Messages returned
+// together with an error status code abort the flow as validation errors, and identity fields returned
+// with a successful status code are applied to the identity in the template context.
+func (e *WebHook) executeAndParse(ctx context.Context, data *templateContext) error {
+	req, err := e.buildRequest(ctx, data)
+	if err != nil || req == nil {
 		return err
 	}
 
-	errChan := make(chan error, 1)
-	go func() {
-		defer close(errChan)
+	resp, err := e.deps.HTTPClient(ctx).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		resp, err := e.deps.HTTPClient(ctx).Do(req)
-		if err != nil {
-			errChan <- err
-			return
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxWebHookResponseSize))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var parsed webHookResponse
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			if resp.StatusCode >= http.StatusBadRequest {
+				return fmt.Errorf("web hook failed with status code %v", resp.StatusCode)
+			}
+			return errors.Wrap(err, "unable to parse web hook response")
 		}
+	}
 
-		if resp.StatusCode >= http.StatusBadRequest {
-			errChan <- fmt.Errorf("web hook failed with status code %v", resp.StatusCode)
-			return
+	if resp.StatusCode >= http.StatusBadRequest {
+		if len(parsed.Messages) == 0 {
+			return fmt.Errorf("web hook failed with status code %v", resp.StatusCode)
 		}
 
-		errChan <- nil
-	}()
+		var validationErr schema.ValidationListError
+		for _, msg := range parsed.Messages {
+			for k := range msg.Messages {
+				if msg.Messages[k].Type == "" {
+					msg.Messages[k].Type = text.Error
+				}
+			}
+			validationErr.WithError(msg.InstancePtr, "a web hook rejected the request", msg.Messages)
+		}
+		return errors.WithStack(&validationErr)
+	}
 
-	if gjson.GetBytes(e.conf, "response.ignore").Bool() {
-		go func() {
-			err := <-errChan
-			e.deps.Logger().WithError(err).Warning("A web hook request failed but the error was ignored because the configuration indicated that the upstream response should be ignored.")
-		}()
-		return nil
+	if parsed.Identity != nil && data.Identity != nil {
+		traits, err := mergePatch(json.RawMessage(data.Identity.Traits), parsed.Identity.Traits)
+		if err != nil {
+			return err
+		}
+		metadataPublic, err := mergePatch(json.RawMessage(data.Identity.MetadataPublic), parsed.Identity.MetadataPublic)
+		if err != nil {
+			return err
+		}
+		metadataAdmin, err := mergePatch(json.RawMessage(data.Identity.MetadataAdmin), parsed.Identity.MetadataAdmin)
+		if err != nil {
+			return err
+		}
+
+		data.Identity.Traits = identity.Traits(traits)
+		data.Identity.MetadataPublic = sqlxx.NullJSONRawMessage(metadataPublic)
+		data.Identity.MetadataAdmin = sqlxx.NullJSONRawMessage(metadataAdmin)
+	}
+
+	return nil
+}
+
+// mergePatch applies the JSON Merge Patch to the document. The document is returned as is if the patch is empty.
+func mergePatch(doc, patch json.RawMessage) (json.RawMessage, error) {
+	if len(patch) == 0 {
+		return doc, nil
+	}
+	if len(bytes.TrimSpace(doc)) == 0 || bytes.Equal(bytes.TrimSpace(doc), []byte("null")) {
+		doc = json.RawMessage("{}")
+	}
+
+	patched, err := jsonpatch.MergePatch(doc, patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to apply the identity patch of the web hook response")
+	}
+	return patched, nil
+}
+
+func (e *WebHook) execute(ctx context.Context, data *templateContext) error {
+	if e.ignoresResponse() {
+		return e.enqueue(ctx, data)
+	}
+
+	req, err := e.buildRequest(ctx, data)
+	if err != nil || req == nil {
+		return err
+	}
+
+	resp, err := e.deps.HTTPClient(ctx).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("web hook failed with status code %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// enqueue writes a web hook whose response is ignored to the outbox, from where it is delivered and retried
+// in the background. If the context carries a transaction, such as the one creating or updating the identity,
+// the delivery is written in it. The request is rendered by the worker so that authentication, such as
+// signatures and access tokens, is fresh on every attempt.
+func (e *WebHook) enqueue(ctx context.Context, data *templateContext) error {
+	stored := *data
+	stored.RequestHeaders = data.RequestHeaders.Clone()
+	for _, h := range sessionHeaders {
+		stored.RequestHeaders.Del(h)
+	}
+	if data.Identity != nil {
+		stored.Identity = data.Identity.CopyWithoutCredentials()
+	}
+
+	payload, err := json.Marshal(&stored)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	d, err := webhook.NewDelivery(ctx, e.deps.Cipher(), e.conf, payload)
+	if err != nil {
+		return err
 	}
 
-	return <-errChan
+	return e.deps.WebHookPersister().AddDelivery(ctx, d)
 }
@@ -1,6 +1,7 @@
 package hook_test
 
 import (
+	"context"
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
@@ -14,10 +15,16 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/container"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/webhook"
 
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
@@ -28,6 +35,8 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 
+	"github.com/ory/x/sqlxx"
+
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
 
@@ -328,6 +337,31 @@ func TestWebHooks(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("must include the identity metadata", func(t *testing.T) {
+		whr := &WebHookRequest{}
+		ts := newServer(webHookEndPoint(whr))
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		f := &login.Flow{ID: x.NewUUID()}
+		s := &session.Session{ID: x.NewUUID(), Identity: &identity.Identity{
+			ID:             x.NewUUID(),
+			MetadataPublic: sqlxx.NullJSONRawMessage(`{"plan":"free"}`),
+			MetadataAdmin:  sqlxx.NullJSONRawMessage(`{"fraud_score":0.1}`),
+		}}
+		conf := json.RawMessage(fmt.Sprintf(`{
+					"url": "%s",
+					"method": "%s",
+					"body": "%s"
+				}`, ts.URL+path, "POST", "./stub/metadata_body.jsonnet"))
+		wh := hook.NewWebHook(reg, conf)
+
+		require.NoError(t, wh.ExecuteLoginPostHook(nil, req, f, s))
+		assert.JSONEq(t, `{"metadata_public":{"plan":"free"},"metadata_admin":{"fraud_score":0.1}}`, whr.Body)
+	})
+
 	t.Run("must not make request", func(t *testing.T) {
 		req := &http.Request{
 			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
@@ -354,7 +388,7 @@ func TestWebHooks(t *testing.T) {
 		}
 	}
 
-	t.Run("ignores the response and is async", func(t *testing.T) {
+	t.Run("ignores the response and is delivered from the outbox", func(t *testing.T) {
 		var wg sync.WaitGroup
 		wg.Add(1)
 		waitTime := time.Millisecond * 100
@@ -378,7 +412,184 @@ func TestWebHooks(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, time.Since(start) < waitTime)
 
+		deliveries, _, err := reg.WebHookPersister().ListDeliveries(context.Background(), webhook.DeliveryStatusQueued, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, ts.URL+path, deliveries[0].URL)
+		assert.Equal(t, "GET", deliveries[0].Method)
+
+		require.NoError(t, reg.WebHookWorker().DispatchQueue(context.Background()))
 		wg.Wait()
+
+		actual, err := reg.WebHookPersister().FetchDelivery(context.Background(), deliveries[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status, "the failed delivery must be retried")
+		assert.Equal(t, 1, actual.SendCount)
+		assert.Equal(t, http.StatusBadRequest, actual.LastResponseCode)
+	})
+
+	t.Run("stores queued deliveries encrypted and without credentials", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		conf.MustSet(config.ViperKeySecretsCipher, []string{"secret-thirty-two-character-long"})
+		conf.MustSet(config.ViperKeyCipherAlgorithm, "xchacha20-poly1305")
+
+		req := &http.Request{
+			Header: map[string][]string{
+				"Some-Header":     {"Some-Value"},
+				"Cookie":          {"ory_kratos_session=some-session"},
+				"Authorization":   {"Bearer some-token"},
+				"X-Session-Token": {"some-token"},
+			},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+			Type:        identity.CredentialsTypePassword,
+			Identifiers: []string{"foo@ory.sh"},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"some-hash"}`),
+		})
+		s := &session.Session{ID: x.NewUUID(), Identity: i}
+		whConf := json.RawMessage(`{"url": "https://www.ory.sh/", "method": "POST", "body": "./stub/test_body.jsonnet", "auth": {"type": "api_key", "config": {"name": "My-Key", "value": "some-api-key", "in": "header"}}, "response": {"ignore": true}}`)
+		require.NoError(t, hook.NewWebHook(reg, whConf).ExecuteLoginPostHook(nil, req, &login.Flow{ID: x.NewUUID()}, s))
+
+		deliveries, _, err := reg.WebHookPersister().ListDeliveries(context.Background(), webhook.DeliveryStatusQueued, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		d, err := reg.WebHookPersister().FetchDelivery(context.Background(), deliveries[0].ID)
+		require.NoError(t, err)
+		assert.NotContains(t, d.Config, "some-api-key")
+		assert.NotContains(t, d.Payload, "Some-Value")
+
+		actualConf, err := reg.Cipher().Decrypt(context.Background(), d.Config)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(whConf), string(actualConf))
+
+		payload, err := reg.Cipher().Decrypt(context.Background(), d.Payload)
+		require.NoError(t, err)
+		assert.Equal(t, "Some-Value", gjson.GetBytes(payload, "request_headers.Some-Header.0").String())
+		for _, h := range []string{"Cookie", "Authorization", "X-Session-Token"} {
+			assert.False(t, gjson.GetBytes(payload, "request_headers."+h).Exists(), "%s", h)
+		}
+		assert.Equal(t, i.ID.String(), gjson.GetBytes(payload, "identity.id").String())
+		assert.False(t, gjson.GetBytes(payload, "identity.credentials").Exists())
+		assert.Len(t, i.Credentials, 1, "the identity must not be modified")
+	})
+
+	t.Run("parses the response", func(t *testing.T) {
+		webHookResponseEndPoint := func(code int, body string, called *bool) httprouter.Handle {
+			return func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+				*called = true
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(body))
+			}
+		}
+
+		req := &http.Request{
+			Header:     map[string][]string{"Some-Header": {"Some-Value"}},
+			RequestURI: "https://www.ory.sh/some_end_point",
+			Method:     http.MethodPost,
+		}
+		newConf := func(ts *httptest.Server, parse bool) json.RawMessage {
+			return json.RawMessage(fmt.Sprintf(`{"url": "%s", "method": "POST", "body": "./stub/test_body.jsonnet", "response": {"parse": %t}}`, ts.URL+path, parse))
+		}
+		newIdentity := func() *identity.Identity {
+			return &identity.Identity{ID: x.NewUUID(), Traits: identity.Traits(`{"email":"parse@ory.sh"}`)}
+		}
+
+		t.Run("case=renders messages into the flow", func(t *testing.T) {
+			var called bool
+			ts := newServer(webHookResponseEndPoint(http.StatusConflict, `{"messages":[{"instance_ptr":"#/traits/invite_code","messages":[{"id":4000100,"text":"The invitation code is invalid.","context":{"reason":"expired"}}]},{"instance_ptr":"#/","messages":[{"id":4000101,"text":"Please try again later.","type":"error"}]}]}`, &called))
+			f := &registration.Flow{ID: x.NewUUID(), UI: container.New("")}
+
+			err := hook.NewWebHook(reg, newConf(ts, true)).ExecutePostRegistrationPrePersistHook(nil, req, f, newIdentity())
+			require.True(t, called)
+
+			var validationErr *schema.ValidationListError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Validations, 2)
+
+			require.NoError(t, f.UI.ParseError(node.DefaultGroup, err))
+			n := f.UI.Nodes.Find("traits.invite_code")
+			require.NotNil(t, n)
+			require.Len(t, n.Messages, 1)
+			assert.Equal(t, "The invitation code is invalid.", n.Messages[0].Text)
+			assert.Equal(t, text.Error, n.Messages[0].Type)
+			assert.JSONEq(t, `{"reason":"expired"}`, string(n.Messages[0].Context))
+			require.Len(t, f.UI.Messages, 1)
+			assert.Equal(t, text.ID(4000101), f.UI.Messages[0].ID)
+		})
+
+		t.Run("case=fails without messages", func(t *testing.T) {
+			var called bool
+			ts := newServer(webHookResponseEndPoint(http.StatusForbidden, `not json`, &called))
+
+			err := hook.NewWebHook(reg, newConf(ts, true)).ExecuteSettingsPrePersistHook(nil, req, &settings.Flow{ID: x.NewUUID()}, newIdentity())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "403")
+		})
+
+		for _, tc := range []struct {
+			uc          string
+			callWebHook func(wh *hook.WebHook, i *identity.Identity) error
+		}{
+			{
+				uc: "registration",
+				callWebHook: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecutePostRegistrationPrePersistHook(nil, req, &registration.Flow{ID: x.NewUUID()}, i)
+				},
+			},
+			{
+				uc: "settings",
+				callWebHook: func(wh *hook.WebHook, i *identity.Identity) error {
+					return wh.ExecuteSettingsPrePersistHook(nil, req, &settings.Flow{ID: x.NewUUID()}, i)
+				},
+			},
+		} {
+			t.Run("case=applies identity patches in "+tc.uc, func(t *testing.T) {
+				var called bool
+				ts := newServer(webHookResponseEndPoint(http.StatusOK, `{"identity":{"traits":{"email":"patched@ory.sh","invite_code":null},"metadata_admin":{"fraud_score":0.9}}}`, &called))
+				i := newIdentity()
+				i.Traits = identity.Traits(`{"email":"parse@ory.sh","name":"Parse","invite_code":"abc"}`)
+				i.MetadataPublic = sqlxx.NullJSONRawMessage(`{"plan":"free"}`)
+				i.MetadataAdmin = sqlxx.NullJSONRawMessage(`{"source":"import"}`)
+
+				require.NoError(t, tc.callWebHook(hook.NewWebHook(reg, newConf(ts, true)), i))
+				require.True(t, called)
+				assert.JSONEq(t, `{"email":"patched@ory.sh","name":"Parse"}`, string(i.Traits), "traits are patched")
+				assert.JSONEq(t, `{"source":"import","fraud_score":0.9}`, string(i.MetadataAdmin), "metadata is patched")
+				assert.JSONEq(t, `{"plan":"free"}`, string(i.MetadataPublic), "fields which are not returned are left untouched")
+			})
+
+			t.Run("case=ignores empty response in "+tc.uc, func(t *testing.T) {
+				var called bool
+				ts := newServer(webHookResponseEndPoint(http.StatusNoContent, ``, &called))
+				i := newIdentity()
+
+				require.NoError(t, tc.callWebHook(hook.NewWebHook(reg, newConf(ts, true)), i))
+				require.True(t, called)
+				assert.JSONEq(t, `{"email":"parse@ory.sh"}`, string(i.Traits))
+			})
+
+			t.Run("case=is not called before persisting without parse in "+tc.uc, func(t *testing.T) {
+				var called bool
+				ts := newServer(webHookResponseEndPoint(http.StatusBadRequest, ``, &called))
+
+				require.NoError(t, tc.callWebHook(hook.NewWebHook(reg, newConf(ts, false)), newIdentity()))
+				assert.False(t, called)
+			})
+		}
+
+		t.Run("case=is not called after persisting with parse", func(t *testing.T) {
+			var called bool
+			ts := newServer(webHookResponseEndPoint(http.StatusBadRequest, ``, &called))
+			wh := hook.NewWebHook(reg, newConf(ts, true))
+			s := &session.Session{ID: x.NewUUID(), Identity: newIdentity()}
+
+			require.NoError(t, wh.ExecutePostRegistrationPostPersistHook(nil, req, &registration.Flow{ID: x.NewUUID()}, s))
+			require.NoError(t, wh.ExecuteSettingsPostPersistHook(nil, req, &settings.Flow{ID: x.NewUUID()}, s.Identity))
+			assert.False(t, called)
+		})
 	})
 
 	for _, tc := range []struct {
@@ -0,0 +1,30 @@
+package code
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ory/x/randx"
+)
+
+func TestRandomCodeGenerator(t *testing.T) {
+	g := NewRandomCodeGenerator()
+
+	for _, tc := range []struct {
+		length   int
+		alphabet []rune
+		pattern  string
+	}{
+		{length: 6, alphabet: randx.Numeric, pattern: "^[0-9]{6}$"},
+		{length: 8, alphabet: randx.Numeric, pattern: "^[0-9]{8}$"},
+		{length: 7, alphabet: randx.AlphaUpperNum, pattern: "^[0-9A-Z]{7}$"},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			assert.Regexp(t, tc.pattern, g.Generate(tc.length, tc.alphabet))
+		})
+	}
+
+	assert.Equal(t, randx.AlphaUpperNum, codeAlphabet("alphanumeric"))
+	assert.Equal(t, randx.Numeric, codeAlphabet("numeric"))
+}
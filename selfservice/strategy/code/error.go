@@ -6,6 +6,7 @@ import (
 	"github.com/ory/kratos/text"
 	"github.com/pkg/errors"
 	"net/http"
+	"time"
 )
 
 type ValidationErrorContextCodePolicyViolation struct {
@@ -40,6 +41,47 @@ func NewCodeSentError() error {
 		}}
 }
 
+// CodeRateLimitError is returned if no further code may be sent yet.
+type CodeRateLimitError struct {
+	*schema.ValidationError
+}
+
+func (e CodeRateLimitError) Error() string {
+	return e.ValidationError.Error()
+}
+
+func (e CodeRateLimitError) Unwrap() error {
+	return e.ValidationError
+}
+
+func (e CodeRateLimitError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+func newCodeRateLimitError(message string, m *text.Message) error {
+	return errors.WithStack(CodeRateLimitError{
+		ValidationError: &schema.ValidationError{
+			ValidationError: &jsonschema.ValidationError{
+				Message:     message,
+				InstancePtr: "#/",
+				Context:     &ValidationErrorContextCodePolicyViolation{},
+			},
+			Messages: new(text.Messages).Add(m),
+		}})
+}
+
+func NewResendTooSoonError(wait time.Duration) error {
+	return newCodeRateLimitError(`a code has just been sent`, text.NewErrorValidationCodeResendTooSoon(wait))
+}
+
+func NewSendLimitExceededError(wait time.Duration) error {
+	return newCodeRateLimitError(`too many codes have been sent to this address`, text.NewErrorValidationCodeSendLimitExceeded(wait))
+}
+
+func NewFlowSendLimitExceededError() error {
+	return newCodeRateLimitError(`too many codes have been sent within this flow`, text.NewErrorValidationCodeFlowSendLimitExceeded())
+}
+
 func (r *ValidationErrorContextCodePolicyViolation) AddContext(_, _ string) {}
 
 func (r *ValidationErrorContextCodePolicyViolation) FinishInstanceContext() {}
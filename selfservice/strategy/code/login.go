@@ -2,6 +2,8 @@ package code
 
 import (
 	"context"
+	"github.com/gofrs/uuid"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -14,6 +16,7 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/pkg/errors"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -53,7 +56,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 			return nil, s.handleLoginError(w, r, f, &p, err)
 		}
 		if i != nil {
-			err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, p.Identifier)
+			err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, i, codeAddressType(i, p.Identifier), p.Identifier)
 			if err != nil {
 				return nil, s.handleLoginError(w, r, f, &p, err)
 			}
@@ -74,7 +77,7 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 			return nil, s.handleLoginError(w, r, f, &p, NewInvalidCodeError())
 		}
 
-		if err := s.updateVerifiableAddress(r.Context(), code.Identifier, i); err != nil {
+		if err := s.updateVerifiableAddress(r.Context(), f.ID, code.Identifier, i); err != nil {
 			return nil, s.handleLoginError(w, r, f, &p, err)
 		}
 
@@ -82,19 +85,13 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 }
 
-func (s *Strategy) updateVerifiableAddress(context context.Context, identifier string, i *identity.Identity) error {
-	var address *identity.VerifiableAddress = nil
-	for index, a := range i.VerifiableAddresses {
-		if a.Value == identifier && a.Via == identity.VerifiableAddressTypePhone {
-			address = &i.VerifiableAddresses[index]
-			break
-		}
-	}
-
+func (s *Strategy) updateVerifiableAddress(context context.Context, flowID uuid.UUID, identifier string, i *identity.Identity) error {
+	address := findCodeAddress(i, identifier)
 	if address == nil {
 		return errors.New("verifiable address not found for identity")
 	}
 
+	wasVerified := address.Verified
 	address.Verified = true
 	verifiedAt := sqlxx.NullTime(time.Now().UTC())
 	address.VerifiedAt = &verifiedAt
@@ -103,9 +100,32 @@ func (s *Strategy) updateVerifiableAddress(context context.Context, identifier s
 		return err
 	}
 
+	if !wasVerified {
+		s.d.EventStream().Emit(context, event.NewAddressVerified(address.IdentityID, flowID, address.Value, string(address.Via)))
+	}
+
 	return nil
 }
 
+// findCodeAddress returns the verifiable address of the identity matching the code identifier.
+func findCodeAddress(i *identity.Identity, identifier string) *identity.VerifiableAddress {
+	for index, a := range i.VerifiableAddresses {
+		if strings.EqualFold(a.Value, identifier) {
+			return &i.VerifiableAddresses[index]
+		}
+	}
+	return nil
+}
+
+// codeAddressType returns the channel codes for the identifier are sent through. It follows the type of the
+// matching verifiable address and falls back to SMS for identities created before codes could be sent by email.
+func codeAddressType(i *identity.Identity, identifier string) identity.VerifiableAddressType {
+	if address := findCodeAddress(i, identifier); address != nil {
+		return address.Via
+	}
+	return identity.VerifiableAddressTypePhone
+}
+
 func (s *Strategy) findByCredentialsIdentifier(context context.Context, identifier string) (*identity.Identity, error) {
 	i, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(context, s.ID(), identifier)
 	if errors.Is(errors.Cause(err), sqlcon.ErrNoRows) {
@@ -122,7 +142,7 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 		return nil
 	}
 
-	// This block adds the identifier (i.e. phone) to the method when the request is forced - as a hint for the user.
+	// This block adds the identifier (i.e. phone or email) to the method when the request is forced - as a hint for the user.
 	var identifier string
 	if !l.IsForced() {
 		// do nothing
@@ -139,7 +159,7 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.Au
 	}
 
 	l.UI.SetNode(node.NewInputField("identifier", identifier, node.CodeGroup,
-		node.InputAttributeTypePhone, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
+		node.InputAttributeTypeText, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeLabelID()))
 
 	if l.Type != flow.TypeBrowser {
 		return nil
@@ -34,15 +34,15 @@ func (m *MockRandomCodeGenerator) EXPECT() *MockRandomCodeGeneratorMockRecorder
 }
 
 // Generate mocks base method.
-func (m *MockRandomCodeGenerator) Generate(arg0 int) string {
+func (m *MockRandomCodeGenerator) Generate(arg0 int, arg1 []int32) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", arg0)
+	ret := m.ctrl.Call(m, "Generate", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockRandomCodeGeneratorMockRecorder) Generate(arg0 interface{}) *gomock.Call {
+func (mr *MockRandomCodeGeneratorMockRecorder) Generate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRandomCodeGenerator)(nil).Generate), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockRandomCodeGenerator)(nil).Generate), arg0, arg1)
 }
@@ -37,6 +37,35 @@ func (m *MockCodePersister) EXPECT() *MockCodePersisterMockRecorder {
 	return m.recorder
 }
 
+// CompareCode mocks base method.
+func (m *MockCodePersister) CompareCode(arg0 context.Context, arg1 *code.Code, arg2 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CompareCode indicates an expected call of CompareCode.
+func (mr *MockCodePersisterMockRecorder) CompareCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareCode", reflect.TypeOf((*MockCodePersister)(nil).CompareCode), arg0, arg1, arg2)
+}
+
+// CountCodesByFlow mocks base method.
+func (m *MockCodePersister) CountCodesByFlow(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCodesByFlow", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCodesByFlow indicates an expected call of CountCodesByFlow.
+func (mr *MockCodePersisterMockRecorder) CountCodesByFlow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCodesByFlow", reflect.TypeOf((*MockCodePersister)(nil).CountCodesByFlow), arg0, arg1)
+}
+
 // CreateCode mocks base method.
 func (m *MockCodePersister) CreateCode(arg0 context.Context, arg1 *code.Code) error {
 	m.ctrl.T.Helper()
@@ -51,6 +80,20 @@ func (mr *MockCodePersisterMockRecorder) CreateCode(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCode", reflect.TypeOf((*MockCodePersister)(nil).CreateCode), arg0, arg1)
 }
 
+// DeleteCode mocks base method.
+func (m *MockCodePersister) DeleteCode(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCode indicates an expected call of DeleteCode.
+func (mr *MockCodePersisterMockRecorder) DeleteCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCode", reflect.TypeOf((*MockCodePersister)(nil).DeleteCode), arg0, arg1)
+}
+
 // DeleteCodes mocks base method.
 func (m *MockCodePersister) DeleteCodes(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -65,6 +108,35 @@ func (mr *MockCodePersisterMockRecorder) DeleteCodes(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCodes", reflect.TypeOf((*MockCodePersister)(nil).DeleteCodes), arg0, arg1)
 }
 
+// DeleteExpiredCodes mocks base method.
+func (m *MockCodePersister) DeleteExpiredCodes(arg0 context.Context, arg1 time.Time, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredCodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredCodes indicates an expected call of DeleteExpiredCodes.
+func (mr *MockCodePersisterMockRecorder) DeleteExpiredCodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCodes", reflect.TypeOf((*MockCodePersister)(nil).DeleteExpiredCodes), arg0, arg1, arg2)
+}
+
+// ExpireCodes mocks base method.
+func (m *MockCodePersister) ExpireCodes(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireCodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireCodes indicates an expected call of ExpireCodes.
+func (mr *MockCodePersisterMockRecorder) ExpireCodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireCodes", reflect.TypeOf((*MockCodePersister)(nil).ExpireCodes), arg0, arg1, arg2)
+}
+
 // FindActiveCode mocks base method.
 func (m *MockCodePersister) FindActiveCode(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (*code.Code, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +151,33 @@ func (mr *MockCodePersisterMockRecorder) FindActiveCode(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveCode", reflect.TypeOf((*MockCodePersister)(nil).FindActiveCode), arg0, arg1, arg2)
 }
+
+// IncrementCodeAttempts mocks base method.
+func (m *MockCodePersister) IncrementCodeAttempts(arg0 context.Context, arg1 uuid.UUID, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementCodeAttempts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementCodeAttempts indicates an expected call of IncrementCodeAttempts.
+func (mr *MockCodePersisterMockRecorder) IncrementCodeAttempts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCodeAttempts", reflect.TypeOf((*MockCodePersister)(nil).IncrementCodeAttempts), arg0, arg1, arg2)
+}
+
+// ListCodesByIdentifier mocks base method.
+func (m *MockCodePersister) ListCodesByIdentifier(arg0 context.Context, arg1 string, arg2 time.Time) ([]code.Code, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodesByIdentifier", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]code.Code)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCodesByIdentifier indicates an expected call of ListCodesByIdentifier.
+func (mr *MockCodePersisterMockRecorder) ListCodesByIdentifier(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodesByIdentifier", reflect.TypeOf((*MockCodePersister)(nil).ListCodesByIdentifier), arg0, arg1, arg2)
+}
@@ -34,6 +34,20 @@ func (m *MockFlow) EXPECT() *MockFlowMockRecorder {
 	return m.recorder
 }
 
+// GetAcceptLanguage mocks base method.
+func (m *MockFlow) GetAcceptLanguage() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcceptLanguage")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAcceptLanguage indicates an expected call of GetAcceptLanguage.
+func (mr *MockFlowMockRecorder) GetAcceptLanguage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptLanguage", reflect.TypeOf((*MockFlow)(nil).GetAcceptLanguage))
+}
+
 // GetID mocks base method.
 func (m *MockFlow) GetID() uuid.UUID {
 	m.ctrl.T.Helper()
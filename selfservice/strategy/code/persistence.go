@@ -9,12 +9,30 @@ import (
 )
 
 type CodePersister interface {
+	// CreateCode stores the code. The code value is stored as an HMAC and never in plain text.
 	CreateCode(ctx context.Context, code *Code) error
 
+	// CompareCode reports, in constant time, whether value matches the stored code.
+	CompareCode(ctx context.Context, code *Code, value string) bool
+
 	// FindActiveCode selects code by login flow id and expiration date/time.
 	FindActiveCode(ctx context.Context, flowId uuid.UUID, expiresAfter time.Time) (*Code, error)
+	// IncrementCodeAttempts counts a verification attempt of the code unless it was already verified max times.
+	// It reports whether the attempt may be made.
+	IncrementCodeAttempts(ctx context.Context, id uuid.UUID, max int) (bool, error)
+	// DeleteCode deletes the code with the given ID.
+	DeleteCode(ctx context.Context, id uuid.UUID) error
 	// DeleteCodes deletes all codes with the given identifier
 	DeleteCodes(ctx context.Context, identifier string) error
+	// ExpireCodes lets all codes with the given identifier expire at the given time. Expired codes can no longer be
+	// used but still count towards the send limits.
+	ExpireCodes(ctx context.Context, identifier string, expiresAt time.Time) error
+	// ListCodesByIdentifier returns the codes sent to the identifier after the given time, oldest first.
+	ListCodesByIdentifier(ctx context.Context, identifier string, createdAfter time.Time) ([]Code, error)
+	// CountCodesByFlow returns the number of codes sent within the flow.
+	CountCodesByFlow(ctx context.Context, flowID uuid.UUID) (int, error)
+	// DeleteExpiredCodes deletes at most limit codes which expired before the given time.
+	DeleteExpiredCodes(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 }
 
 type CodePersistenceProvider interface {
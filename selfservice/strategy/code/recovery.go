@@ -0,0 +1,277 @@
+package code
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gofrs/uuid"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
+	"github.com/pkg/errors"
+)
+
+func (s *Strategy) RecoveryStrategyID() string {
+	return recovery.StrategyRecoveryCodeName
+}
+
+func (s *Strategy) RecoveryNodeGroup() node.Group {
+	return node.CodeGroup
+}
+
+func (s *Strategy) PopulateRecoveryMethod(r *http.Request, f *recovery.Flow) error {
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.GetNodes().Upsert(
+		node.NewInputField("phone", nil, node.CodeGroup, node.InputAttributeTypePhone, node.WithRequiredInputAttribute).WithMetaLabel(text.NewInfoNodeInputPhone()),
+	)
+	f.UI.GetNodes().Append(node.NewInputField("method", s.RecoveryStrategyID(), node.CodeGroup, node.InputAttributeTypeSubmit).WithMetaLabel(text.NewInfoNodeLabelSubmit()))
+	return nil
+}
+
+// swagger:model submitSelfServiceRecoveryFlowWithCodeMethodBody
+// nolint:deadcode,unused
+type submitSelfServiceRecoveryFlowWithCodeMethodBody struct {
+	// Phone to Recover
+	//
+	// Needs to be set when initiating the flow. If the phone number is a registered
+	// recovery address, a recovery code will be sent by SMS. If the phone number is not
+	// known, no message is sent.
+	Phone string `json:"phone" form:"phone"`
+
+	// Recovery Code
+	//
+	// The code sent by SMS. Needs to be set to complete the flow.
+	Code string `json:"code" form:"code"`
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CSRFToken string `form:"csrf_token" json:"csrf_token"`
+
+	// Method should be set to "code" when recovering an account using the code strategy.
+	//
+	// required: true
+	Method string `json:"method"`
+}
+
+type recoverySubmitPayload struct {
+	Method    string `json:"method" form:"method"`
+	Code      string `json:"code" form:"code"`
+	CSRFToken string `json:"csrf_token" form:"csrf_token"`
+	Flow      string `json:"flow" form:"flow"`
+	Phone     string `json:"phone" form:"phone"`
+}
+
+func (s *Strategy) Recover(w http.ResponseWriter, r *http.Request, f *recovery.Flow) (err error) {
+	body, err := s.decodeRecovery(r)
+	if err != nil {
+		return s.handleRecoveryError(w, r, nil, body, err)
+	}
+
+	if err := flow.MethodEnabledAndAllowed(r.Context(), s.RecoveryStrategyID(), body.Method, s.d); err != nil {
+		return s.handleRecoveryError(w, r, nil, body, err)
+	}
+
+	if _, err := s.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil {
+		if x.IsJSONRequest(r) {
+			session.RespondWithJSONErrorOnAuthenticated(s.d.Writer(), recovery.ErrAlreadyLoggedIn)(w, r, nil)
+		} else {
+			session.RedirectOnAuthenticated(s.d)(w, r, nil)
+		}
+		return errors.WithStack(flow.ErrCompletedByStrategy)
+	}
+
+	if err := f.Valid(); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	if err := flow.EnsureCSRF(s.d, r, f.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, body.CSRFToken); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	switch f.State {
+	case recovery.StateChooseMethod:
+		fallthrough
+	case recovery.StateEmailSent:
+		if len(body.Code) > 0 {
+			return s.recoveryUseCode(w, r, f, body)
+		}
+		return s.recoveryHandleFormSubmission(w, r, f, body)
+	case recovery.StatePassedChallenge:
+		// was already handled, do not allow retry
+		return s.handleRecoveryError(w, r, f, body, errors.New("recovery flow is not active"))
+	default:
+		return s.handleRecoveryError(w, r, f, body, errors.New("unexpected flow state"))
+	}
+}
+
+func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload) error {
+	if len(body.Phone) == 0 {
+		return s.handleRecoveryError(w, r, f, body, schema.NewRequiredError("#/phone", "phone"))
+	}
+
+	address, err := s.d.IdentityPool().FindRecoveryAddressByValue(r.Context(), identity.RecoveryAddressTypePhone, body.Phone)
+	if err == nil {
+		i, err := s.d.IdentityPool().GetIdentity(r.Context(), address.IdentityID)
+		if err != nil {
+			return s.handleRecoveryError(w, r, f, body, err)
+		}
+
+		if err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, i, identity.VerifiableAddressTypePhone, address.Value); err != nil {
+			return s.handleRecoveryError(w, r, f, body, err)
+		}
+	} else if errors.Is(err, sqlcon.ErrNoRows) {
+		// Unknown phone numbers are not disclosed: they are subject to the same send limits and get the same
+		// response, but do not receive a message.
+		if err := s.d.CodeAuthenticationService().SendCodeToUnknownRecipient(r.Context(), f, body.Phone); err != nil {
+			return s.handleRecoveryError(w, r, f, body, err)
+		}
+	} else {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+	f.UI.GetNodes().Upsert(
+		node.NewInputField("phone", body.Phone, node.CodeGroup, node.InputAttributeTypePhone, node.WithRequiredInputAttribute),
+	)
+	f.UI.GetNodes().Upsert(node.NewInputField("code", "", node.CodeGroup, node.InputAttributeTypeText))
+
+	f.Active = sqlxx.NullString(s.RecoveryNodeGroup())
+	f.State = recovery.StateEmailSent
+	f.UI.Messages.Set(text.NewRecoveryPhoneSent())
+	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) recoveryUseCode(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload) error {
+	code, err := s.d.CodeAuthenticationService().VerifyCode(r.Context(), f, body.Code)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	address, err := s.d.IdentityPool().FindRecoveryAddressByValue(r.Context(), identity.RecoveryAddressTypePhone, code.Identifier)
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return s.handleRecoveryError(w, r, f, body, NewInvalidCodeError())
+	} else if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	recovered, err := s.d.IdentityPool().GetIdentity(r.Context(), address.IdentityID)
+	if err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	// The code must not be usable a second time.
+	if err := s.d.CodePersister().ExpireCodes(r.Context(), code.Identifier, s.d.Clock().Now().UTC()); err != nil {
+		return s.handleRecoveryError(w, r, f, body, err)
+	}
+
+	// Receiving the code proves ownership of the phone number.
+	if a := findCodeAddress(recovered, code.Identifier); a != nil && !a.Verified {
+		if err := s.updateVerifiableAddress(r.Context(), f.ID, code.Identifier, recovered); err != nil {
+			return s.handleRecoveryError(w, r, f, body, err)
+		}
+	}
+
+	return s.recoveryIssueSession(w, r, f, recovered)
+}
+
+func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request, f *recovery.Flow, id *identity.Identity) error {
+	f.UI.Messages.Clear()
+	f.State = recovery.StatePassedChallenge
+	f.SetCSRFToken(s.d.CSRFHandler().RegenerateToken(w, r))
+	f.RecoveredIdentityID = uuid.NullUUID{
+		UUID:  id.ID,
+		Valid: true,
+	}
+	if err := s.d.RecoveryFlowPersister().UpdateRecoveryFlow(r.Context(), f); err != nil {
+		return err
+	}
+
+	sess, err := session.NewActiveSession(id, s.d.Config(r.Context()), s.d.Clock().Now().UTC(), identity.CredentialsTypeRecoveryCode, identity.AuthenticatorAssuranceLevel1)
+	if err != nil {
+		return err
+	}
+
+	if err := s.d.SessionManager().UpsertAndIssueCookie(r.Context(), w, r, sess); err != nil {
+		return err
+	}
+
+	sf, err := s.d.SettingsHandler().NewFlow(w, r, sess.Identity, flow.TypeBrowser)
+	if err != nil {
+		return err
+	}
+
+	// Take over `return_to` parameter from recovery flow
+	sfRequestURL, err := url.Parse(sf.RequestURL)
+	if err != nil {
+		return err
+	}
+	fRequestURL, err := url.Parse(f.RequestURL)
+	if err != nil {
+		return err
+	}
+	sfQuery := sfRequestURL.Query()
+	sfQuery.Set("return_to", fRequestURL.Query().Get("return_to"))
+	sfRequestURL.RawQuery = sfQuery.Encode()
+	sf.RequestURL = sfRequestURL.String()
+
+	if err := s.d.RecoveryExecutor().PostRecoveryHook(w, r, f, sess); err != nil {
+		return err
+	}
+
+	sf.UI.Messages.Set(text.NewRecoverySuccessful(s.d.Clock().Now().Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge())))
+	if err := s.d.SettingsFlowPersister().UpdateSettingsFlow(r.Context(), sf); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, sf.AppendTo(s.d.Config(r.Context()).SelfServiceFlowSettingsUI()).String(), http.StatusSeeOther)
+	return errors.WithStack(flow.ErrCompletedByStrategy)
+}
+
+// handleRecoveryError is a convenience function for handling all types of errors that may occur (e.g. validation error).
+func (s *Strategy) handleRecoveryError(w http.ResponseWriter, r *http.Request, f *recovery.Flow, body *recoverySubmitPayload, err error) error {
+	if f != nil {
+		phone := ""
+		if body != nil {
+			phone = body.Phone
+		}
+
+		f.UI.SetCSRF(s.d.GenerateCSRFToken(r))
+		f.UI.GetNodes().Upsert(
+			node.NewInputField("phone", phone, node.CodeGroup, node.InputAttributeTypePhone, node.WithRequiredInputAttribute),
+		)
+	}
+
+	return err
+}
+
+func (s *Strategy) decodeRecovery(r *http.Request) (*recoverySubmitPayload, error) {
+	var body recoverySubmitPayload
+
+	compiler, err := decoderx.HTTPRawJSONSchemaCompiler(recoverySchema)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := s.hd.Decode(r, &body, compiler,
+		decoderx.HTTPDecoderUseQueryAndBody(),
+		decoderx.HTTPKeepRequestBody(true),
+		decoderx.HTTPDecoderAllowedMethods("POST", "GET"),
+		decoderx.HTTPDecoderSetValidatePayloads(true),
+		decoderx.HTTPDecoderJSONFollowsFormFormat(),
+	); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &body, nil
+}
@@ -0,0 +1,200 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/kratos/courier/template/sms"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/ui/node"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/assertx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestRecovery(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/recovery.schema.json")
+	conf.MustSet(config.ViperKeySelfServiceBrowserDefaultReturnTo, "https://www.ory.sh")
+	conf.MustSet(config.ViperKeyURLsAllowedReturnToDomains, []string{"https://www.ory.sh"})
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+recovery.StrategyRecoveryCodeName+".enabled", true)
+	conf.MustSet(config.ViperKeySelfServiceRecoveryEnabled, true)
+
+	_ = testhelpers.NewRecoveryUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+
+	public, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+
+	var createIdentityToRecover = func(phone string) *identity.Identity {
+		var id = &identity.Identity{
+			ID:       x.NewUUID(),
+			Traits:   identity.Traits(`{"phone":"` + phone + `"}`),
+			SchemaID: config.DefaultIdentityTraitsSchemaID,
+		}
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), id, identity.ManagerAllowWriteProtectedTraits))
+		return id
+	}
+
+	var submit = func(t *testing.T, hc *http.Client, f *kratos.SelfServiceRecoveryFlow, values url.Values, expectedURL string) string {
+		body, res := testhelpers.RecoveryMakeRequest(t, false, f, hc, values.Encode())
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Contains(t, res.Request.URL.String(), expectedURL, "%s", body)
+		return body
+	}
+
+	var sendCode = func(t *testing.T, hc *http.Client, phone string) (*kratos.SelfServiceRecoveryFlow, url.Values, string) {
+		f := testhelpers.InitializeRecoveryFlowViaBrowser(t, hc, false, public, nil)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		values.Set("method", recovery.StrategyRecoveryCodeName)
+		values.Set("phone", phone)
+
+		body, res := testhelpers.RecoveryMakeRequest(t, false, f, hc, values.Encode())
+		assert.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowRecoveryUI().String(), "%s", body)
+		return f, values, body
+	}
+
+	t.Run("description=should recover an account", func(t *testing.T) {
+		phone := "+18004444444"
+		createIdentityToRecover(phone)
+
+		hc := testhelpers.NewClientWithCookies(t)
+		f, values, body := sendCode(t, hc, phone)
+		assert.EqualValues(t, node.CodeGroup, gjson.Get(body, "active").String(), "%s", body)
+		assert.EqualValues(t, recovery.StateEmailSent, gjson.Get(body, "state").String(), "%s", body)
+		assert.EqualValues(t, phone, gjson.Get(body, "ui.nodes.#(attributes.name==phone).attributes.value").String(), "%s", body)
+		assertx.EqualAsJSON(t, text.NewRecoveryPhoneSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+		message := testhelpers.CourierExpectMessage(t, reg, phone, "")
+		var model sms.CodeMessageModel
+		require.NoError(t, json.Unmarshal(message.TemplateData, &model))
+
+		values.Set("code", model.Code)
+		body = submit(t, hc, f, values, conf.SelfServiceFlowSettingsUI().String())
+		assert.Equal(t, text.NewRecoverySuccessful(time.Now().Add(time.Hour)).Text,
+			gjson.Get(body, "ui.messages.0.text").String(), "%s", body)
+
+		addr, err := reg.IdentityPool().FindVerifiableAddressByValue(context.Background(), identity.VerifiableAddressTypePhone, phone)
+		require.NoError(t, err)
+		assert.True(t, addr.Verified)
+		assert.Equal(t, identity.VerifiableAddressStatusCompleted, addr.Status)
+
+		res, err := hc.Get(public.URL + session.RouteWhoami)
+		require.NoError(t, err)
+		whoami := x.MustReadAll(res.Body)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "code_recovery", gjson.GetBytes(whoami, "authentication_methods.0.method").String(), "%s", whoami)
+	})
+
+	t.Run("description=should not be able to use an invalid code", func(t *testing.T) {
+		phone := "+442087599036"
+		createIdentityToRecover(phone)
+
+		hc := testhelpers.NewClientWithCookies(t)
+		f, values, _ := sendCode(t, hc, phone)
+
+		values.Set("code", "not-the-code")
+		body := submit(t, hc, f, values, conf.SelfServiceFlowRecoveryUI().String())
+		assert.EqualValues(t, text.NewErrorValidationInvalidCode().Text,
+			gjson.Get(body, fmt.Sprintf("ui.messages.#(id==%d).text", text.ErrorValidationInvalidCode)).String(), "%s", body)
+	})
+
+	t.Run("description=should reject the valid code once the attempts are exceeded", func(t *testing.T) {
+		conf.MustSet(config.CodeMaxAttempts, 3)
+		t.Cleanup(func() {
+			conf.MustSet(config.CodeMaxAttempts, 5)
+		})
+
+		phone := "+442087599037"
+		createIdentityToRecover(phone)
+
+		hc := testhelpers.NewClientWithCookies(t)
+		f, values, _ := sendCode(t, hc, phone)
+
+		message := testhelpers.CourierExpectMessage(t, reg, phone, "")
+		var model sms.CodeMessageModel
+		require.NoError(t, json.Unmarshal(message.TemplateData, &model))
+
+		for i := 0; i < 3; i++ {
+			values.Set("code", "not-the-code")
+			submit(t, hc, f, values, conf.SelfServiceFlowRecoveryUI().String())
+		}
+
+		values.Set("code", model.Code)
+		body := submit(t, hc, f, values, conf.SelfServiceFlowRecoveryUI().String())
+		assert.EqualValues(t, text.NewErrorValidationInvalidCode().Text,
+			gjson.Get(body, fmt.Sprintf("ui.messages.#(id==%d).text", text.ErrorValidationInvalidCode)).String(), "%s", body)
+
+		res, err := hc.Get(public.URL + session.RouteWhoami)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("description=should not send a code to an unknown phone number", func(t *testing.T) {
+		phone := "+380634872774"
+
+		hc := testhelpers.NewClientWithCookies(t)
+		_, _, body := sendCode(t, hc, phone)
+		assert.EqualValues(t, recovery.StateEmailSent, gjson.Get(body, "state").String(), "%s", body)
+		assertx.EqualAsJSON(t, text.NewRecoveryPhoneSent(), json.RawMessage(gjson.Get(body, "ui.messages.0").Raw))
+
+		message, err := reg.CourierPersister().LatestQueuedMessage(context.Background())
+		if err == nil {
+			assert.NotEqual(t, phone, message.Recipient)
+		}
+	})
+
+	t.Run("description=should respond identically for known and unknown phone numbers", func(t *testing.T) {
+		known, unknown := "+14155552671", "+14155552672"
+		createIdentityToRecover(known)
+
+		// normalize keeps the parts of the flow which do not differ between two flows by design.
+		normalize := func(body string) string {
+			return fmt.Sprintf(`{"state":%s,"active":%s,"messages":%s,"texts":%s,"nodes":%s}`,
+				gjson.Get(body, "state").Raw,
+				gjson.Get(body, "active").Raw,
+				gjson.Get(body, "ui.messages.#.id").Raw,
+				gjson.Get(body, "ui.messages.#.text").Raw,
+				gjson.Get(body, "ui.nodes.#.attributes.name").Raw)
+		}
+
+		send := func(t *testing.T, phone string) (int, string) {
+			hc := testhelpers.NewClientWithCookies(t)
+			f := testhelpers.InitializeRecoveryFlowViaBrowser(t, hc, false, public, nil)
+			values := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+			values.Set("method", recovery.StrategyRecoveryCodeName)
+			values.Set("phone", phone)
+
+			body, res := testhelpers.RecoveryMakeRequest(t, false, f, hc, values.Encode())
+			return res.StatusCode, normalize(body)
+		}
+
+		knownStatus, knownBody := send(t, known)
+		unknownStatus, unknownBody := send(t, unknown)
+		assert.Equal(t, knownStatus, unknownStatus)
+		assert.JSONEq(t, knownBody, unknownBody)
+
+		// Sending again right away hits the resend interval, regardless of whether the phone number is known.
+		knownStatus, knownBody = send(t, known)
+		unknownStatus, unknownBody = send(t, unknown)
+		assert.Equal(t, knownStatus, unknownStatus)
+		assert.JSONEq(t, knownBody, unknownBody)
+		assert.EqualValues(t, text.ErrorValidationCodeResendTooSoon, gjson.Get(unknownBody, "messages.0").Int(), "%s", unknownBody)
+	})
+}
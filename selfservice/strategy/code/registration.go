@@ -115,7 +115,8 @@ func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registrat
 			return s.handleRegistrationError(w, r, f, &p,
 				fmt.Errorf("credentials identifiers missing or more than one: %v", credentials.Identifiers))
 		}
-		err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, credentials.Identifiers[0])
+		err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, i,
+			codeAddressType(i, credentials.Identifiers[0]), credentials.Identifiers[0])
 		if err != nil {
 			return s.handleRegistrationError(w, r, f, &p, err)
 		}
@@ -126,21 +127,24 @@ func (s *Strategy) Register(w http.ResponseWriter, r *http.Request, f *registrat
 		if err != nil {
 			return s.handleRegistrationError(w, r, f, &p, err)
 		}
-		verifiedAt := sqlxx.NullTime(time.Now().UTC())
-		i.VerifiableAddresses = append(i.VerifiableAddresses, identity.VerifiableAddress{
-			Value:      code.Identifier,
-			Verified:   true,
-			VerifiedAt: &verifiedAt,
-			Status:     identity.VerifiableAddressStatusCompleted,
-			Via:        identity.VerifiableAddressTypePhone,
-			IdentityID: i.ID,
-		})
-
 		if err := s.d.IdentityValidator().ValidateWithRunner(r.Context(), i,
 			NewSchemaExtensionVerificationCode(code.Identifier)); err != nil {
 			return err
 		}
 
+		// Validating the identity derives its verifiable addresses from the traits, including the type of the
+		// address the code was sent to.
+		if err := s.d.IdentityValidator().Validate(r.Context(), i); err != nil {
+			return err
+		}
+		address := findCodeAddress(i, code.Identifier)
+		if address == nil {
+			return s.handleRegistrationError(w, r, f, &p, errors.New("verifiable address not found for identity"))
+		}
+		verifiedAt := sqlxx.NullTime(time.Now().UTC())
+		address.Verified = true
+		address.VerifiedAt = &verifiedAt
+		address.Status = identity.VerifiableAddressStatusCompleted
 	}
 
 	return nil
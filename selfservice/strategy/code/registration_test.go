@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/gofrs/uuid"
 	kratos "github.com/ory/kratos-client-go"
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template/email"
 	"github.com/ory/kratos/courier/template/sms"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -150,7 +152,7 @@ func TestRegistration(t *testing.T) {
 				conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeCode.String()), nil)
 			})
 
-			identifier := "+11111111111"
+			identifier := "+11111111112"
 
 			t.Run("type=api", func(t *testing.T) {
 				expectSuccessfulLogin(t, true, false, nil,
@@ -179,6 +181,48 @@ func TestRegistration(t *testing.T) {
 			//})
 		})
 
+		t.Run("case=should send the code by email to email identifiers", func(t *testing.T) {
+			testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/email.schema.json")
+			conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeCode.String()), []config.SelfServiceHook{{Name: "session"}})
+			t.Cleanup(func() {
+				testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/default.schema.json")
+				conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter, identity.CredentialsTypeCode.String()), nil)
+			})
+
+			identifier := "code-registration@ory.sh"
+			hc := new(http.Client)
+			f := testhelpers.InitializeRegistrationFlow(t, true, hc, publicTS, false)
+
+			var values = func(v url.Values) {
+				v.Set("method", "code")
+				v.Set("traits.email", identifier)
+			}
+			testhelpers.SubmitRegistrationFormWithFlow(t, true, hc, values,
+				false, http.StatusBadRequest, publicTS.URL+registration.RouteSubmitFlow, f)
+
+			messages, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+			require.NoError(t, err, "Courier queue should not be empty.")
+			require.Len(t, messages, 1)
+			assert.Equal(t, courier.MessageTypeEmail, messages[0].Type)
+			assert.Equal(t, identifier, messages[0].Recipient)
+			var emailModel email.CodeMessageModel
+			require.NoError(t, json.Unmarshal(messages[0].TemplateData, &emailModel))
+			assert.Contains(t, messages[0].Body, emailModel.Code)
+
+			values = func(v url.Values) {
+				v.Set("method", "code")
+				v.Set("traits.email", identifier)
+				v.Set("code", emailModel.Code)
+			}
+			body := testhelpers.SubmitRegistrationFormWithFlow(t, true, hc, values,
+				false, http.StatusOK, publicTS.URL+registration.RouteSubmitFlow, f)
+
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+			assert.Equal(t, identifier, gjson.Get(body, "identity.verifiable_addresses.0.value").String(), "%s", body)
+			assert.Equal(t, "email", gjson.Get(body, "identity.verifiable_addresses.0.via").String(), "%s", body)
+			assert.Equal(t, "true", gjson.Get(body, "identity.verifiable_addresses.0.verified").String(), "%s", body)
+		})
+
 		t.Run("case=should create verifiable address", func(t *testing.T) {
 			identifier := "+1234567890"
 			createdIdentity := &identity.Identity{
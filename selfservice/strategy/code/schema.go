@@ -10,3 +10,6 @@ var registrationSchema []byte
 
 //go:embed .schema/verification.schema.json
 var verificationSchema []byte
+
+//go:embed .schema/recovery.schema.json
+var recoverySchema []byte
@@ -4,22 +4,31 @@ package code
 
 import (
 	"context"
+	"strings"
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/courier/template/email"
 	templates "github.com/ory/kratos/courier/template/sms"
 	"github.com/ory/kratos/driver/clock"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/x/httpx"
+	"github.com/ory/x/randx"
 )
 
 type Flow interface {
 	GetID() uuid.UUID
+	GetAcceptLanguage() string
 	Valid() error
 }
 
 type AuthenticationService interface {
-	SendCode(ctx context.Context, flow Flow, phone string) error
+	SendCode(ctx context.Context, flow Flow, i *identity.Identity, via identity.VerifiableAddressType, identifier string) error
+	SendCodeToUnknownRecipient(ctx context.Context, flow Flow, identifier string) error
 	VerifyCode(ctx context.Context, flow Flow, code string) (*Code, error)
 }
 
@@ -46,44 +55,91 @@ func NewCodeAuthenticationService(r dependencies) AuthenticationService {
 }
 
 // SendCode
-// Sends a new code to the user in a message.
-// Returns error if the code was already sent and is not expired yet.
-func (s *authenticationServiceImpl) SendCode(ctx context.Context, flow Flow, identifier string) error {
-	if err := flow.Valid(); err != nil {
-		return err
-	}
+// Sends a new code to the user in a message. The message is an email if via is
+// identity.VerifiableAddressTypeEmail and an SMS otherwise. The message is localized using the locale trait of the
+// identity, if it is known, and the Accept-Language header captured by the flow.
+// Returns error if the resend interval or one of the send limits has not passed yet.
+func (s *authenticationServiceImpl) SendCode(ctx context.Context, flow Flow, i *identity.Identity, via identity.VerifiableAddressType, identifier string) error {
+	return s.sendCode(ctx, flow, i, via, identifier, true)
+}
 
-	if err := s.r.CodePersister().DeleteCodes(ctx, identifier); err != nil {
+// SendCodeToUnknownRecipient
+// Behaves like SendCode for an identifier which does not belong to any identity, without delivering a message. The
+// same send limits apply and a code nobody knows is stored, so that the caller can not tell known and unknown
+// identifiers apart.
+func (s *authenticationServiceImpl) SendCodeToUnknownRecipient(ctx context.Context, flow Flow, identifier string) error {
+	return s.sendCode(ctx, flow, nil, identity.VerifiableAddressTypePhone, identifier, false)
+}
+
+func (s *authenticationServiceImpl) sendCode(ctx context.Context, flow Flow, i *identity.Identity, via identity.VerifiableAddressType, identifier string, deliver bool) error {
+	if err := flow.Valid(); err != nil {
 		return err
 	}
 
+	length := s.r.Config(ctx).SelfServiceCodeLength()
 	codeValue := ""
-	sendSMS := true
+	send := true
 	for _, n := range s.r.Config(ctx).SelfServiceCodeTestNumbers() {
 		if n == identifier {
-			codeValue = "0000"
-			sendSMS = false
+			codeValue = strings.Repeat("0", length)
+			send = false
 			break
 		}
 	}
 
-	if sendSMS {
-		codeValue = s.r.RandomCodeGenerator().Generate(4)
+	var sent int
+	if send {
+		var err error
+		if sent, err = s.checkSendLimits(ctx, flow, identifier); err != nil {
+			return err
+		}
+		codeValue = s.r.RandomCodeGenerator().Generate(length, codeAlphabet(s.r.Config(ctx).SelfServiceCodeAlphabet()))
+	}
+
+	if err := s.r.CodePersister().ExpireCodes(ctx, identifier, s.r.Clock().Now()); err != nil {
+		return err
 	}
 
-	if err := s.r.CodePersister().CreateCode(ctx, &Code{
+	c := &Code{
 		FlowId:     flow.GetID(),
 		Identifier: identifier,
 		Code:       codeValue,
 		ExpiresAt:  s.r.Clock().Now().Add(s.r.Config(ctx).SelfServiceCodeLifespan()),
-	}); err != nil {
+	}
+	if err := s.r.CodePersister().CreateCode(ctx, c); err != nil {
 		return err
 	}
 
-	if sendSMS {
+	if send {
+		if err := s.checkRacingSends(ctx, flow, identifier, sent); err != nil {
+			if deleteErr := s.r.CodePersister().DeleteCode(ctx, c.ID); deleteErr != nil {
+				return deleteErr
+			}
+			return err
+		}
+	}
+
+	if !send || !deliver {
+		return nil
+	}
+
+	var traits []byte
+	if i != nil {
+		traits = i.Traits
+	}
+	locales := template.Locales(traits, s.r.Config(ctx).CourierTemplatesLocaleTrait(), flow.GetAcceptLanguage())
+	switch via {
+	case identity.VerifiableAddressTypeEmail:
+		if _, err := s.r.Courier(ctx).QueueEmail(
+			ctx,
+			email.NewCodeMessage(s.r, &email.CodeMessageModel{Code: codeValue, To: identifier, Locales: locales}),
+		); err != nil {
+			return err
+		}
+	default:
 		if _, err := s.r.Courier(ctx).QueueSMS(
 			ctx,
-			templates.NewCodeMessage(s.r, &templates.CodeMessageModel{Code: codeValue, To: identifier}),
+			templates.NewCodeMessage(s.r, &templates.CodeMessageModel{Code: codeValue, To: identifier, Locales: locales}),
 		); err != nil {
 			return err
 		}
@@ -91,6 +147,81 @@ func (s *authenticationServiceImpl) SendCode(ctx context.Context, flow Flow, ide
 	return nil
 }
 
+// SendLimitWindow is the period in which the codes sent to an identifier count towards the send limit. Codes
+// must be kept at least this long.
+const SendLimitWindow = 24 * time.Hour
+
+// checkSendLimits returns an error if no further code may be sent to the identifier within the flow yet. Otherwise it
+// returns the number of codes counting towards the limits of the identifier.
+func (s *authenticationServiceImpl) checkSendLimits(ctx context.Context, flow Flow, identifier string) (int, error) {
+	c := s.r.Config(ctx)
+	now := s.r.Clock().Now()
+
+	if max := c.SelfServiceCodeMaxSendsPerFlow(); max > 0 {
+		count, err := s.r.CodePersister().CountCodesByFlow(ctx, flow.GetID())
+		if err != nil {
+			return 0, err
+		}
+		if count >= max {
+			return 0, NewFlowSendLimitExceededError()
+		}
+	}
+
+	sent, err := s.r.CodePersister().ListCodesByIdentifier(ctx, identifier, now.Add(-SendLimitWindow))
+	if err != nil {
+		return 0, err
+	}
+	if len(sent) == 0 {
+		return 0, nil
+	}
+
+	if wait := sent[len(sent)-1].CreatedAt.Add(c.SelfServiceCodeResendInterval()).Sub(now); wait > 0 {
+		return 0, NewResendTooSoonError(wait)
+	}
+
+	// The limit is lifted once the oldest code counting towards it is older than the window.
+	if max := c.SelfServiceCodeMaxSendsPerIdentifier(); max > 0 && len(sent) >= max {
+		return 0, NewSendLimitExceededError(sent[len(sent)-max].CreatedAt.Add(SendLimitWindow).Sub(now))
+	}
+
+	return len(sent), nil
+}
+
+// checkRacingSends returns an error if codes stored by parallel requests, together with the code just stored, exceed
+// the send limits. Checking the limits and storing the code are separate statements, which is why the codes are
+// counted again after the code was stored, the same way the login lockout counts parallel attempts. Parallel
+// requests may all be rejected, but never more codes than allowed are sent.
+func (s *authenticationServiceImpl) checkRacingSends(ctx context.Context, flow Flow, identifier string, counted int) error {
+	c := s.r.Config(ctx)
+	now := s.r.Clock().Now()
+
+	if max := c.SelfServiceCodeMaxSendsPerFlow(); max > 0 {
+		count, err := s.r.CodePersister().CountCodesByFlow(ctx, flow.GetID())
+		if err != nil {
+			return err
+		}
+		if count > max {
+			return NewFlowSendLimitExceededError()
+		}
+	}
+
+	sent, err := s.r.CodePersister().ListCodesByIdentifier(ctx, identifier, now.Add(-SendLimitWindow))
+	if err != nil {
+		return err
+	}
+
+	// Apart from the code just stored, all codes which were not counted before were sent by parallel requests.
+	if interval := c.SelfServiceCodeResendInterval(); interval > 0 && len(sent)-counted > 1 {
+		return NewResendTooSoonError(interval)
+	}
+
+	if max := c.SelfServiceCodeMaxSendsPerIdentifier(); max > 0 && len(sent) > max {
+		return NewSendLimitExceededError(sent[len(sent)-1-max].CreatedAt.Add(SendLimitWindow).Sub(now))
+	}
+
+	return nil
+}
+
 // VerifyCode
 // Verifies code by looking up in db.
 func (s *authenticationServiceImpl) VerifyCode(ctx context.Context, flow Flow, code string) (*Code, error) {
@@ -101,11 +232,29 @@ func (s *authenticationServiceImpl) VerifyCode(ctx context.Context, flow Flow, c
 	if err != nil {
 		return nil, err
 	}
-	if expectedCode == nil || expectedCode.Code != code {
+	if expectedCode == nil {
 		return nil, NewInvalidCodeError()
-	} else if expectedCode.Attempts >= s.r.Config(ctx).SelfServiceCodeMaxAttempts() {
+	}
+
+	// The attempt is counted before the code is compared so that it is not possible to guess the code by
+	// sending many requests in parallel.
+	if ok, err := s.r.CodePersister().IncrementCodeAttempts(ctx, expectedCode.ID, s.r.Config(ctx).SelfServiceCodeMaxAttempts()); err != nil {
+		return nil, err
+	} else if !ok {
 		return nil, NewAttemptsExceededError()
 	}
 
+	// Alphanumeric codes only contain upper case letters, so they are matched case-insensitively.
+	if !s.r.CodePersister().CompareCode(ctx, expectedCode, strings.ToUpper(strings.TrimSpace(code))) {
+		return nil, NewInvalidCodeError()
+	}
+
 	return expectedCode, nil
 }
+
+func codeAlphabet(alphabet string) []rune {
+	if alphabet == config.CodeAlphabetAlphanumeric {
+		return randx.AlphaUpperNum
+	}
+	return randx.Numeric
+}
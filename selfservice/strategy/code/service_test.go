@@ -2,6 +2,7 @@ package code_test
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/benbjohnson/clock"
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
@@ -9,13 +10,22 @@ import (
 	"github.com/ory/kratos/courier"
 	courierMock "github.com/ory/kratos/courier/mocks"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/strategy/code"
 	codeMock "github.com/ory/kratos/selfservice/strategy/code/mocks"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/dbal"
 	"github.com/ory/x/httpx"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -39,6 +49,7 @@ func TestAuthenticationService_SendCode(t *testing.T) {
 		name    string
 		service code.AuthenticationService
 		flow    code.Flow
+		via     identity.VerifiableAddressType
 		phone   string
 		wantErr bool
 	}{
@@ -50,17 +61,19 @@ func TestAuthenticationService_SendCode(t *testing.T) {
 				tc.codeGenerator("0000"),
 			),
 			tc.invalidFlow(),
+			identity.VerifiableAddressTypePhone,
 			"000000",
 			true,
 		},
 		{"do not send code to test phone number",
 			tc.NewCodeAuthenticationService(
-				tc.repoNoCodeCreateCode(),
+				tc.repoTestNumberCreateCode(),
 				tc.courierNoCalls(),
 				clock.NewMock(),
 				tc.codeGenerator("0000"),
 			),
 			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
 			"test_phone_number",
 			false,
 		},
@@ -72,19 +85,234 @@ func TestAuthenticationService_SendCode(t *testing.T) {
 				tc.codeGenerator("0000"),
 			),
 			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
+			"1234",
+			false,
+		},
+		{"send code by email to email addresses",
+			tc.NewCodeAuthenticationService(
+				tc.repoNoCodeCreateCode(),
+				tc.courierEmail(),
+				clock.NewMock(),
+				tc.codeGenerator("0000"),
+			),
+			tc.validFlowGetID(),
+			identity.VerifiableAddressTypeEmail,
+			"foo@ory.sh",
+			false,
+		},
+		{"send code when resend interval passed",
+			tc.NewCodeAuthenticationService(
+				tc.repoSentCodes(0, newTime("2021-07-10T11:59:00Z")),
+				tc.courier(),
+				fixClock("2021-07-10T12:00:00Z"),
+				tc.codeGenerator("0000"),
+			),
+			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
 			"1234",
 			false,
 		},
+		{"error if code was sent within resend interval",
+			tc.NewCodeAuthenticationService(
+				tc.repoSentCodes(0, newTime("2021-07-10T11:59:50Z")),
+				tc.courierNoCalls(),
+				fixClock("2021-07-10T12:00:00Z"),
+				tc.codeGenerator("0000"),
+			),
+			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
+			"1234",
+			true,
+		},
+		{"error if too many codes were sent to the identifier",
+			tc.NewCodeAuthenticationService(
+				tc.repoSentCodes(0, sentEvery(newTime("2021-07-10T02:00:00Z"), time.Hour, 10)...),
+				tc.courierNoCalls(),
+				fixClock("2021-07-10T12:00:00Z"),
+				tc.codeGenerator("0000"),
+			),
+			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
+			"1234",
+			true,
+		},
+		{"error if too many codes were sent within the flow",
+			tc.NewCodeAuthenticationService(
+				tc.repoSentCodes(5),
+				tc.courierNoCalls(),
+				fixClock("2021-07-10T12:00:00Z"),
+				tc.codeGenerator("0000"),
+			),
+			tc.validFlowGetID(),
+			identity.VerifiableAddressTypePhone,
+			"1234",
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.service.SendCode(tc.context, tt.flow, tt.phone); (err != nil) != tt.wantErr {
+			if err := tt.service.SendCode(tc.context, tt.flow, nil, tt.via, tt.phone); (err != nil) != tt.wantErr {
 				t.Errorf("SendCode() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestAuthenticationService_SendCodeLimits(t *testing.T) {
+	tc := testContext{
+		context.Background(),
+		gomock.NewController(t),
+		internal.NewConfigurationWithDefaults(t),
+	}
+
+	t.Run("case=resend interval", func(t *testing.T) {
+		err := tc.NewCodeAuthenticationService(
+			tc.repoSentCodes(0, newTime("2021-07-10T11:59:50Z")),
+			tc.courierNoCalls(),
+			fixClock("2021-07-10T12:00:00Z"),
+			tc.codeGenerator("0000"),
+		).SendCode(tc.context, tc.validFlowGetID(), nil, identity.VerifiableAddressTypePhone, "1234")
+
+		var e code.CodeRateLimitError
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, http.StatusTooManyRequests, e.StatusCode())
+		assert.Equal(t, text.ErrorValidationCodeResendTooSoon, e.Messages[0].ID)
+		assert.Contains(t, e.Messages[0].Text, "20 seconds")
+	})
+
+	t.Run("case=daily limit", func(t *testing.T) {
+		err := tc.NewCodeAuthenticationService(
+			tc.repoSentCodes(0, sentEvery(newTime("2021-07-10T02:00:00Z"), time.Hour, 10)...),
+			tc.courierNoCalls(),
+			fixClock("2021-07-10T12:00:00Z"),
+			tc.codeGenerator("0000"),
+		).SendCode(tc.context, tc.validFlowGetID(), nil, identity.VerifiableAddressTypePhone, "1234")
+
+		var e code.CodeRateLimitError
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, text.ErrorValidationCodeSendLimitExceeded, e.Messages[0].ID)
+		// The oldest code was sent ten hours ago and leaves the window in fourteen hours.
+		assert.Contains(t, e.Messages[0].Text, "840 minutes")
+	})
+
+	t.Run("case=flow limit", func(t *testing.T) {
+		err := tc.NewCodeAuthenticationService(
+			tc.repoSentCodes(5),
+			tc.courierNoCalls(),
+			fixClock("2021-07-10T12:00:00Z"),
+			tc.codeGenerator("0000"),
+		).SendCode(tc.context, tc.validFlowGetID(), nil, identity.VerifiableAddressTypePhone, "1234")
+
+		var e code.CodeRateLimitError
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, text.ErrorValidationCodeFlowSendLimitExceeded, e.Messages[0].ID)
+	})
+}
+
+func TestAuthenticationService_SendCodeInParallel(t *testing.T) {
+	conf, reg := internal.NewRegistryDefaultWithDSN(t, dbal.SQLiteSharedInMemory)
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+
+	var queued int32
+	c := courierMock.NewMockCourier(controller)
+	c.EXPECT().QueueSMS(ctx, gomock.Any()).AnyTimes().DoAndReturn(func(context.Context, courier.SMSTemplate) (uuid.UUID, error) {
+		atomic.AddInt32(&queued, 1)
+		return uuid.Nil, nil
+	})
+
+	f := codeMock.NewMockFlow(controller)
+	f.EXPECT().Valid().AnyTimes().Return(nil)
+	f.EXPECT().GetID().AnyTimes().Return(x.NewUUID())
+	f.EXPECT().GetAcceptLanguage().AnyTimes().Return("")
+
+	const parallel = 10
+	p := &barrierCodePersister{CodePersister: reg.Persister(), parties: parallel, released: make(chan struct{})}
+	s := code.NewCodeAuthenticationService(&dependencies{conf, p, c, clock.New(), code.NewRandomCodeGenerator()})
+	const identifier = "+4917612345678"
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = s.SendCode(ctx, f, nil, identity.VerifiableAddressTypePhone, identifier)
+		}()
+	}
+	wg.Wait()
+
+	stored, err := reg.Persister().ListCodesByIdentifier(ctx, identifier, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&queued), int32(1), "parallel requests must not send more codes than the resend interval allows")
+	assert.Len(t, stored, int(atomic.LoadInt32(&queued)), "codes of rejected requests must not count towards the limits")
+}
+
+// barrierCodePersister lets the first parties calls of ListCodesByIdentifier wait for each other, so that all
+// parallel requests check the send limits before any of them stores a code.
+type barrierCodePersister struct {
+	code.CodePersister
+	parties  int32
+	arrived  int32
+	released chan struct{}
+}
+
+func (p *barrierCodePersister) ListCodesByIdentifier(ctx context.Context, identifier string, createdAfter time.Time) ([]code.Code, error) {
+	codes, err := p.CodePersister.ListCodesByIdentifier(ctx, identifier, createdAfter)
+	if n := atomic.AddInt32(&p.arrived, 1); n == p.parties {
+		close(p.released)
+	} else if n < p.parties {
+		<-p.released
+	}
+	return codes, err
+}
+
+func TestAuthenticationService_SendCodeLocale(t *testing.T) {
+	tc := testContext{
+		context.Background(),
+		gomock.NewController(t),
+		internal.NewConfigurationWithDefaults(t),
+	}
+	tc.config.MustSet(config.ViperKeyCourierTemplatesLocaleTrait, "locale")
+
+	send := func(t *testing.T, i *identity.Identity) []string {
+		var locales []string
+		m := courierMock.NewMockCourier(tc.controller)
+		m.EXPECT().QueueSMS(tc.context, gomock.Any()).DoAndReturn(
+			func(_ context.Context, tmpl courier.SMSTemplate) (uuid.UUID, error) {
+				raw, err := json.Marshal(tmpl)
+				if err != nil {
+					return uuid.Nil, err
+				}
+				var model struct{ Locales []string }
+				if err := json.Unmarshal(raw, &model); err != nil {
+					return uuid.Nil, err
+				}
+				locales = model.Locales
+				return uuid.Nil, nil
+			},
+		)
+
+		require.NoError(t, tc.NewCodeAuthenticationService(
+			tc.repoNoCodeCreateCode(),
+			m,
+			clock.NewMock(),
+			tc.codeGenerator("0000"),
+		).SendCode(tc.context, tc.validFlowGetID(), i, identity.VerifiableAddressTypePhone, "1234"))
+		return locales
+	}
+
+	t.Run("case=prefers the locale trait over Accept-Language", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"phone":"1234","locale":"fr"}`)
+		assert.Equal(t, []string{"fr", "de-DE", "de"}, send(t, i))
+	})
+
+	t.Run("case=falls back to Accept-Language without identity", func(t *testing.T) {
+		assert.Equal(t, []string{"de-DE", "de"}, send(t, nil))
+	})
+}
+
 func TestAuthenticationService_VerifyCode(t *testing.T) {
 	tc := testContext{
 		context.Background(),
@@ -156,6 +384,17 @@ func TestAuthenticationService_VerifyCode(t *testing.T) {
 			"0000",
 			false,
 		},
+		{"alphanumeric code matches case-insensitively",
+			tc.NewCodeAuthenticationService(
+				tc.repoActiveCodeVerify("AB12CD", newTime("2021-07-10T12:00:00Z"), 0),
+				tc.courierNoCalls(),
+				fixClock("2021-07-10T12:00:00Z"),
+				tc.codeGenerator("AB12CD"),
+			),
+			tc.validFlowGetID(),
+			" ab12cd ",
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -190,6 +429,7 @@ func (tc *testContext) validFlowGetID() code.Flow {
 	m := codeMock.NewMockFlow(tc.controller)
 	m.EXPECT().Valid().Return(nil)
 	m.EXPECT().GetID().MinTimes(1).Return(uuid.FromStringOrNil("00000000-0000-0000-0000-000000000001"))
+	m.EXPECT().GetAcceptLanguage().AnyTimes().Return("de-DE,de;q=0.9")
 	return m
 }
 
@@ -200,15 +440,43 @@ func (tc *testContext) repoNoCode() code.CodePersister {
 }
 
 func (tc *testContext) repoNoCodeCreateCode() code.CodePersister {
+	return tc.repoSentCodes(0)
+}
+
+func (tc *testContext) repoTestNumberCreateCode() code.CodePersister {
 	m := codeMock.NewMockCodePersister(tc.controller)
-	m.EXPECT().DeleteCodes(tc.context, gomock.Any())
+	m.EXPECT().ExpireCodes(tc.context, gomock.Any(), gomock.Any())
 	m.EXPECT().CreateCode(tc.context, gomock.Any())
 	return m
 }
 
+// repoSentCodes returns a persister which knows about flowCount codes sent within the flow and codes sent to the
+// identifier at the given times. A new code is only stored if no send limit is hit, in which case the limits are
+// checked again.
+func (tc *testContext) repoSentCodes(flowCount int, sentAt ...time.Time) code.CodePersister {
+	m := codeMock.NewMockCodePersister(tc.controller)
+	m.EXPECT().CountCodesByFlow(tc.context, gomock.Any()).MinTimes(1).MaxTimes(2).Return(flowCount, nil)
+
+	var sent []code.Code
+	for _, t := range sentAt {
+		sent = append(sent, code.Code{Identifier: "1234", CreatedAt: t})
+	}
+	m.EXPECT().ListCodesByIdentifier(tc.context, gomock.Any(), gomock.Any()).MaxTimes(2).Return(sent, nil)
+	m.EXPECT().ExpireCodes(tc.context, gomock.Any(), gomock.Any()).MaxTimes(1)
+	m.EXPECT().CreateCode(tc.context, gomock.Any()).MaxTimes(1)
+	return m
+}
+
+func sentEvery(start time.Time, interval time.Duration, count int) (sentAt []time.Time) {
+	for i := 0; i < count; i++ {
+		sentAt = append(sentAt, start.Add(time.Duration(i)*interval))
+	}
+	return sentAt
+}
+
 func (tc *testContext) repoActiveCodeSend(authCode string, t time.Time, attempts int) code.CodePersister {
 	m := codeMock.NewMockCodePersister(tc.controller)
-	m.EXPECT().DeleteCodes(tc.context, gomock.Any())
+	m.EXPECT().ExpireCodes(tc.context, gomock.Any(), gomock.Any())
 	m.EXPECT().FindActiveCode(tc.context, gomock.Any(), t).Return(
 		&code.Code{
 			Identifier: "11111",
@@ -230,6 +498,20 @@ func (tc *testContext) repoActiveCodeVerify(authCode string, t time.Time, attemp
 		},
 		nil,
 	)
+	m.EXPECT().IncrementCodeAttempts(tc.context, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, max int) (bool, error) {
+			return attempts < max, nil
+		},
+	)
+	compare := m.EXPECT().CompareCode(tc.context, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, c *code.Code, value string) bool {
+			return c.Code == value
+		},
+	)
+	if attempts >= 5 {
+		// The code must not be compared once the maximum number of attempts is reached.
+		compare.Times(0)
+	}
 	return m
 }
 
@@ -248,6 +530,12 @@ func (tc *testContext) courier() courier.Courier {
 	return m
 }
 
+func (tc *testContext) courierEmail() courier.Courier {
+	m := courierMock.NewMockCourier(tc.controller)
+	m.EXPECT().QueueEmail(tc.context, gomock.Any())
+	return m
+}
+
 func (tc *testContext) lifespan(s string) *config.Config {
 	tc.config.MustSet(config.CodeLifespan, s)
 	return tc.config
@@ -258,7 +546,7 @@ type randomCodeGeneratorStub struct {
 }
 
 //goland:noinspection GoUnusedParameter
-func (s *randomCodeGeneratorStub) Generate(max int) string {
+func (s *randomCodeGeneratorStub) Generate(length int, alphabet []rune) string {
 	return s.code
 }
 
@@ -1,9 +1,13 @@
 package code
 
 import (
+	"github.com/ory/kratos/driver/clock"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
@@ -14,10 +18,13 @@ import (
 	"net/http"
 )
 
+var _ recovery.Strategy = new(Strategy)
+
 type strategyDependencies interface {
 	config.Provider
 	x.CSRFProvider
 	x.CSRFTokenGeneratorProvider
+	x.WriterProvider
 	session.ManagementProvider
 	identity.PoolProvider
 	identity.PrivilegedPoolProvider
@@ -25,11 +32,21 @@ type strategyDependencies interface {
 	identity.ValidationProvider
 	x.LoggingProvider
 	AuthenticationServiceProvider
+	CodePersistenceProvider
 
 	registration.HandlerProvider
 
+	recovery.FlowPersistenceProvider
+	recovery.HookExecutorProvider
+
+	settings.HandlerProvider
+	settings.FlowPersistenceProvider
+
 	verification.FlowPersistenceProvider
 	verification.HookExecutorProvider
+
+	event.StreamProvider
+	clock.Provider
 }
 
 type Strategy struct {
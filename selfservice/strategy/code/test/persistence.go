@@ -36,5 +36,69 @@ func TestCodePersister(ctx context.Context, p persistence.Persister) func(t *tes
 			assert.EqualValues(t, expected.Identifier, actual.Identifier)
 			x.AssertEqualTime(t, expected.ExpiresAt, actual.ExpiresAt)
 		})
+
+		t.Run("case=should not store the code in plain text", func(t *testing.T) {
+			expected := newCode(t)
+			value := expected.Code
+			require.NoError(t, p.CreateCode(ctx, expected))
+			assert.Equal(t, value, expected.Code)
+
+			actual, err := p.FindActiveCode(ctx, expected.FlowId, expected.ExpiresAt.Add(-time.Minute))
+			require.NoError(t, err)
+			require.NotNil(t, actual)
+
+			assert.NotEqual(t, value, actual.Code)
+			assert.True(t, p.CompareCode(ctx, actual, value))
+			assert.False(t, p.CompareCode(ctx, actual, value+"0"))
+		})
+
+		t.Run("case=should expire, list and count codes", func(t *testing.T) {
+			first := newCode(t)
+			first.ExpiresAt = time.Now().UTC().Add(time.Hour)
+			require.NoError(t, p.CreateCode(ctx, first))
+
+			second := newCode(t)
+			second.Identifier = first.Identifier
+			second.FlowId = first.FlowId
+			second.ExpiresAt = time.Now().UTC().Add(time.Hour)
+			require.NoError(t, p.CreateCode(ctx, second))
+
+			count, err := p.CountCodesByFlow(ctx, first.FlowId)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			actual, err := p.ListCodesByIdentifier(ctx, first.Identifier, time.Now().UTC().Add(-time.Hour))
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.EqualValues(t, first.ID, actual[0].ID)
+			assert.EqualValues(t, second.ID, actual[1].ID)
+
+			actual, err = p.ListCodesByIdentifier(ctx, first.Identifier, time.Now().UTC().Add(time.Hour))
+			require.NoError(t, err)
+			assert.Len(t, actual, 0)
+
+			require.NoError(t, p.ExpireCodes(ctx, first.Identifier, time.Now().UTC()))
+
+			active, err := p.FindActiveCode(ctx, first.FlowId, time.Now().UTC())
+			require.NoError(t, err)
+			assert.Nil(t, active)
+
+			count, err = p.CountCodesByFlow(ctx, first.FlowId)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count, "expired codes still count towards the limits")
+		})
+
+		t.Run("case=should delete a code", func(t *testing.T) {
+			kept, deleted := newCode(t), newCode(t)
+			deleted.FlowId = kept.FlowId
+			require.NoError(t, p.CreateCode(ctx, kept))
+			require.NoError(t, p.CreateCode(ctx, deleted))
+
+			require.NoError(t, p.DeleteCode(ctx, deleted.ID))
+
+			count, err := p.CountCodesByFlow(ctx, kept.FlowId)
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
 	}
 }
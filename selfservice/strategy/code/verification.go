@@ -1,6 +1,7 @@
 package code
 
 import (
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -8,6 +9,7 @@ import (
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/ui/node"
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/sqlxx"
 	"github.com/pkg/errors"
 	"net/http"
@@ -141,6 +143,7 @@ func (s *Strategy) verificationUseCode(w http.ResponseWriter, r *http.Request, f
 	if address == nil {
 		return s.retryWithError(w, r, f.Type, errors.New("address ot found"))
 	}
+	wasVerified := address.Verified
 	address.Verified = true
 	verifiedAt := sqlxx.NullTime(time.Now().UTC())
 	address.VerifiedAt = &verifiedAt
@@ -149,6 +152,10 @@ func (s *Strategy) verificationUseCode(w http.ResponseWriter, r *http.Request, f
 		return s.retryWithError(w, r, f.Type, err)
 	}
 
+	if !wasVerified {
+		s.d.EventStream().Emit(r.Context(), event.NewAddressVerified(address.IdentityID, f.ID, address.Value, string(address.Via)))
+	}
+
 	return nil
 }
 
@@ -167,7 +174,17 @@ func (s *Strategy) verificationHandleFormSubmission(w http.ResponseWriter, r *ht
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
-	if err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, body.Phone); err != nil {
+	// The identity is only used to localize the message, so unknown phone numbers still receive a code.
+	var i *identity.Identity
+	if address, err := s.d.IdentityPool().FindVerifiableAddressByValue(r.Context(), identity.VerifiableAddressTypePhone, body.Phone); err == nil {
+		if i, err = s.d.IdentityPool().GetIdentity(r.Context(), address.IdentityID); err != nil {
+			return s.handleVerificationError(w, r, f, body, err)
+		}
+	} else if !errors.Is(err, sqlcon.ErrNoRows) {
+		return s.handleVerificationError(w, r, f, body, err)
+	}
+
+	if err := s.d.CodeAuthenticationService().SendCode(r.Context(), f, i, identity.VerifiableAddressTypePhone, body.Phone); err != nil {
 		return s.handleVerificationError(w, r, f, body, err)
 	}
 
@@ -2,6 +2,7 @@ package link
 
 import (
 	"context"
+	"time"
 )
 
 type (
@@ -9,6 +10,8 @@ type (
 		CreateRecoveryToken(ctx context.Context, token *RecoveryToken) error
 		UseRecoveryToken(ctx context.Context, token string) (*RecoveryToken, error)
 		DeleteRecoveryToken(ctx context.Context, token string) error
+		// DeleteExpiredRecoveryTokens deletes at most limit recovery tokens which expired or were used before the given time.
+		DeleteExpiredRecoveryTokens(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 
 	RecoveryTokenPersistenceProvider interface {
@@ -19,6 +22,8 @@ type (
 		CreateVerificationToken(ctx context.Context, token *VerificationToken) error
 		UseVerificationToken(ctx context.Context, token string) (*VerificationToken, error)
 		DeleteVerificationToken(ctx context.Context, token string) error
+		// DeleteExpiredVerificationTokens deletes at most limit verification tokens which expired or were used before the given time.
+		DeleteExpiredVerificationTokens(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 	}
 
 	VerificationTokenPersistenceProvider interface {
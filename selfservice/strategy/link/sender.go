@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/go-retryablehttp"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/courier/template/email"
 
 	"github.com/ory/x/httpx"
@@ -67,7 +68,7 @@ func (s *Sender) SendRecoveryLink(ctx context.Context, r *http.Request, f *recov
 
 	address, err := s.r.IdentityPool().FindRecoveryAddressByValue(ctx, identity.RecoveryAddressTypeEmail, to)
 	if err != nil {
-		if err := s.send(ctx, string(via), email.NewRecoveryInvalid(s.r, &email.RecoveryInvalidModel{To: to})); err != nil {
+		if err := s.send(ctx, string(via), email.NewRecoveryInvalid(s.r, &email.RecoveryInvalidModel{To: to, Locales: s.locales(ctx, nil, f.GetAcceptLanguage())})); err != nil {
 			return err
 		}
 		return errors.Cause(ErrUnknownAddress)
@@ -107,7 +108,7 @@ func (s *Sender) SendVerificationLink(ctx context.Context, f *verification.Flow,
 				WithField("via", via).
 				WithSensitiveField("email_address", address).
 				Info("Sending out invalid verification email because address is unknown.")
-			if err := s.send(ctx, string(via), email.NewVerificationInvalid(s.r, &email.VerificationInvalidModel{To: to})); err != nil {
+			if err := s.send(ctx, string(via), email.NewVerificationInvalid(s.r, &email.VerificationInvalidModel{To: to, Locales: s.locales(ctx, nil, f.GetAcceptLanguage())})); err != nil {
 				return err
 			}
 			return errors.Cause(ErrUnknownAddress)
@@ -152,7 +153,7 @@ func (s *Sender) SendRecoveryTokenTo(ctx context.Context, f *recovery.Flow, i *i
 			url.Values{
 				"token": {token.Token},
 				"flow":  {f.ID.String()},
-			}).String(), Identity: model}))
+			}).String(), Identity: model, Locales: s.locales(ctx, i, f.GetAcceptLanguage())}))
 }
 
 func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Flow, i *identity.Identity, address *identity.VerifiableAddress, token *VerificationToken) error {
@@ -175,7 +176,7 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 			url.Values{
 				"flow":  {f.ID.String()},
 				"token": {token.Token},
-			}).String(), Identity: model})); err != nil {
+			}).String(), Identity: model, Locales: s.locales(ctx, i, f.GetAcceptLanguage())})); err != nil {
 		return err
 	}
 	address.Status = identity.VerifiableAddressStatusSent
@@ -185,6 +186,16 @@ func (s *Sender) SendVerificationTokenTo(ctx context.Context, f *verification.Fl
 	return nil
 }
 
+// locales returns the locales the message should be rendered in, preferring the locale trait of the identity, if
+// known, over the Accept-Language header captured by the flow.
+func (s *Sender) locales(ctx context.Context, i *identity.Identity, acceptLanguage string) []string {
+	var traits []byte
+	if i != nil {
+		traits = i.Traits
+	}
+	return template.Locales(traits, s.r.Config(ctx).CourierTemplatesLocaleTrait(), acceptLanguage)
+}
+
 func (s *Sender) send(ctx context.Context, via string, t courier.EmailTemplate) error {
 	switch via {
 	case identity.AddressTypeEmail:
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -63,6 +65,32 @@ func TestManager(t *testing.T) {
 		assert.NotContains(t, messages[1].Body, "flow=")
 	})
 
+	t.Run("method=SendRecoveryLink with localized templates", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "recovery", "valid"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "recovery", "valid", "email.subject.de.gotmpl"), []byte("Zugang wiederherstellen"), 0644))
+		conf.MustSet(config.ViperKeyCourierTemplatesPath, dir)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyCourierTemplatesPath, "")
+		})
+
+		r := &http.Request{URL: urlx.ParseOrPanic("https://www.ory.sh/"), Header: http.Header{"Accept-Language": {"de-DE,de;q=0.9,en;q=0.8"}}}
+		f, err := recovery.NewFlow(conf, time.Hour, "", r, reg.RecoveryStrategies(context.Background()), flow.TypeBrowser)
+		require.NoError(t, err)
+		assert.EqualValues(t, "de-DE,de;q=0.9,en;q=0.8", f.AcceptLanguage)
+
+		require.NoError(t, reg.RecoveryFlowPersister().CreateRecoveryFlow(context.Background(), f))
+		require.NoError(t, reg.LinkSender().SendRecoveryLink(context.Background(), hr, f, "email", "tracked@ory.sh"))
+
+		messages, err := reg.CourierPersister().NextMessages(context.Background(), 12)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		assert.EqualValues(t, "tracked@ory.sh", messages[0].Recipient)
+		assert.Equal(t, "Zugang wiederherstellen", messages[0].Subject)
+		assert.Contains(t, messages[0].Body, "token=")
+	})
+
 	t.Run("method=SendVerificationLink", func(t *testing.T) {
 		f, err := verification.NewFlow(conf, time.Hour, "", u, reg.VerificationStrategies(context.Background()), flow.TypeBrowser)
 		require.NoError(t, err)
@@ -3,6 +3,7 @@ package link
 import (
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/errorx"
@@ -67,6 +68,8 @@ type (
 		SenderProvider
 
 		schema.IdentityTraitsProvider
+
+		event.StreamProvider
 	}
 
 	Strategy struct {
@@ -15,6 +15,7 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -468,6 +469,8 @@ func (s *Strategy) markRecoveryAddressVerified(w http.ResponseWriter, r *http.Re
 		if err := s.d.PrivilegedIdentityPool().UpdateVerifiableAddress(r.Context(), address); err != nil {
 			return s.HandleRecoveryError(w, r, f, nil, err)
 		}
+
+		s.d.EventStream().Emit(r.Context(), event.NewAddressVerified(id.ID, f.ID, address.Value, string(address.Via)))
 	}
 
 	return nil
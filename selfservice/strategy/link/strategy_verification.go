@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ory/kratos/event"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -237,6 +238,7 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 	}
 
 	address := token.VerifiableAddress
+	wasVerified := address.Verified
 	address.Verified = true
 	verifiedAt := sqlxx.NullTime(time.Now().UTC())
 	address.VerifiedAt = &verifiedAt
@@ -245,6 +247,10 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 		return s.retryVerificationFlowWithError(w, r, flow.TypeBrowser, err)
 	}
 
+	if !wasVerified {
+		s.d.EventStream().Emit(r.Context(), event.NewAddressVerified(address.IdentityID, f.ID, address.Value, string(address.Via)))
+	}
+
 	defaultRedirectURL := s.d.Config(r.Context()).SelfServiceFlowVerificationReturnTo(f.AppendTo(s.d.Config(r.Context()).SelfServiceFlowVerificationUI()))
 
 	verificationRequestURL, err := urlx.Parse(f.GetRequestURL())
@@ -106,6 +106,10 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, err)
 	}
 
+	if err := s.d.LoginLockoutManager().Check(r.Context(), r, s.ID(), ss.IdentityID.String()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), ss.IdentityID.String())
 	if errors.Is(err, sqlcon.ErrNoRows) {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoLookupDefined()))
@@ -131,9 +135,16 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	if !found {
+		if err := s.d.LoginLockoutManager().RecordFailure(r.Context(), f, s.ID(), ss.IdentityID.String(), ss.IdentityID); err != nil {
+			return nil, s.handleLoginError(r, f, err)
+		}
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewErrorValidationLookupInvalid()))
 	}
 
+	if err := s.d.LoginLockoutManager().Reset(r.Context(), ss.IdentityID.String()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	toUpdate, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ss.IdentityID)
 	if err != nil {
 		return nil, err
@@ -48,6 +48,7 @@ type registrationStrategyDependencies interface {
 	login.HookExecutorProvider
 	login.FlowPersistenceProvider
 	login.HandlerProvider
+	login.LockoutManagerProvider
 
 	settings.FlowPersistenceProvider
 	settings.HookExecutorProvider
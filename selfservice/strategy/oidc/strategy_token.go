@@ -0,0 +1,268 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+const RouteAdminIdentityToken = "/identities/:id/credentials/oidc/:provider/token"
+
+// An Upstream Access Token
+//
+// swagger:model identityOidcProviderToken
+type ProviderToken struct {
+	// Provider is the ID of the OpenID Connect provider which issued the token.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the identity's subject at the provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// AccessToken is a currently valid access token issued by the provider.
+	//
+	// required: true
+	AccessToken string `json:"access_token"`
+
+	// ExpiresAt is when the access token expires. It is not set if the provider did not tell.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (s *Strategy) RegisterAdminLoginRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteAdminIdentityToken, s.adminGetIdentityOidcToken)
+}
+
+// swagger:parameters adminGetIdentityOidcToken
+// nolint:deadcode,unused
+type adminGetIdentityOidcToken struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// Provider is the ID of the OpenID Connect provider as configured in
+	// `selfservice.methods.oidc.config.providers`.
+	//
+	// required: true
+	// in: path
+	Provider string `json:"provider"`
+}
+
+// swagger:route GET /admin/identities/{id}/credentials/oidc/{provider}/token v0alpha2 adminGetIdentityOidcToken
+//
+// Get an Upstream Access Token of an Identity
+//
+// Returns a currently valid access token issued by the given OpenID Connect provider for the identity's
+// linked account, which can be used to call the provider's APIs on behalf of the identity.
+//
+// If the stored access token has expired, it is refreshed using the stored refresh token. The rotated
+// tokens are stored encrypted. If the token can not be refreshed, for example because the identity
+// revoked the access at the provider, the identity has to sign in with the provider again.
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: identityOidcProviderToken
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (s *Strategy) adminGetIdentityOidcToken(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	iID, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID"))
+		return
+	}
+
+	token, err := s.ProviderToken(r.Context(), r, iID, ps.ByName("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, token)
+}
+
+// errTokenExpired is returned by providerToken if the access token has expired but may not be refreshed.
+var errTokenExpired = errors.New("the access token has expired")
+
+// ProviderToken returns a currently valid access token issued by the provider for the identity and
+// refreshes it if it expired.
+//
+// Refreshing spends the refresh token at providers which rotate them, which is why the identity is locked
+// while the token is refreshed. Within the lock, the identity is read again, because a concurrent request
+// might have refreshed the token already.
+func (s *Strategy) ProviderToken(ctx context.Context, r *http.Request, identityID uuid.UUID, providerID string) (*ProviderToken, error) {
+	token, err := s.providerToken(ctx, r, identityID, providerID, false)
+	if !errors.Is(err, errTokenExpired) {
+		return token, err
+	}
+
+	if err := s.d.PrivilegedIdentityPool().LockIdentity(ctx, identityID, func(ctx context.Context) (err error) {
+		token, err = s.providerToken(ctx, r, identityID, providerID, true)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// providerToken reads the identity's access token issued by the provider. If it expired, it is refreshed and
+// stored if refresh is true and errTokenExpired is returned otherwise.
+func (s *Strategy) providerToken(ctx context.Context, r *http.Request, identityID uuid.UUID, providerID string, refresh bool) (*ProviderToken, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identityID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf identity.CredentialsOIDC
+	creds, err := i.ParseCredentials(s.ID(), &conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var linked *identity.CredentialsOIDCProvider
+	for k := range conf.Providers {
+		if conf.Providers[k].Provider == providerID {
+			linked = &conf.Providers[k]
+			break
+		}
+	}
+	if linked == nil {
+		return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`The identity has not linked an account at OpenID Connect Provider "%s".`, providerID))
+	}
+
+	token, err := s.decryptToken(ctx, linked)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenExpired(linked, token) {
+		if !refresh {
+			return nil, errors.WithStack(errTokenExpired)
+		}
+
+		if token, err = s.refreshToken(ctx, r, providerID, token); err != nil {
+			return nil, err
+		}
+
+		if err := s.encryptToken(ctx, linked, token); err != nil {
+			return nil, err
+		}
+
+		creds.Config, err = json.Marshal(conf)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		i.SetCredentials(s.ID(), *creds)
+		if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ProviderToken{
+		Provider:    linked.Provider,
+		Subject:     linked.Subject,
+		AccessToken: token.AccessToken,
+		ExpiresAt:   linked.AccessTokenExpiresAt,
+	}, nil
+}
+
+// tokenExpired reports whether the access token has to be refreshed. Accounts linked before the expiry
+// was stored are refreshed once if possible because the age of their initial token is unknown.
+func tokenExpired(linked *identity.CredentialsOIDCProvider, token *oauth2.Token) bool {
+	if linked.AccessTokenExpiresAt == nil {
+		return linked.AccessToken == "" && token.RefreshToken != ""
+	}
+	return !token.Valid()
+}
+
+func (s *Strategy) refreshToken(ctx context.Context, r *http.Request, providerID string, token *oauth2.Token) (*oauth2.Token, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.WithStack(herodot.ErrConflict.WithReasonf(`The access token issued by OpenID Connect Provider "%s" has expired and no refresh token is available. The identity has to sign in with the provider again.`, providerID))
+	}
+
+	provider, err := s.provider(ctx, r, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := provider.OAuth2(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed, err := c.TokenSource(
+		context.WithValue(ctx, oauth2.HTTPClient, s.d.HTTPClient(ctx).HTTPClient),
+		&oauth2.Token{RefreshToken: token.RefreshToken},
+	).Token()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrConflict.
+			WithReasonf(`Unable to refresh the access token issued by OpenID Connect Provider "%s". The identity has to sign in with the provider again.`, providerID).
+			WithDebug(err.Error()))
+	}
+
+	// Providers which do not rotate refresh tokens omit them from the response.
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	return refreshed, nil
+}
+
+func (s *Strategy) decryptToken(ctx context.Context, linked *identity.CredentialsOIDCProvider) (*oauth2.Token, error) {
+	accessToken, err := s.d.Cipher().Decrypt(ctx, linked.CurrentAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.d.Cipher().Decrypt(ctx, linked.CurrentRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{AccessToken: string(accessToken), RefreshToken: string(refreshToken)}
+	if linked.AccessTokenExpiresAt != nil {
+		token.Expiry = *linked.AccessTokenExpiresAt
+	}
+
+	return token, nil
+}
+
+func (s *Strategy) encryptToken(ctx context.Context, linked *identity.CredentialsOIDCProvider, token *oauth2.Token) (err error) {
+	if linked.AccessToken, err = s.d.Cipher().Encrypt(ctx, []byte(token.AccessToken)); err != nil {
+		return err
+	}
+
+	if linked.RefreshToken, err = s.d.Cipher().Encrypt(ctx, []byte(token.RefreshToken)); err != nil {
+		return err
+	}
+
+	linked.AccessTokenExpiresAt = nil
+	if !token.Expiry.IsZero() {
+		expiresAt := token.Expiry.UTC()
+		linked.AccessTokenExpiresAt = &expiresAt
+	}
+
+	return nil
+}
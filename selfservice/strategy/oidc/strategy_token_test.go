@@ -0,0 +1,205 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/dbal"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestAdminGetIdentityOidcToken(t *testing.T) {
+	ctx := context.Background()
+	// Concurrent requests need a database which is shared by all connections.
+	conf, reg := internal.NewRegistryDefaultWithDSN(t, dbal.SQLiteSharedInMemory)
+	reg.WithCSRFHandler(x.NewFakeCSRFHandler(""))
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, []byte(`{"type": "object"}`))
+
+	var refreshes int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			issuer := "http://" + r.Host
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 issuer,
+				"authorization_endpoint": issuer + "/auth",
+				"token_endpoint":         issuer + "/token",
+				"jwks_uri":               issuer + "/jwks",
+			})
+		case "/token":
+			atomic.AddInt32(&refreshes, 1)
+			require.NoError(t, r.ParseForm())
+			if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "valid-refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token": "refreshed-access-token", "refresh_token": "valid-refresh-token", "token_type": "bearer", "expires_in": 3600}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	viperSetProviderConfig(t, conf, oidc.Configuration{
+		Provider:     "generic",
+		ID:           "valid",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		IssuerURL:    upstream.URL,
+		Mapper:       "file://./stub/oidc.hydra.jsonnet",
+	})
+
+	_, admin := testhelpers.NewKratosServer(t, reg)
+
+	encrypt := func(t *testing.T, plaintext string) string {
+		out, err := reg.Cipher().Encrypt(ctx, []byte(plaintext))
+		require.NoError(t, err)
+		return out
+	}
+	decrypt := func(t *testing.T, ciphertext string) string {
+		out, err := reg.Cipher().Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	createIdentity := func(t *testing.T, accessToken, refreshToken string, expiresAt time.Time) *identity.Identity {
+		subject := x.NewUUID().String()
+		creds, err := identity.NewCredentialsOIDC("", encrypt(t, accessToken), encrypt(t, refreshToken), expiresAt, "valid", subject)
+		require.NoError(t, err)
+
+		i := identity.NewIdentity("default")
+		i.SetCredentials(identity.CredentialsTypeOIDC, *creds)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	linkedProvider := func(t *testing.T, i *identity.Identity) identity.CredentialsOIDCProvider {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+
+		var c identity.CredentialsOIDC
+		_, err = actual.ParseCredentials(identity.CredentialsTypeOIDC, &c)
+		require.NoError(t, err)
+		require.Len(t, c.Providers, 1)
+		return c.Providers[0]
+	}
+
+	getToken := func(t *testing.T, id, provider string, expectedStatus int) []byte {
+		res, err := admin.Client().Get(admin.URL + fmt.Sprintf("/admin/identities/%s/credentials/oidc/%s/token", id, provider))
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		require.Equal(t, expectedStatus, res.StatusCode, "%s", body)
+		return body
+	}
+
+	t.Run("case=returns the stored token if it is still valid", func(t *testing.T) {
+		before := atomic.LoadInt32(&refreshes)
+		i := createIdentity(t, "stored-access-token", "valid-refresh-token", time.Now().Add(time.Hour))
+
+		body := getToken(t, i.ID.String(), "valid", http.StatusOK)
+		assert.Equal(t, "stored-access-token", gjson.GetBytes(body, "access_token").String(), "%s", body)
+		assert.Equal(t, "valid", gjson.GetBytes(body, "provider").String(), "%s", body)
+		assert.True(t, gjson.GetBytes(body, "expires_at").Exists(), "%s", body)
+		assert.Equal(t, before, atomic.LoadInt32(&refreshes))
+	})
+
+	t.Run("case=refreshes an expired token and stores the rotated tokens encrypted", func(t *testing.T) {
+		i := createIdentity(t, "expired-access-token", "valid-refresh-token", time.Now().Add(-time.Hour))
+
+		body := getToken(t, i.ID.String(), "valid", http.StatusOK)
+		assert.Equal(t, "refreshed-access-token", gjson.GetBytes(body, "access_token").String(), "%s", body)
+
+		linked := linkedProvider(t, i)
+		assert.NotEqual(t, "refreshed-access-token", linked.AccessToken)
+		assert.Equal(t, "refreshed-access-token", decrypt(t, linked.AccessToken))
+		assert.Equal(t, "valid-refresh-token", decrypt(t, linked.RefreshToken))
+		assert.Equal(t, "expired-access-token", decrypt(t, linked.InitialAccessToken))
+		require.NotNil(t, linked.AccessTokenExpiresAt)
+		assert.True(t, linked.AccessTokenExpiresAt.After(time.Now().Add(time.Minute)))
+
+		t.Run("case=does not refresh the token again", func(t *testing.T) {
+			before := atomic.LoadInt32(&refreshes)
+			body := getToken(t, i.ID.String(), "valid", http.StatusOK)
+			assert.Equal(t, "refreshed-access-token", gjson.GetBytes(body, "access_token").String(), "%s", body)
+			assert.Equal(t, before, atomic.LoadInt32(&refreshes))
+		})
+	})
+
+	t.Run("case=refreshes the token only once for concurrent requests", func(t *testing.T) {
+		before := atomic.LoadInt32(&refreshes)
+		i := createIdentity(t, "expired-access-token", "valid-refresh-token", time.Now().Add(-time.Hour))
+
+		var wg sync.WaitGroup
+		for k := 0; k < 5; k++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				body := getToken(t, i.ID.String(), "valid", http.StatusOK)
+				assert.Equal(t, "refreshed-access-token", gjson.GetBytes(body, "access_token").String(), "%s", body)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, before+1, atomic.LoadInt32(&refreshes))
+	})
+
+	t.Run("case=refreshes tokens of accounts linked without an expiry", func(t *testing.T) {
+		i := identity.NewIdentity("default")
+		i.SetCredentials(identity.CredentialsTypeOIDC, identity.Credentials{
+			Type:        identity.CredentialsTypeOIDC,
+			Identifiers: []string{identity.OIDCUniqueID("valid", "legacy")},
+			Config: []byte(fmt.Sprintf(`{"providers": [{"subject": "legacy", "provider": "valid", "initial_access_token": "%s", "initial_refresh_token": "%s"}]}`,
+				encrypt(t, "initial-access-token"), encrypt(t, "valid-refresh-token"))),
+		})
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+
+		body := getToken(t, i.ID.String(), "valid", http.StatusOK)
+		assert.Equal(t, "refreshed-access-token", gjson.GetBytes(body, "access_token").String(), "%s", body)
+	})
+
+	t.Run("case=fails if the upstream rejects the refresh token", func(t *testing.T) {
+		i := createIdentity(t, "expired-access-token", "revoked-refresh-token", time.Now().Add(-time.Hour))
+		body := getToken(t, i.ID.String(), "valid", http.StatusConflict)
+		assert.Contains(t, gjson.GetBytes(body, "error.reason").String(), "sign in with the provider again", "%s", body)
+	})
+
+	t.Run("case=fails if the token expired and there is no refresh token", func(t *testing.T) {
+		i := createIdentity(t, "expired-access-token", "", time.Now().Add(-time.Hour))
+		getToken(t, i.ID.String(), "valid", http.StatusConflict)
+	})
+
+	t.Run("case=fails if the identity has not linked the provider", func(t *testing.T) {
+		i := createIdentity(t, "stored-access-token", "valid-refresh-token", time.Now().Add(time.Hour))
+		getToken(t, i.ID.String(), "other", http.StatusNotFound)
+
+		i = identity.NewIdentity("default")
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		getToken(t, i.ID.String(), "valid", http.StatusNotFound)
+	})
+
+	t.Run("case=fails if the identity does not exist", func(t *testing.T) {
+		getToken(t, x.NewUUID().String(), "valid", http.StatusNotFound)
+		getToken(t, "not-a-uuid", "valid", http.StatusBadRequest)
+	})
+}
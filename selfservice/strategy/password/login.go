@@ -66,10 +66,15 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(w, r, f, &p, err)
 	}
 
-	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), stringsx.Coalesce(p.Identifier, p.LegacyIdentifier))
+	identifier := stringsx.Coalesce(p.Identifier, p.LegacyIdentifier)
+	if err := s.d.LoginLockoutManager().Check(r.Context(), r, s.ID(), identifier); err != nil {
+		return nil, s.handleLoginError(w, r, f, &p, err)
+	}
+
+	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), identifier)
 	if err != nil {
 		time.Sleep(x.RandomDelay(s.d.Config(r.Context()).HasherArgon2().ExpectedDuration, s.d.Config(r.Context()).HasherArgon2().ExpectedDeviation))
-		return nil, s.handleLoginError(w, r, f, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
+		return nil, s.handleLoginError(w, r, f, &p, s.recordLoginFailure(r, f, identifier, uuid.Nil))
 	}
 
 	var o identity.CredentialsPassword
@@ -79,12 +84,25 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	if err := hash.Compare(r.Context(), []byte(p.Password), []byte(o.HashedPassword)); err != nil {
-		return nil, s.handleLoginError(w, r, f, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
+		return nil, s.handleLoginError(w, r, f, &p, s.recordLoginFailure(r, f, identifier, i.ID))
+	}
+
+	if err := s.d.LoginLockoutManager().Reset(r.Context(), identifier); err != nil {
+		return nil, s.handleLoginError(w, r, f, &p, err)
 	}
 
-	if !s.d.Hasher().Understands([]byte(o.HashedPassword)) {
-		if err := s.migratePasswordHash(r.Context(), i.ID, []byte(p.Password)); err != nil {
-			return nil, s.handleLoginError(w, r, f, &p, err)
+	if s.d.Hasher().NeedsRehash(r.Context(), []byte(o.HashedPassword)) {
+		reason := hashUpgradeReasonParameters
+		if !s.d.Hasher().Understands([]byte(o.HashedPassword)) {
+			reason = hashUpgradeReasonAlgorithm
+		}
+
+		// The password was correct, which is why a failing upgrade must not fail the login. The hash will be
+		// upgraded on one of the next logins instead.
+		if upgraded, err := s.migratePasswordHash(r.Context(), i.ID, []byte(p.Password), []byte(o.HashedPassword)); err != nil {
+			s.d.Logger().WithRequest(r).WithError(err).Warn("Unable to upgrade the password hash.")
+		} else if upgraded {
+			hashUpgrades.WithLabelValues(reason).Inc()
 		}
 	}
 
@@ -97,30 +115,63 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	return i, nil
 }
 
-func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID, password []byte) error {
+// recordLoginFailure records the failed attempt and returns the invalid credentials error.
+func (s *Strategy) recordLoginFailure(r *http.Request, f *login.Flow, identifier string, identityID uuid.UUID) error {
+	if err := s.d.LoginLockoutManager().RecordFailure(r.Context(), f, s.ID(), identifier, identityID); err != nil {
+		return err
+	}
+	return errors.WithStack(schema.NewInvalidCredentialsError())
+}
+
+// migratePasswordHash replaces the compared hash with a hash of the password generated using the current hasher
+// configuration. It reports whether the hash was replaced.
+func (s *Strategy) migratePasswordHash(ctx context.Context, identifier uuid.UUID, password, compared []byte) (bool, error) {
 	hpw, err := s.d.Hasher().Generate(ctx, password)
 	if err != nil {
-		return err
+		return false, err
 	}
 	co, err := json.Marshal(&identity.CredentialsPassword{HashedPassword: string(hpw)})
 	if err != nil {
-		return errors.Wrap(err, "unable to encode password configuration to JSON")
+		return false, errors.Wrap(err, "unable to encode password configuration to JSON")
 	}
 
-	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identifier)
-	if err != nil {
-		return err
-	}
+	// The identity is locked before it is loaded so that concurrent changes to the identity are
+	// not overwritten by the upgraded hash.
+	var upgraded bool
+	if err := s.d.PrivilegedIdentityPool().LockIdentity(ctx, identifier, func(ctx context.Context) error {
+		i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, identifier)
+		if err != nil {
+			return err
+		}
 
-	c, ok := i.GetCredentials(s.ID())
-	if !ok {
-		return errors.New("expected to find password credential but could not")
-	}
+		c, ok := i.GetCredentials(s.ID())
+		if !ok {
+			return errors.New("expected to find password credential but could not")
+		}
 
-	c.Config = co
-	i.SetCredentials(s.ID(), *c)
+		// The password might have been changed since it was compared. The new password must not be replaced
+		// by a hash of the old one.
+		var stored identity.CredentialsPassword
+		if err := json.Unmarshal(c.Config, &stored); err != nil {
+			return errors.Wrap(err, "unable to decode password configuration from JSON")
+		}
+		if stored.HashedPassword != string(compared) {
+			return nil
+		}
+
+		c.Config = co
+		i.SetCredentials(s.ID(), *c)
+
+		if err := s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i); err != nil {
+			return err
+		}
+		upgraded = true
+		return nil
+	}); err != nil {
+		return false, err
+	}
 
-	return s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i)
+	return upgraded, nil
 }
 
 func (s *Strategy) PopulateLoginMethod(r *http.Request, requestedAAL identity.AuthenticatorAssuranceLevel, sr *login.Flow) error {
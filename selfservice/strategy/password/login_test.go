@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,12 +19,14 @@ import (
 	"github.com/ory/kratos/selfservice/flow"
 
 	"github.com/gofrs/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ory/x/urlx"
 
 	kratos "github.com/ory/kratos-client-go"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/x/assertx"
+	"github.com/ory/x/dbal"
 	"github.com/ory/x/errorsx"
 	"github.com/ory/x/ioutilx"
 	"github.com/ory/x/sqlxx"
@@ -50,7 +53,7 @@ func TestCompleteLogin(t *testing.T) {
 	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword),
 		map[string]interface{}{"enabled": true})
 	router := x.NewRouterPublic()
-	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+	publicTS, adminTS := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
 
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
@@ -800,7 +803,7 @@ func TestCompleteLogin(t *testing.T) {
 	})
 
 	t.Run("should upgrade password not primary hashing algorithm", func(t *testing.T) {
-		identifier, pwd := x.NewUUID().String(), "password"
+		pwd := "password"
 		h := &hash.Pbkdf2{
 			Algorithm:  "sha256",
 			Iterations: 100000,
@@ -809,6 +812,75 @@ func TestCompleteLogin(t *testing.T) {
 		}
 		p, _ := h.Generate(context.Background(), []byte(pwd))
 
+		for name, hashed := range map[string]string{
+			"pbkdf2":   string(p),
+			"md5crypt": "$1$HHkq5gKN$sx5gX55iHca8NTE8j35pE0",
+			"ssha512":  "{SSHA512}7dgD64B0H25CRih+tTBTZNNef6cNPa1Wt3LLQLmrb8xmCqQrtq3yajPJmCC2AC7gyQiQl7sXCmaa5uC3qEeMrIofAnfRMwS+",
+		} {
+			t.Run("hash="+name, func(t *testing.T) {
+				identifier := x.NewUUID().String()
+
+				iId := x.NewUUID()
+				require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+					ID:     iId,
+					Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
+					Credentials: map[identity.CredentialsType]identity.Credentials{
+						identity.CredentialsTypePassword: {
+							Type:        identity.CredentialsTypePassword,
+							Identifiers: []string{identifier},
+							Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + hashed + `"}`),
+						},
+					},
+					VerifiableAddresses: []identity.VerifiableAddress{
+						{
+							ID:         x.NewUUID(),
+							Value:      identifier,
+							Verified:   true,
+							CreatedAt:  time.Now(),
+							IdentityID: iId,
+						},
+					},
+				}))
+
+				var values = func(v url.Values) {
+					v.Set("identifier", identifier)
+					v.Set("method", identity.CredentialsTypePassword.String())
+					v.Set("password", pwd)
+				}
+
+				browserClient := testhelpers.NewClientWithCookies(t)
+
+				body := testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
+					false, false, http.StatusOK, redirTS.URL)
+
+				assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+
+				// check if password hash algorithm is upgraded
+				_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, identifier)
+				require.NoError(t, err)
+				var o identity.CredentialsPassword
+				require.NoError(t, json.NewDecoder(bytes.NewBuffer(c.Config)).Decode(&o))
+				assert.True(t, reg.Hasher().Understands([]byte(o.HashedPassword)), "%s", o.HashedPassword)
+				assert.True(t, hash.IsBcryptHash([]byte(o.HashedPassword)), "%s", o.HashedPassword)
+
+				// retry after upgraded
+				body = testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
+					false, true, http.StatusOK, redirTS.URL)
+				assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+			})
+		}
+	})
+
+	t.Run("should upgrade password hash when hasher parameters change", func(t *testing.T) {
+		pwd := "password"
+		identifier := x.NewUUID().String()
+
+		conf.MustSet(config.ViperKeyHasherBcryptCost, 5)
+		hashed, err := reg.Hasher().Generate(context.Background(), []byte(pwd))
+		require.NoError(t, err)
+		conf.MustSet(config.ViperKeyHasherBcryptCost, 4)
+		require.True(t, reg.Hasher().NeedsRehash(context.Background(), hashed))
+
 		iId := x.NewUUID()
 		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
 			ID:     iId,
@@ -817,7 +889,7 @@ func TestCompleteLogin(t *testing.T) {
 				identity.CredentialsTypePassword: {
 					Type:        identity.CredentialsTypePassword,
 					Identifiers: []string{identifier},
-					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(hashed) + `"}`),
 				},
 			},
 			VerifiableAddresses: []identity.VerifiableAddress{
@@ -837,24 +909,201 @@ func TestCompleteLogin(t *testing.T) {
 			v.Set("password", pwd)
 		}
 
+		upgrades := hashUpgrades(t, "parameters")
 		browserClient := testhelpers.NewClientWithCookies(t)
-
 		body := testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
 			false, false, http.StatusOK, redirTS.URL)
-
 		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
 
-		// check if password hash algorithm is upgraded
 		_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, identifier)
 		require.NoError(t, err)
 		var o identity.CredentialsPassword
 		require.NoError(t, json.NewDecoder(bytes.NewBuffer(c.Config)).Decode(&o))
-		assert.True(t, reg.Hasher().Understands([]byte(o.HashedPassword)), "%s", o.HashedPassword)
-		assert.True(t, hash.IsBcryptHash([]byte(o.HashedPassword)), "%s", o.HashedPassword)
+		assert.NotEqual(t, string(hashed), o.HashedPassword)
+		assert.False(t, reg.Hasher().NeedsRehash(context.Background(), []byte(o.HashedPassword)), "%s", o.HashedPassword)
+		assert.Equal(t, upgrades+1, hashUpgrades(t, "parameters"))
 
-		// retry after upgraded
+		// The hash is up to date now, which is why it is not upgraded again.
 		body = testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, values,
 			false, true, http.StatusOK, redirTS.URL)
 		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+		assert.Equal(t, upgrades+1, hashUpgrades(t, "parameters"))
 	})
+
+	t.Run("should sign in even if the password hash can not be upgraded", func(t *testing.T) {
+		// bcrypt rejects passwords longer than 72 bytes, which is why this hash can not be upgraded.
+		pwd := strings.Repeat("a", 80)
+		identifier := x.NewUUID().String()
+		h := &hash.Pbkdf2{
+			Algorithm:  "sha256",
+			Iterations: 100000,
+			SaltLength: 32,
+			KeyLength:  32,
+		}
+		hashed, err := h.Generate(context.Background(), []byte(pwd))
+		require.NoError(t, err)
+
+		iId := x.NewUUID()
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+			ID:     iId,
+			Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
+			Credentials: map[identity.CredentialsType]identity.Credentials{
+				identity.CredentialsTypePassword: {
+					Type:        identity.CredentialsTypePassword,
+					Identifiers: []string{identifier},
+					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(hashed) + `"}`),
+				},
+			},
+			VerifiableAddresses: []identity.VerifiableAddress{
+				{
+					ID:         x.NewUUID(),
+					Value:      identifier,
+					Verified:   true,
+					CreatedAt:  time.Now(),
+					IdentityID: iId,
+				},
+			},
+		}))
+
+		var values = func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("method", identity.CredentialsTypePassword.String())
+			v.Set("password", pwd)
+		}
+
+		upgrades := hashUpgrades(t, "algorithm")
+		body := testhelpers.SubmitLoginForm(t, false, testhelpers.NewClientWithCookies(t), publicTS, values,
+			false, false, http.StatusOK, redirTS.URL)
+		assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+
+		_, c, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, identifier)
+		require.NoError(t, err)
+		var o identity.CredentialsPassword
+		require.NoError(t, json.NewDecoder(bytes.NewBuffer(c.Config)).Decode(&o))
+		assert.Equal(t, string(hashed), o.HashedPassword)
+		assert.Equal(t, upgrades, hashUpgrades(t, "algorithm"))
+	})
+
+	t.Run("should lock out the identifier after too many failed attempts", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceLoginAfter+".password.hooks", nil)
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, true)
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutMaxAttempts, 2)
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutDelay, "1ms")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, false)
+		})
+
+		identifier, pwd := x.NewUUID().String(), "password"
+		createIdentity(identifier, pwd)
+
+		var wrong = func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("password", "not-password")
+		}
+		var correct = func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("password", pwd)
+		}
+
+		for k := 0; k < 2; k++ {
+			body := expectValidationError(t, true, false, false, wrong)
+			assert.EqualValues(t, text.ErrorValidationInvalidCredentials, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		}
+
+		body := testhelpers.SubmitLoginForm(t, true, nil, publicTS, correct, false, false,
+			http.StatusTooManyRequests, publicTS.URL+login.RouteSubmitFlow)
+		assert.EqualValues(t, text.ErrorValidationLoginLockedOut, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "ui.messages.0.context.locked_until").String(), "%s", body)
+
+		i, _, err := reg.PrivilegedIdentityPool().FindByCredentialsIdentifier(context.Background(), identity.CredentialsTypePassword, identifier)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("DELETE", adminTS.URL+"/admin/identities/"+i.ID.String()+"/lockout", nil)
+		require.NoError(t, err)
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		body = testhelpers.SubmitLoginForm(t, true, nil, publicTS, correct, false, false,
+			http.StatusOK, publicTS.URL+login.RouteSubmitFlow)
+		assert.Equal(t, identifier, gjson.Get(body, "session.identity.traits.subject").String(), "%s", body)
+	})
+}
+
+// hashUpgrades returns the number of password hashes which were upgraded for the given reason.
+func hashUpgrades(t *testing.T, reason string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() != "kratos_password_hash_upgrades_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "reason" && l.GetValue() == reason {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestCompleteLoginWithParallelAttempts(t *testing.T) {
+	// Parallel requests need a database which is shared by all connections.
+	conf, reg := internal.NewRegistryDefaultWithDSN(t, dbal.SQLiteSharedInMemory)
+	reg.WithCSRFHandler(x.NewFakeCSRFHandler(""))
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword),
+		map[string]interface{}{"enabled": true})
+	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, x.NewRouterPublic(), x.NewRouterAdmin())
+	conf.MustSet(config.ViperKeySelfServiceErrorUI, testhelpers.NewErrorTestServer(t, reg).URL+"/error-ts")
+	conf.MustSet(config.ViperKeySelfServiceLoginUI, testhelpers.NewLoginUIFlowEchoServer(t, reg).URL+"/login-ts")
+	testhelpers.SetDefaultIdentitySchemaFromRaw(conf, loginSchema)
+	conf.MustSet(config.ViperKeySecretsDefault, []string{"not-a-secure-session-key"})
+
+	conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, true)
+	conf.MustSet(config.ViperKeySelfServiceLoginLockoutMaxAttempts, 2)
+	conf.MustSet(config.ViperKeySelfServiceLoginLockoutDelay, "1ms")
+
+	identifier := x.NewUUID().String()
+	p, err := reg.Hasher().Generate(context.Background(), []byte("password"))
+	require.NoError(t, err)
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+		Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{identifier},
+				Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+			},
+		},
+	}))
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 6)
+	for k := range bodies {
+		hc := new(http.Client)
+		f := testhelpers.InitializeLoginFlow(t, true, hc, publicTS, false, false)
+		payload := testhelpers.SDKFormFieldsToURLValues(f.Ui.Nodes)
+		payload.Set("identifier", identifier)
+		payload.Set("password", "not-password")
+
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			bodies[k], _ = testhelpers.LoginMakeRequest(t, true, false, f, hc, testhelpers.EncodeFormAsJSON(t, true, payload))
+		}(k)
+	}
+	wg.Wait()
+
+	var compared int
+	for _, body := range bodies {
+		if text.ID(gjson.Get(body, "ui.messages.0.id").Int()) == text.ErrorValidationInvalidCredentials {
+			compared++
+		} else {
+			assert.EqualValues(t, text.ErrorValidationLoginLockedOut, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		}
+	}
+	assert.LessOrEqual(t, compared, 2)
 }
@@ -0,0 +1,21 @@
+package password
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	hashUpgradeReasonAlgorithm  = "algorithm"
+	hashUpgradeReasonParameters = "parameters"
+)
+
+// hashUpgrades counts password hashes which were regenerated on login, either because they were
+// created with a different algorithm or because the hasher parameters changed since.
+var hashUpgrades = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "kratos",
+	Subsystem: "password",
+	Name:      "hash_upgrades_total",
+	Help:      "Number of password hashes which were upgraded to the configured hasher and parameters on login.",
+}, []string{"reason"})
+
+func init() {
+	prometheus.MustRegister(hashUpgrades)
+}
@@ -103,6 +103,10 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 		return nil, s.handleLoginError(r, f, err)
 	}
 
+	if err := s.d.LoginLockoutManager().Check(r.Context(), r, s.ID(), ss.IdentityID.String()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), ss.IdentityID.String())
 	if err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewNoTOTPDeviceRegistered()))
@@ -119,9 +123,16 @@ func (s *Strategy) Login(w http.ResponseWriter, r *http.Request, f *login.Flow,
 	}
 
 	if !totp.Validate(p.TOTPCode, key.Secret()) {
+		if err := s.d.LoginLockoutManager().RecordFailure(r.Context(), f, s.ID(), ss.IdentityID.String(), ss.IdentityID); err != nil {
+			return nil, s.handleLoginError(r, f, err)
+		}
 		return nil, s.handleLoginError(r, f, errors.WithStack(schema.NewTOTPVerifierWrongError("#/")))
 	}
 
+	if err := s.d.LoginLockoutManager().Reset(r.Context(), ss.IdentityID.String()); err != nil {
+		return nil, s.handleLoginError(r, f, err)
+	}
+
 	f.Active = s.ID()
 	if err = s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), f); err != nil {
 		return nil, s.handleLoginError(r, f, errors.WithStack(herodot.ErrInternalServerError.WithReason("Could not update flow").WithDebug(err.Error())))
@@ -85,7 +85,7 @@ func TestCompleteLogin(t *testing.T) {
 	conf.MustSet(config.ViperKeyURLsAllowedReturnToDomains, []string{"https://www.ory.sh"})
 
 	router := x.NewRouterPublic()
-	publicTS, _ := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
+	publicTS, adminTS := testhelpers.NewKratosServerWithRouters(t, reg, router, x.NewRouterAdmin())
 
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
@@ -347,6 +347,47 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("case=should lock out the identity after too many wrong codes", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, true)
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutMaxAttempts, 2)
+		conf.MustSet(config.ViperKeySelfServiceLoginLockoutDelay, "1ms")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceLoginLockoutEnabled, false)
+		})
+
+		id, _, key := createIdentity(t, reg)
+		wrong := func(v url.Values) {
+			v.Set("totp_code", "111111")
+		}
+		correct := func(v url.Values) {
+			code, err := stdtotp.GenerateCode(key.Secret(), time.Now())
+			require.NoError(t, err)
+			v.Set("totp_code", code)
+		}
+
+		for k := 0; k < 2; k++ {
+			body, res := doAPIFlow(t, wrong, id)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode, "%s", body)
+			assert.EqualValues(t, text.ErrorValidationTOTPVerifierWrong, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		}
+
+		body, res := doAPIFlow(t, correct, id)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode, "%s", body)
+		assert.EqualValues(t, text.ErrorValidationLoginLockedOut, gjson.Get(body, "ui.messages.0.id").Int(), "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "ui.messages.0.context.locked_until").String(), "%s", body)
+
+		req, err := http.NewRequest("DELETE", adminTS.URL+"/admin/identities/"+id.ID.String()+"/lockout", nil)
+		require.NoError(t, err)
+		res, err = adminTS.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		body, res = doAPIFlow(t, correct, id)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.EqualValues(t, identity.AuthenticatorAssuranceLevel2, gjson.Get(body, "session.authenticator_assurance_level").String(), "%s", body)
+	})
+
 	t.Run("case=should fail because totp can not handle AAL1", func(t *testing.T) {
 		apiClient := testhelpers.NewDebugClient(t)
 		f := testhelpers.InitializeLoginFlowViaAPI(t, apiClient, publicTS, false)
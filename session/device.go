@@ -0,0 +1,119 @@
+package session
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/driver/config"
+)
+
+const (
+	maxDeviceFieldLength = 512
+
+	// maxSessionDevices is the number of devices recorded per session. Once it is reached, the least recently
+	// seen device is replaced.
+	maxSessionDevices = 32
+)
+
+// Device corresponds to a device (IP address and user agent) a session was used from.
+//
+// swagger:model sessionDevice
+type Device struct {
+	// Device record ID
+	//
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	// The IP address the session was used from.
+	IPAddress string `json:"ip_address" faker:"ipv4" db:"ip_address"`
+
+	// The user agent of the client the session was used from.
+	UserAgent string `json:"user_agent" db:"user_agent"`
+
+	// The approximate geo location of the IP address, if it could be resolved.
+	Location string `json:"location,omitempty" faker:"-" db:"location"`
+
+	// First Seen At
+	//
+	// When the session was first used from this device.
+	FirstSeenAt time.Time `json:"first_seen_at" faker:"-" db:"created_at"`
+
+	// Last Seen At
+	//
+	// When the session was last issued or refreshed on this device.
+	LastSeenAt time.Time `json:"last_seen_at" faker:"-" db:"updated_at"`
+
+	// SessionID is a helper struct field for gobuffalo.pop.
+	SessionID uuid.UUID `json:"-" faker:"-" db:"session_id"`
+	NID       uuid.UUID `json:"-" faker:"-" db:"nid"`
+}
+
+func (d Device) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "session_devices")
+}
+
+// deviceIPAddress returns the canonical form of the client IP address, or an empty string if it is not a valid
+// IP address, for example because the server listens on a unix socket.
+func deviceIPAddress(v string) string {
+	ip := net.ParseIP(v)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// truncateDeviceField removes invalid UTF-8 and truncates the value to at most maxDeviceFieldLength bytes
+// without splitting a rune.
+func truncateDeviceField(v string) string {
+	v = strings.ToValidUTF8(v, "")
+	if len(v) <= maxDeviceFieldLength {
+		return v
+	}
+
+	end := maxDeviceFieldLength
+	for end > 0 && !utf8.RuneStart(v[end]) {
+		end--
+	}
+	return v[:end]
+}
+
+type (
+	// GeoLocationResolver resolves the approximate geo location of the client which made the request,
+	// for example "Berlin, DE". An empty location is returned if it can not be determined.
+	GeoLocationResolver interface {
+		ResolveGeoLocation(ctx context.Context, r *http.Request, ip string) (string, error)
+	}
+	GeoLocationResolverProvider interface {
+		SessionGeoLocationResolver() GeoLocationResolver
+	}
+
+	// HeaderGeoLocationResolver resolves the geo location from HTTP headers set by a reverse proxy or CDN,
+	// for example Cloudflare's `Cf-Ipcity` and `Cf-Ipcountry` headers.
+	HeaderGeoLocationResolver struct {
+		d config.Provider
+	}
+)
+
+var _ GeoLocationResolver = new(HeaderGeoLocationResolver)
+
+func NewHeaderGeoLocationResolver(d config.Provider) *HeaderGeoLocationResolver {
+	return &HeaderGeoLocationResolver{d: d}
+}
+
+// ResolveGeoLocation joins the values of the configured headers, skipping headers which are not set.
+func (g *HeaderGeoLocationResolver) ResolveGeoLocation(ctx context.Context, r *http.Request, _ string) (string, error) {
+	var parts []string
+	for _, h := range g.d.Config(ctx).SessionDevicesGeoLocationHeaders() {
+		if v := strings.TrimSpace(r.Header.Get(h)); len(v) > 0 {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", "), nil
+}
@@ -0,0 +1,45 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateDeviceField(t *testing.T) {
+	for k, tc := range []struct {
+		in, expected string
+	}{
+		{in: "", expected: ""},
+		{in: "Mozilla/5.0", expected: "Mozilla/5.0"},
+		{in: strings.Repeat("a", maxDeviceFieldLength+1), expected: strings.Repeat("a", maxDeviceFieldLength)},
+		{in: strings.Repeat("a", maxDeviceFieldLength-1) + "ü", expected: strings.Repeat("a", maxDeviceFieldLength-1)},
+		{in: "invalid\xff", expected: "invalid"},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			actual := truncateDeviceField(tc.in)
+			assert.Equal(t, tc.expected, actual)
+			assert.True(t, utf8.ValidString(actual))
+		})
+	}
+}
+
+func TestDeviceIPAddress(t *testing.T) {
+	for k, tc := range []struct {
+		in, expected string
+	}{
+		{in: "", expected: ""},
+		{in: "192.0.2.1", expected: "192.0.2.1"},
+		{in: "2001:0db8:0000:0000:0000:0000:0000:0001", expected: "2001:db8::1"},
+		{in: "@", expected: ""},
+		{in: "/var/run/kratos.sock", expected: ""},
+		{in: strings.Repeat("a", maxDeviceFieldLength), expected: ""},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			assert.Equal(t, tc.expected, deviceIPAddress(tc.in))
+		})
+	}
+}
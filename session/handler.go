@@ -3,6 +3,7 @@ package session
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ory/x/pointerx"
 
@@ -11,10 +12,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/x/decoderx"
+	"github.com/ory/x/urlx"
 
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
 )
 
@@ -22,10 +26,12 @@ type (
 	handlerDependencies interface {
 		ManagementProvider
 		PersistenceProvider
+		TokenizerProvider
 		x.WriterProvider
 		x.LoggingProvider
 		x.CSRFProvider
 		config.Provider
+		event.StreamProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -49,6 +55,7 @@ const (
 	RouteCollection = "/sessions"
 	RouteWhoami     = RouteCollection + "/whoami"
 	RouteSession    = RouteCollection + "/:id"
+	RouteJWKS       = "/.well-known/ory/sessions/jwks.json"
 )
 
 const (
@@ -62,11 +69,14 @@ func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.DELETE(AdminRouteIdentitiesSessions, h.adminDeleteIdentitySessions)
 	admin.PATCH(AdminRouteSessionExtendId, h.adminSessionExtend)
 
+	admin.GET(RouteCollection, h.adminListSessions)
+	admin.GET(RouteSession, h.adminGetSession)
+	admin.DELETE(RouteSession, h.adminRevokeSession)
+
 	admin.DELETE(RouteCollection, x.RedirectToPublicRoute(h.r))
-	admin.DELETE(RouteSession, x.RedirectToPublicRoute(h.r))
-	admin.GET(RouteCollection, x.RedirectToPublicRoute(h.r))
 
-	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut} {
+	// GET is redirected by adminGetSession because the route conflicts with RouteSession.
+	for _, m := range []string{http.MethodHead, http.MethodPost, http.MethodPut} {
 		// Redirect to public endpoint
 		admin.Handle(m, RouteWhoami, x.RedirectToPublicRoute(h.r))
 	}
@@ -87,6 +97,7 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	public.DELETE(RouteCollection, h.revokeSessions)
 	public.DELETE(RouteSession, h.revokeSession)
 	public.GET(RouteCollection, h.listSessions)
+	public.GET(RouteJWKS, h.getJsonWebKeySet)
 
 	public.DELETE(AdminRouteIdentitiesSessions, x.RedirectToAdminRoute(h.r))
 }
@@ -109,6 +120,17 @@ type toSession struct {
 	Cookie string `json:"Cookie"`
 }
 
+// nolint:deadcode,unused
+// swagger:parameters toSession
+type toSessionTokenizeAs struct {
+	// Returns the session additionally as a token (such as a JWT) in the `tokenized` field of the session.
+	//
+	// The value is the name of the tokenizer template configured in `session.whoami.tokenizer.templates`.
+	//
+	// in: query
+	TokenizeAs string `json:"tokenize_as"`
+}
+
 // swagger:route GET /sessions/whoami v0alpha2 toSession
 //
 // Check Who the Current HTTP Session Belongs To
@@ -158,6 +180,10 @@ type toSession struct {
 //
 // If none of these headers are set or the cooke or token are invalid, the endpoint returns a HTTP 401 status code.
 //
+// If the `tokenize_as` query parameter is set, the session is additionally returned as a short-lived JSON Web Token
+// in the `tokenized` field. The token is signed with the keys of the tokenizer template and can be verified
+// using the keys published at `/.well-known/ory/sessions/jwks.json`.
+//
 // As explained above, this request may fail due to several reasons. The `error.id` can be one of:
 //
 // - `session_inactive`: No active session was found in the request (e.g. no Ory Session Cookie / Ory Session Token).
@@ -170,6 +196,7 @@ type toSession struct {
 //
 //     Responses:
 //       200: session
+//       400: jsonError
 //       401: jsonError
 //       403: jsonError
 //       500: jsonError
@@ -196,12 +223,55 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	// s.Devices = nil
 	s.Identity = s.Identity.CopyWithoutCredentials()
 
+	if template := r.URL.Query().Get("tokenize_as"); len(template) > 0 {
+		if err := h.r.SessionTokenizer().TokenizeSession(r.Context(), template, s); err != nil {
+			h.r.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
 	// Set userId as the X-Kratos-Authenticated-Identity-Id header.
 	w.Header().Set("X-Kratos-Authenticated-Identity-Id", s.Identity.ID.String())
 
 	h.r.Writer().Write(w, r, s)
 }
 
+// JSON Web Key Set
+//
+// swagger:model jsonWebKeySet
+// nolint:deadcode,unused
+type jsonWebKeySet struct {
+	// The public keys which are used to sign session tokens.
+	//
+	// required: true
+	Keys []map[string]interface{} `json:"keys"`
+}
+
+// swagger:route GET /.well-known/ory/sessions/jwks.json v0alpha2 getSessionJsonWebKeySet
+//
+// Get the JSON Web Key Set for Session Tokens
+//
+// This endpoint returns the public keys which are used to sign the session tokens returned by
+// `/sessions/whoami?tokenize_as=...`. Use it to verify session tokens without calling Ory Kratos.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: jsonWebKeySet
+//       500: jsonError
+func (h *Handler) getJsonWebKeySet(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	keys, err := h.r.SessionTokenizer().PublicKeys(r.Context())
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, keys)
+}
+
 // swagger:parameters adminDeleteIdentitySessions
 // nolint:deadcode,unused
 type adminDeleteIdentitySessions struct {
@@ -242,6 +312,8 @@ func (h *Handler) adminDeleteIdentitySessions(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	h.r.EventStream().Emit(r.Context(), event.New(event.TypeSessionRevoked, iID))
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -306,6 +378,223 @@ func (h *Handler) adminListIdentitySessions(w http.ResponseWriter, r *http.Reque
 	h.r.Writer().Write(w, r, sess)
 }
 
+// swagger:parameters adminListSessions
+// nolint:deadcode,unused
+type adminListSessions struct {
+	x.PaginationParams
+
+	// Returns sessions in this active state. Expired sessions are never active.
+	//
+	// required: false
+	// in: query
+	Active *bool `json:"active"`
+
+	// Returns sessions with this Authenticator Assurance Level, for example `aal2`.
+	//
+	// required: false
+	// in: query
+	AAL identity.AuthenticatorAssuranceLevel `json:"aal"`
+
+	// Returns sessions which were authenticated using this method, for example `password` or `totp`.
+	//
+	// required: false
+	// in: query
+	AuthenticationMethod identity.CredentialsType `json:"authentication_method"`
+
+	// Returns sessions authenticated at or after this time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	AuthenticatedAfter time.Time `json:"authenticated_after"`
+
+	// Returns sessions authenticated before this time (RFC 3339).
+	//
+	// required: false
+	// in: query
+	AuthenticatedBefore time.Time `json:"authenticated_before"`
+}
+
+func parseListSessionsParameters(r *http.Request) (params ListSessionsParameters, err error) {
+	query := r.URL.Query()
+	params.Page, params.PerPage = x.ParsePagination(r)
+
+	if active := query.Get("active"); len(active) > 0 {
+		v, err := strconv.ParseBool(active)
+		if err != nil {
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `active`.", active))
+		}
+		params.Active = &v
+	}
+
+	if aal := query.Get("aal"); len(aal) > 0 {
+		switch params.AAL = identity.AuthenticatorAssuranceLevel(aal); params.AAL {
+		case identity.NoAuthenticatorAssuranceLevel, identity.AuthenticatorAssuranceLevel1, identity.AuthenticatorAssuranceLevel2, identity.AuthenticatorAssuranceLevel3:
+		default:
+			return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `aal`.", aal))
+		}
+	}
+
+	params.AuthenticationMethod = identity.CredentialsType(query.Get("authentication_method"))
+
+	for key, t := range map[string]*time.Time{"authenticated_after": &params.AuthenticatedAfter, "authenticated_before": &params.AuthenticatedBefore} {
+		if value := query.Get(key); len(value) > 0 {
+			if *t, err = time.Parse(time.RFC3339, value); err != nil {
+				return params, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Invalid value `%s` for parameter `%s`, expected an RFC 3339 timestamp.", value, key))
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// swagger:route GET /admin/sessions v0alpha2 adminListSessions
+//
+// List Sessions
+//
+// Lists the sessions of all identities, most recently authenticated first. The result can be filtered by
+// active state, Authenticator Assurance Level, authentication method, and authentication time.
+//
+// This endpoint is useful for:
+//
+// - Seeing who is signed in right now, for example `?active=true`.
+// - Auditing sessions in an administrative context.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: sessionList
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) adminListSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	params, err := parseListSessionsParameters(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	sess, err := h.r.SessionPersister().ListSessions(r.Context(), params)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	total, err := h.r.SessionPersister().CountSessions(r.Context(), params)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection), total, params.Page, params.PerPage)
+	h.r.Writer().Write(w, r, sess)
+}
+
+// swagger:parameters adminGetSession
+// nolint:deadcode,unused
+type adminGetSession struct {
+	// ID is the session's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/sessions/{id} v0alpha2 adminGetSession
+//
+// Get a Session
+//
+// Returns the session with the given ID, including its identity, regardless of whether it is still active.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: session
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminGetSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if ps.ByName("id") == "whoami" {
+		// Special case where we redirect to the public whoami endpoint.
+		x.RedirectToPublicRoute(h.r)(w, r, ps)
+		return
+	}
+
+	sID, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	s, err := h.r.SessionPersister().GetSession(r.Context(), sID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, s)
+}
+
+// swagger:parameters adminRevokeSession
+// nolint:deadcode,unused
+type adminRevokeSession struct {
+	// ID is the session's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /admin/sessions/{id} v0alpha2 adminRevokeSession
+//
+// Revoke a Session
+//
+// Calling this endpoint invalidates the session with the given ID, which signs the identity out on the device
+// the session belongs to. Session data are not deleted.
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       204: emptyResponse
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminRevokeSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	sID, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	s, err := h.r.SessionPersister().GetSession(r.Context(), sID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := h.r.SessionPersister().RevokeSession(r.Context(), s.IdentityID, s.ID); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.EventStream().Emit(r.Context(), event.New(event.TypeSessionRevoked, s.IdentityID).WithSessionID(s.ID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // swagger:model revokedSessions
 type revokeSessions struct {
 	// The number of sessions that were revoked.
@@ -343,6 +632,8 @@ func (h *Handler) revokeSessions(w http.ResponseWriter, r *http.Request, _ httpr
 		return
 	}
 
+	h.r.EventStream().Emit(r.Context(), event.New(event.TypeSessionRevoked, s.IdentityID).WithData("except_session_id", s.ID))
+
 	h.r.Writer().WriteCode(w, r, http.StatusOK, &revokeSessions{Count: n})
 }
 
@@ -402,6 +693,8 @@ func (h *Handler) revokeSession(w http.ResponseWriter, r *http.Request, ps httpr
 		return
 	}
 
+	h.r.EventStream().Emit(r.Context(), event.New(event.TypeSessionRevoked, s.Identity.ID).WithSessionID(sessionID))
+
 	h.r.Writer().WriteCode(w, r, http.StatusNoContent, nil)
 }
 
@@ -26,6 +26,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
@@ -159,6 +160,45 @@ func TestSessionWhoAmI(t *testing.T) {
 		}
 	})
 
+	t.Run("case=tokenize", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySessionWhoAmIAAL, "aal1")
+		conf.MustSet(config.ViperKeySessionTokenizerTemplates+".jwt", map[string]interface{}{
+			"jwks_url": generateJWKS(t, "whoami-key", "ES256"),
+		})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerTemplates, nil)
+		})
+
+		client := testhelpers.NewClientWithCookies(t)
+		reg.CSRFHandler().IgnorePath("/set")
+		testhelpers.MockHydrateCookieClient(t, client, ts.URL+"/set")
+
+		res, err := client.Get(ts.URL + RouteJWKS)
+		require.NoError(t, err)
+		require.EqualValues(t, http.StatusOK, res.StatusCode)
+		var keys jose.JSONWebKeySet
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&keys))
+		require.Len(t, keys.Keys, 1)
+
+		res, err = client.Get(ts.URL + RouteWhoami)
+		require.NoError(t, err)
+		body := x.MustReadAll(res.Body)
+		assert.EqualValues(t, http.StatusOK, res.StatusCode)
+		assert.False(t, gjson.GetBytes(body, "tokenized").Exists(), "%s", body)
+
+		res, err = client.Get(ts.URL + RouteWhoami + "?tokenize_as=jwt")
+		require.NoError(t, err)
+		body = x.MustReadAll(res.Body)
+		require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+		claims := parseTokenizedSession(t, &keys, gjson.GetBytes(body, "tokenized").String())
+		assert.Equal(t, gjson.GetBytes(body, "identity.id").String(), claims["sub"])
+		assert.Equal(t, gjson.GetBytes(body, "id").String(), claims["sid"])
+
+		res, err = client.Get(ts.URL + RouteWhoami + "?tokenize_as=unknown")
+		require.NoError(t, err)
+		assert.EqualValues(t, http.StatusBadRequest, res.StatusCode)
+	})
+
 	/*
 		t.Run("case=respects AAL config", func(t *testing.T) {
 			conf.MustSet(config.ViperKeySessionLifespan, "1m")
@@ -504,6 +544,112 @@ func TestHandlerAdminSessionManagement(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("case=should get, list and revoke sessions of all identities", func(t *testing.T) {
+		client := testhelpers.NewClientWithCookies(t)
+		i1, i2 := identity.NewIdentity(""), identity.NewIdentity("")
+		require.NoError(t, reg.IdentityManager().Create(ctx, i1))
+		require.NoError(t, reg.IdentityManager().Create(ctx, i2))
+
+		// Other test cases create sessions as well, which is why all sessions are listed within this time window.
+		base := time.Date(1999, 12, 31, 23, 0, 0, 0, time.UTC)
+		sess := []*Session{
+			{Identity: i1, Active: true, AuthenticatedAt: base, AuthenticatorAssuranceLevel: identity.AuthenticatorAssuranceLevel1,
+				AMR: AuthenticationMethods{{Method: identity.CredentialsTypePassword, AAL: identity.AuthenticatorAssuranceLevel1}}},
+			{Identity: i2, Active: true, AuthenticatedAt: base.Add(time.Minute), AuthenticatorAssuranceLevel: identity.AuthenticatorAssuranceLevel2,
+				AMR: AuthenticationMethods{{Method: identity.CredentialsTypePassword, AAL: identity.AuthenticatorAssuranceLevel1}, {Method: identity.CredentialsTypeTOTP, AAL: identity.AuthenticatorAssuranceLevel2}}},
+			{Identity: i2, Active: false, AuthenticatedAt: base.Add(2 * time.Minute), AuthenticatorAssuranceLevel: identity.AuthenticatorAssuranceLevel1,
+				AMR: AuthenticationMethods{{Method: identity.CredentialsTypeOIDC, AAL: identity.AuthenticatorAssuranceLevel1}}},
+		}
+		for _, s := range sess {
+			s.ExpiresAt = time.Now().Add(time.Hour)
+			s.Token, s.LogoutToken = x.NewUUID().String(), x.NewUUID().String()
+			require.NoError(t, reg.SessionPersister().UpsertSession(ctx, s))
+		}
+
+		window := "authenticated_after=" + base.Format(time.RFC3339) + "&authenticated_before=" + base.Add(time.Hour).Format(time.RFC3339)
+
+		t.Run("should list sessions", func(t *testing.T) {
+			for _, tc := range []struct {
+				query    string
+				expected []*Session
+			}{
+				{query: window, expected: []*Session{sess[2], sess[1], sess[0]}},
+				{query: window + "&active=true", expected: []*Session{sess[1], sess[0]}},
+				{query: window + "&active=false", expected: []*Session{sess[2]}},
+				{query: window + "&aal=aal2", expected: []*Session{sess[1]}},
+				{query: window + "&authentication_method=password", expected: []*Session{sess[1], sess[0]}},
+				{query: window + "&authentication_method=totp&aal=aal2", expected: []*Session{sess[1]}},
+				{query: window + "&authentication_method=webauthn", expected: []*Session{}},
+				{query: "authenticated_after=" + base.Format(time.RFC3339) + "&authenticated_before=" + base.Add(time.Minute).Format(time.RFC3339), expected: []*Session{sess[0]}},
+			} {
+				t.Run("query="+tc.query, func(t *testing.T) {
+					res, err := client.Get(ts.URL + "/admin/sessions?" + tc.query)
+					require.NoError(t, err)
+					defer res.Body.Close()
+					require.Equal(t, http.StatusOK, res.StatusCode)
+					assert.Equal(t, fmt.Sprintf("%d", len(tc.expected)), res.Header.Get("X-Total-Count"))
+
+					var sessions []Session
+					require.NoError(t, json.NewDecoder(res.Body).Decode(&sessions))
+					require.Len(t, sessions, len(tc.expected))
+					for k, s := range tc.expected {
+						assert.Equal(t, s.ID, sessions[k].ID)
+						assert.Equal(t, s.IdentityID, sessions[k].Identity.ID)
+					}
+				})
+			}
+		})
+
+		t.Run("should reject invalid filters", func(t *testing.T) {
+			for _, query := range []string{"active=maybe", "aal=aal9", "authenticated_after=yesterday"} {
+				res, err := client.Get(ts.URL + "/admin/sessions?" + query)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+				assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+			}
+		})
+
+		t.Run("should get session with identity", func(t *testing.T) {
+			res, err := client.Get(ts.URL + "/admin/sessions/" + sess[1].ID.String())
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			body := x.MustReadAll(res.Body)
+			assert.Equal(t, sess[1].ID.String(), gjson.GetBytes(body, "id").String(), "%s", body)
+			assert.Equal(t, i2.ID.String(), gjson.GetBytes(body, "identity.id").String(), "%s", body)
+			assert.Equal(t, "totp", gjson.GetBytes(body, "authentication_methods.1.method").String(), "%s", body)
+		})
+
+		t.Run("should revoke session", func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", ts.URL+"/admin/sessions/"+sess[0].ID.String(), nil)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+			actual, err := reg.SessionPersister().GetSession(ctx, sess[0].ID)
+			require.NoError(t, err)
+			assert.False(t, actual.Active)
+
+			other, err := reg.SessionPersister().GetSession(ctx, sess[1].ID)
+			require.NoError(t, err)
+			assert.True(t, other.Active)
+		})
+
+		for _, method := range []string{"GET", "DELETE"} {
+			t.Run("http method="+method, func(t *testing.T) {
+				for id, code := range map[string]int{"BADUUID": http.StatusBadRequest, x.NewUUID().String(): http.StatusNotFound} {
+					req, _ := http.NewRequest(method, ts.URL+"/admin/sessions/"+id, nil)
+					res, err := client.Do(req)
+					require.NoError(t, err)
+					require.NoError(t, res.Body.Close())
+					assert.Equal(t, code, res.StatusCode, id)
+				}
+			})
+		}
+	})
 }
 
 func TestHandlerSelfServiceSessionManagement(t *testing.T) {
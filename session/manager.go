@@ -86,6 +86,11 @@ type Manager interface {
 	// Also regenerates CSRF tokens due to assumed principal change.
 	UpsertAndIssueCookie(context.Context, http.ResponseWriter, *http.Request, *Session) error
 
+	// UpsertAndTrackDevice stores a session in the database and records the device (IP address, user agent,
+	// and geo location) the request was made from. If the session was already used from that device, only
+	// the time it was last seen is updated.
+	UpsertAndTrackDevice(context.Context, *http.Request, *Session) error
+
 	// IssueCookie issues a cookie for the given session.
 	//
 	// Also regenerates CSRF tokens due to assumed principal change.
@@ -97,6 +102,9 @@ type Manager interface {
 	// PurgeFromRequest removes an HTTP session.
 	PurgeFromRequest(context.Context, http.ResponseWriter, *http.Request) error
 
+	// RevokeSessionByToken revokes the session with the given token.
+	RevokeSessionByToken(ctx context.Context, token string) error
+
 	// DoesSessionSatisfy answers if a session is satisfying the AAL.
 	DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) error
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/ory/x/urlx"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/event"
 
 	"github.com/ory/x/sqlcon"
 
@@ -29,7 +31,10 @@ type (
 		identity.ManagementProvider
 		x.CookieProvider
 		x.CSRFProvider
+		x.LoggingProvider
 		PersistenceProvider
+		GeoLocationResolverProvider
+		event.StreamProvider
 	}
 	ManagerHTTP struct {
 		cookieName func(ctx context.Context) string
@@ -47,7 +52,7 @@ func NewManagerHTTP(r managerHTTPDependencies) *ManagerHTTP {
 }
 
 func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, ss *Session) error {
-	if err := s.r.SessionPersister().UpsertSession(ctx, ss); err != nil {
+	if err := s.UpsertAndTrackDevice(ctx, r, ss); err != nil {
 		return err
 	}
 
@@ -58,6 +63,56 @@ func (s *ManagerHTTP) UpsertAndIssueCookie(ctx context.Context, w http.ResponseW
 	return nil
 }
 
+func (s *ManagerHTTP) UpsertAndTrackDevice(ctx context.Context, r *http.Request, ss *Session) error {
+	if err := s.r.SessionPersister().UpsertSession(ctx, ss); err != nil {
+		return err
+	}
+
+	ip, ua := deviceIPAddress(x.ClientIP(r, s.r.Config(ctx).PublicTrustedProxies())), truncateDeviceField(r.UserAgent())
+
+	device := Device{SessionID: ss.ID, IPAddress: ip, UserAgent: ua}
+	devices := make([]Device, 0, len(ss.Devices)+1)
+	for _, d := range ss.Devices {
+		if d.IPAddress == ip && d.UserAgent == ua {
+			device = d
+			continue
+		}
+		devices = append(devices, d)
+	}
+
+	if device.ID == uuid.Nil && len(devices) >= maxSessionDevices {
+		// Reuse the least recently seen device's row instead of growing the list without bound.
+		oldest := 0
+		for k, d := range devices {
+			if d.LastSeenAt.Before(devices[oldest].LastSeenAt) {
+				oldest = k
+			}
+		}
+		device.ID, device.FirstSeenAt = devices[oldest].ID, time.Now().UTC()
+		devices = append(devices[:oldest], devices[oldest+1:]...)
+	}
+
+	location, err := s.r.SessionGeoLocationResolver().ResolveGeoLocation(ctx, r, ip)
+	if err != nil {
+		// The location is informational only, which is why it must not prevent the session from being issued.
+		s.r.Logger().WithRequest(r).WithError(err).Warn("Unable to resolve the geo location of the session device.")
+	} else {
+		device.Location = truncateDeviceField(location)
+	}
+
+	if err := s.r.SessionPersister().UpsertDevice(ctx, &device); err != nil {
+		return err
+	}
+
+	ss.Devices = append([]Device{device}, devices...)
+
+	s.r.EventStream().Emit(ctx, event.New(event.TypeSessionIssued, ss.IdentityID).
+		WithSessionID(ss.ID).
+		WithData("authenticator_assurance_level", ss.AuthenticatorAssuranceLevel).
+		WithData("authentication_methods", ss.AMR))
+	return nil
+}
+
 func (s *ManagerHTTP) IssueCookie(ctx context.Context, w http.ResponseWriter, r *http.Request, session *Session) error {
 	cookie, err := s.r.CookieManager(r.Context()).Get(r, s.cookieName(ctx))
 	// Fix for https://github.com/ory/kratos/issues/1695
@@ -154,7 +209,7 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (*S
 
 func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	if token, ok := bearerTokenFromRequest(r); ok {
-		return errors.WithStack(s.r.SessionPersister().RevokeSessionByToken(ctx, token))
+		return s.RevokeSessionByToken(ctx, token)
 	}
 
 	cookie, _ := s.r.CookieManager(r.Context()).Get(r, s.cookieName(ctx))
@@ -163,8 +218,8 @@ func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWrite
 		return nil
 	}
 
-	if err := s.r.SessionPersister().RevokeSessionByToken(ctx, token); err != nil {
-		return errors.WithStack(err)
+	if err := s.RevokeSessionByToken(ctx, token); err != nil {
+		return err
 	}
 
 	cookie.Options.MaxAge = -1
@@ -174,6 +229,20 @@ func (s *ManagerHTTP) PurgeFromRequest(ctx context.Context, w http.ResponseWrite
 	return nil
 }
 
+func (s *ManagerHTTP) RevokeSessionByToken(ctx context.Context, token string) error {
+	sess, err := s.r.SessionPersister().GetSessionByToken(ctx, token)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.r.SessionPersister().RevokeSessionByToken(ctx, token); err != nil {
+		return errors.WithStack(err)
+	}
+
+	s.r.EventStream().Emit(ctx, event.New(event.TypeSessionRevoked, sess.IdentityID).WithSessionID(sess.ID))
+	return nil
+}
+
 func (s *ManagerHTTP) DoesSessionSatisfy(r *http.Request, sess *Session, requestedAAL string) error {
 	sess.SetAuthenticatorAssuranceLevel()
 	switch requestedAAL {
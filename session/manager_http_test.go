@@ -60,6 +60,12 @@ func (f *mockCSRFHandler) RegenerateToken(w http.ResponseWriter, r *http.Request
 	return x.FakeCSRFToken
 }
 
+type failingGeoLocationResolver struct{}
+
+func (failingGeoLocationResolver) ResolveGeoLocation(context.Context, *http.Request, string) (string, error) {
+	return "", errors.New("geo location database is unavailable")
+}
+
 func createAAL2Identity(t *testing.T, reg driver.Registry) *identity.Identity {
 	idAAL2 := identity.Identity{Traits: []byte("{}"), State: identity.StateActive, Credentials: map[identity.CredentialsType]identity.Credentials{
 		identity.CredentialsTypePassword: {Type: identity.CredentialsTypePassword, Config: []byte(`{"hashed_password": "$argon2id$v=19$m=32,t=2,p=4$cm94YnRVOW5jZzFzcVE4bQ$MNzk5BtR2vUhrp6qQEjRNw"}`), Identifiers: []string{testhelpers.RandomEmail()}},
@@ -167,6 +173,89 @@ func TestManagerHTTP(t *testing.T) {
 		assert.Len(t, actual.AMR, 2)
 	})
 
+	t.Run("suite=UpsertAndTrackDevice", func(t *testing.T) {
+		ctx := context.Background()
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+		testhelpers.SetDefaultIdentitySchema(conf, "file://./stub/identity.schema.json")
+		conf.MustSet(config.ViperKeySessionDevicesGeoLocationHeaders, []string{"Cf-Ipcity", "Cf-Ipcountry"})
+
+		i := &identity.Identity{Traits: []byte("{}"), State: identity.StateActive}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		sess := session.NewInactiveSession()
+		require.NoError(t, sess.Activate(i, conf, time.Now()))
+
+		newRequest := func(ua string) *http.Request {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("User-Agent", ua)
+			r.Header.Set("Cf-Ipcity", "Munich")
+			r.Header.Set("Cf-Ipcountry", "DE")
+			return r
+		}
+
+		require.NoError(t, reg.SessionManager().UpsertAndTrackDevice(ctx, newRequest("phone"), sess))
+		require.Len(t, sess.Devices, 1)
+		first := sess.Devices[0]
+		assert.Equal(t, "192.0.2.1", first.IPAddress)
+		assert.Equal(t, "phone", first.UserAgent)
+		assert.Equal(t, "Munich, DE", first.Location)
+
+		t.Run("case=updates the known device", func(t *testing.T) {
+			actual, err := reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionManager().UpsertAndTrackDevice(ctx, newRequest("phone"), actual))
+
+			actual, err = reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 1)
+			assert.Equal(t, first.ID, actual.Devices[0].ID)
+			assert.Equal(t, first.FirstSeenAt.Unix(), actual.Devices[0].FirstSeenAt.Unix())
+			assert.True(t, actual.Devices[0].LastSeenAt.After(first.LastSeenAt))
+		})
+
+		t.Run("case=records a new device", func(t *testing.T) {
+			reg.WithGeoLocationResolver(failingGeoLocationResolver{})
+			t.Cleanup(func() {
+				reg.WithGeoLocationResolver(session.NewHeaderGeoLocationResolver(reg))
+			})
+
+			actual, err := reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.NoError(t, reg.SessionManager().UpsertAndTrackDevice(ctx, newRequest("laptop"), actual))
+
+			actual, err = reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 2)
+			for _, d := range actual.Devices {
+				if d.ID == first.ID {
+					continue
+				}
+				assert.Equal(t, "laptop", d.UserAgent)
+				assert.Empty(t, d.Location)
+			}
+		})
+
+		t.Run("case=replaces the least recently seen device once the limit is reached", func(t *testing.T) {
+			actual, err := reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 2)
+
+			for k := 0; k < 31; k++ {
+				require.NoError(t, reg.SessionManager().UpsertAndTrackDevice(ctx, newRequest(fmt.Sprintf("device-%d", k)), actual))
+			}
+
+			actual, err = reg.SessionPersister().GetSession(ctx, sess.ID)
+			require.NoError(t, err)
+			require.Len(t, actual.Devices, 32)
+			for _, d := range actual.Devices {
+				assert.NotEqual(t, "phone", d.UserAgent)
+				if d.ID == first.ID {
+					assert.Equal(t, "device-30", d.UserAgent)
+					assert.True(t, d.FirstSeenAt.After(first.FirstSeenAt))
+				}
+			}
+		})
+	})
+
 	t.Run("suite=lifecycle", func(t *testing.T) {
 		conf, reg := internal.NewFastRegistryWithMocks(t)
 		conf.MustSet(config.ViperKeySelfServiceLoginUI, "https://www.ory.sh")
@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/gofrs/uuid"
@@ -18,6 +19,30 @@ type PersistenceProvider interface {
 	SessionPersister() Persister
 }
 
+// ListSessionsParameters filters and paginates the sessions returned by Persister.ListSessions.
+//
+// Zero values are ignored.
+type ListSessionsParameters struct {
+	// Active matches sessions in this active state. Expired sessions are never active.
+	Active *bool
+
+	// AAL matches sessions with this authenticator assurance level.
+	AAL identity.AuthenticatorAssuranceLevel
+
+	// AuthenticationMethod matches sessions which were authenticated using this method.
+	AuthenticationMethod identity.CredentialsType
+
+	// AuthenticatedAfter matches sessions authenticated at or after this time.
+	AuthenticatedAfter time.Time
+
+	// AuthenticatedBefore matches sessions authenticated before this time.
+	AuthenticatedBefore time.Time
+
+	// Page and PerPage control the page-based pagination.
+	Page    int
+	PerPage int
+}
+
 type Persister interface {
 	// GetSession retrieves a session from the store.
 	GetSession(ctx context.Context, sid uuid.UUID) (*Session, error)
@@ -25,9 +50,18 @@ type Persister interface {
 	// ListSessionsByIdentity retrieves sessions for an identity from the store.
 	ListSessionsByIdentity(ctx context.Context, iID uuid.UUID, active *bool, page, perPage int, except uuid.UUID) ([]*Session, error)
 
+	// ListSessions retrieves the sessions of all identities matching the given parameters from the store.
+	ListSessions(ctx context.Context, params ListSessionsParameters) ([]*Session, error)
+
+	// CountSessions counts the sessions matching the given parameters. Pagination parameters are ignored.
+	CountSessions(ctx context.Context, params ListSessionsParameters) (int64, error)
+
 	// UpsertSession inserts or updates a session into / in the store.
 	UpsertSession(ctx context.Context, s *Session) error
 
+	// UpsertDevice inserts or updates a device of a session into / in the store.
+	UpsertDevice(ctx context.Context, d *Device) error
+
 	// DeleteSession removes a session from the store.
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 
@@ -54,6 +88,9 @@ type Persister interface {
 
 	// RevokeSessionsIdentityExcept marks all except the given session of an identity inactive. It returns the number of sessions that were revoked.
 	RevokeSessionsIdentityExcept(ctx context.Context, iID, sID uuid.UUID) (int, error)
+
+	// DeleteExpiredSessions deletes at most limit sessions which expired, or were revoked, before the given time.
+	DeleteExpiredSessions(ctx context.Context, expiresAt time.Time, limit int) (int, error)
 }
 
 func TestPersister(ctx context.Context, conf *config.Config, p interface {
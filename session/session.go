@@ -80,6 +80,17 @@ type Session struct {
 	// required: true
 	Identity *identity.Identity `json:"identity" faker:"identity" db:"-" belongs_to:"identities" fk_id:"IdentityID"`
 
+	// Devices
+	//
+	// The devices this session was issued or refreshed on, most recently seen first.
+	Devices []Device `json:"devices" faker:"-" db:"-"`
+
+	// Tokenized
+	//
+	// The session as a signed JSON Web Token. Only set if the `tokenize_as` query parameter was set
+	// when calling the `/sessions/whoami` endpoint.
+	Tokenized string `json:"tokenized,omitempty" faker:"-" db:"-"`
+
 	// IdentityID is a helper struct field for gobuffalo.pop.
 	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
 
@@ -184,12 +195,6 @@ func (s *Session) Activate(i *identity.Identity, c lifespanProvider, authenticat
 	return nil
 }
 
-// swagger:model sessionDevice
-type Device struct {
-	// UserAgent of this device
-	UserAgent string `json:"user_agent"`
-}
-
 func (s *Session) Declassify() *Session {
 	s.Identity = s.Identity.CopyWithoutCredentials()
 	return s
@@ -353,6 +353,124 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			}
 		})
 
+		t.Run("case=list sessions", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			authenticatedAt := time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)
+			// The first session is active, the second one was revoked and the third one expired.
+			expected := make([]session.Session, 3)
+			for k := range expected {
+				require.NoError(t, faker.FakeData(&expected[k]))
+				expected[k].Identity, expected[k].IdentityID = &i, i.ID
+				expected[k].Active = k != 1
+				expected[k].ExpiresAt = time.Now().UTC().Add(time.Hour)
+				if k == 2 {
+					expected[k].ExpiresAt = time.Now().UTC().Add(-time.Hour)
+				}
+				expected[k].AuthenticatedAt = authenticatedAt.Add(time.Duration(k) * time.Second)
+				expected[k].AMR = session.AuthenticationMethods{{Method: identity.CredentialsTypePassword}}
+				require.NoError(t, p.UpsertSession(ctx, &expected[k]))
+			}
+			expected[1].AMR = append(expected[1].AMR, session.AuthenticationMethod{Method: identity.CredentialsTypeTOTP})
+			require.NoError(t, p.UpsertSession(ctx, &expected[1]))
+
+			params := session.ListSessionsParameters{AuthenticatedAfter: authenticatedAt, AuthenticatedBefore: authenticatedAt.Add(time.Hour)}
+			actual, err := p.ListSessions(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, actual, 3)
+			assert.Equal(t, expected[2].ID, actual[0].ID)
+			assert.Equal(t, i.ID, actual[0].Identity.ID)
+
+			count, err := p.CountSessions(ctx, params)
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, count)
+
+			params.Active = pointerx.Bool(true)
+			actual, err = p.ListSessions(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, expected[0].ID, actual[0].ID)
+
+			count, err = p.CountSessions(ctx, params)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, count)
+
+			params.Active = pointerx.Bool(false)
+			actual, err = p.ListSessions(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.Equal(t, expected[2].ID, actual[0].ID)
+			assert.Equal(t, expected[1].ID, actual[1].ID)
+
+			params.Active, params.AuthenticationMethod = nil, identity.CredentialsTypeTOTP
+			actual, err = p.ListSessions(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, expected[1].ID, actual[0].ID)
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				actual, err := other.ListSessions(ctx, params)
+				require.NoError(t, err)
+				assert.Len(t, actual, 0)
+
+				count, err := other.CountSessions(ctx, params)
+				require.NoError(t, err)
+				assert.EqualValues(t, 0, count)
+			})
+		})
+
+		t.Run("case=upsert device", func(t *testing.T) {
+			var expected session.Session
+			require.NoError(t, faker.FakeData(&expected))
+			require.NoError(t, p.CreateIdentity(ctx, expected.Identity))
+			require.NoError(t, p.UpsertSession(ctx, &expected))
+
+			device := session.Device{SessionID: expected.ID, IPAddress: "192.0.2.1", UserAgent: "phone", Location: "Munich, DE"}
+			require.NoError(t, p.UpsertDevice(ctx, &device))
+			require.NotEqual(t, uuid.Nil, device.ID)
+
+			device.Location = "Berlin, DE"
+			require.NoError(t, p.UpsertDevice(ctx, &device))
+
+			for name, get := range map[string]func() (*session.Session, error){
+				"by id":    func() (*session.Session, error) { return p.GetSession(ctx, expected.ID) },
+				"by token": func() (*session.Session, error) { return p.GetSessionByToken(ctx, expected.Token) },
+			} {
+				t.Run("method=get "+name, func(t *testing.T) {
+					actual, err := get()
+					require.NoError(t, err)
+					require.Len(t, actual.Devices, 1)
+					assert.Equal(t, device.ID, actual.Devices[0].ID)
+					assert.Equal(t, "192.0.2.1", actual.Devices[0].IPAddress)
+					assert.Equal(t, "phone", actual.Devices[0].UserAgent)
+					assert.Equal(t, "Berlin, DE", actual.Devices[0].Location)
+					assert.False(t, actual.Devices[0].FirstSeenAt.IsZero())
+					assert.False(t, actual.Devices[0].LastSeenAt.Before(actual.Devices[0].FirstSeenAt))
+				})
+			}
+
+			t.Run("method=list by identity", func(t *testing.T) {
+				actual, err := p.ListSessionsByIdentity(ctx, expected.IdentityID, nil, 1, 10, uuid.Nil)
+				require.NoError(t, err)
+				require.Len(t, actual, 1)
+				require.Len(t, actual[0].Devices, 1)
+				assert.Equal(t, device.ID, actual[0].Devices[0].ID)
+			})
+
+			t.Run("on another network", func(t *testing.T) {
+				_, other := testhelpers.NewNetwork(t, ctx, p)
+				require.NoError(t, other.UpsertDevice(ctx, &session.Device{SessionID: expected.ID, UserAgent: "other"}))
+
+				actual, err := p.GetSession(ctx, expected.ID)
+				require.NoError(t, err)
+				require.Len(t, actual.Devices, 1)
+				assert.Equal(t, device.ID, actual.Devices[0].ID)
+			})
+		})
+
 		t.Run("case=delete session for", func(t *testing.T) {
 			var expected1 session.Session
 			var expected2 session.Session
@@ -0,0 +1,258 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-jsonnet"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/driver/clock"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	tokenizerDependencies interface {
+		config.Provider
+		clock.Provider
+		x.HTTPClientProvider
+		x.LoggingProvider
+	}
+	TokenizerProvider interface {
+		SessionTokenizer() *Tokenizer
+	}
+
+	// Tokenizer converts sessions into signed JSON Web Tokens which can be verified
+	// without calling Ory Kratos, using the keys published at the JWKS endpoint.
+	Tokenizer struct {
+		r     tokenizerDependencies
+		cache *lru.Cache
+	}
+
+	// cachedResource is a fetched and parsed JSON Web Key Set or claims mapper.
+	cachedResource struct {
+		value     interface{}
+		expiresAt time.Time
+	}
+)
+
+// tokenizerCacheTTL is how long fetched JSON Web Key Sets and claims mappers are reused before they are
+// fetched again.
+const tokenizerCacheTTL = 5 * time.Minute
+
+// reservedClaims can not be set by the claims mapper.
+var reservedClaims = map[string]bool{
+	"iss": true,
+	"sub": true,
+	"exp": true,
+	"nbf": true,
+	"iat": true,
+	"jti": true,
+	"sid": true,
+}
+
+func NewTokenizer(r tokenizerDependencies) *Tokenizer {
+	// lru.New only fails for a non-positive size.
+	cache, _ := lru.New(64)
+	return &Tokenizer{r: r, cache: cache}
+}
+
+// cached returns the value cached under the key or loads and caches it if it is missing or expired.
+func (t *Tokenizer) cached(key string, load func() (interface{}, error)) (interface{}, error) {
+	now := t.r.Clock().Now()
+	if v, ok := t.cache.Get(key); ok {
+		if c := v.(cachedResource); now.Before(c.expiresAt) {
+			return c.value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	t.cache.Add(key, cachedResource{value: value, expiresAt: now.Add(tokenizerCacheTTL)})
+	return value, nil
+}
+
+func (t *Tokenizer) fetch(ctx context.Context, url string) (*bytes.Buffer, error) {
+	return fetcher.NewFetcher(fetcher.WithClient(t.r.HTTPClient(ctx))).Fetch(url)
+}
+
+// fetchKeySet returns the parsed JSON Web Key Set, which must not be modified as it is shared between calls.
+func (t *Tokenizer) fetchKeySet(ctx context.Context, url string) (*jose.JSONWebKeySet, error) {
+	set, err := t.cached("jwks:"+url, func() (interface{}, error) {
+		raw, err := t.fetch(ctx, url)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to fetch the JSON Web Key Set for the session tokenizer."))
+		}
+
+		var set jose.JSONWebKeySet
+		if err := json.NewDecoder(raw).Decode(&set); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to decode the JSON Web Key Set for the session tokenizer."))
+		}
+
+		return &set, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set.(*jose.JSONWebKeySet), nil
+}
+
+func (t *Tokenizer) fetchClaimsMapper(ctx context.Context, url string) (string, error) {
+	jn, err := t.cached("claims_mapper:"+url, func() (interface{}, error) {
+		jn, err := t.fetch(ctx, url)
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to fetch the claims mapper for the session tokenizer."))
+		}
+		return jn.String(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return jn.(string), nil
+}
+
+func signingKey(set *jose.JSONWebKeySet) (*jose.JSONWebKey, error) {
+	for k := range set.Keys {
+		key := &set.Keys[k]
+		if !key.IsPublic() && (key.Use == "" || key.Use == "sig") && len(key.Algorithm) > 0 {
+			return key, nil
+		}
+	}
+	return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The JSON Web Key Set for the session tokenizer does not contain a private signing key with an algorithm."))
+}
+
+// TokenizeSession renders the session's claims using the template and stores the signed JSON Web Token in
+// the session's Tokenized field. The session's identity should not contain credentials.
+func (t *Tokenizer) TokenizeSession(ctx context.Context, template string, s *Session) error {
+	tpl, err := t.r.Config(ctx).TokenizeTemplate(template)
+	if err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithWrap(err).WithReasonf("Unable to tokenize the session because the requested template does not exist."))
+	}
+
+	set, err := t.fetchKeySet(ctx, tpl.JWKSURL)
+	if err != nil {
+		return err
+	}
+
+	key, err := signingKey(set)
+	if err != nil {
+		return err
+	}
+
+	method := jwt.GetSigningMethod(key.Algorithm)
+	if method == nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The session tokenizer does not support signing algorithm \"%s\".", key.Algorithm))
+	}
+
+	now := t.r.Clock().Now().UTC()
+	claims := jwt.MapClaims{
+		"jti": x.NewUUID().String(),
+		"iss": t.r.Config(ctx).SelfPublicURL().String(),
+		"sub": s.IdentityID.String(),
+		"sid": s.ID.String(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(tpl.TTL).Unix(),
+	}
+
+	if len(tpl.ClaimsMapperURL) > 0 {
+		mapped, err := t.mapClaims(ctx, tpl.ClaimsMapperURL, s)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range mapped {
+			if !reservedClaims[k] {
+				claims[k] = v
+			}
+		}
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.KeyID
+
+	s.Tokenized, err = token.SignedString(key.Key)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to sign the session token."))
+	}
+
+	return nil
+}
+
+func (t *Tokenizer) mapClaims(ctx context.Context, url string, s *Session) (map[string]interface{}, error) {
+	jn, err := t.fetchClaimsMapper(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var session bytes.Buffer
+	if err := json.NewEncoder(&session).Encode(s); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.ExtCode("session", session.String())
+	evaluated, err := vm.EvaluateAnonymousSnippet(url, jn)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithWrap(err).WithReasonf("Unable to render the claims of the session token."))
+	}
+
+	var mapped map[string]interface{}
+	if claims := gjson.Get(evaluated, "claims"); !claims.IsObject() {
+		t.r.Logger().
+			WithField("claims_mapper_url", url).
+			WithSensitiveField("claims_mapper_output", evaluated).
+			Error("Session tokenizer Jsonnet mapper did not return an object for key claims. Please check your Jsonnet code!")
+		return mapped, nil
+	} else if err := json.Unmarshal([]byte(claims.Raw), &mapped); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return mapped, nil
+}
+
+// PublicKeys returns the public keys of all tokenizer templates' JSON Web Key Sets.
+func (t *Tokenizer) PublicKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	var keys []jose.JSONWebKey
+	seen := map[string]bool{}
+	for _, name := range t.r.Config(ctx).TokenizeTemplates() {
+		tpl, err := t.r.Config(ctx).TokenizeTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+
+		set, err := t.fetchKeySet(ctx, tpl.JWKSURL)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range set.Keys {
+			if key.Use != "" && key.Use != "sig" {
+				continue
+			}
+
+			pub := key.Public()
+			if !pub.Valid() || (len(pub.KeyID) > 0 && seen[pub.KeyID]) {
+				continue
+			}
+
+			seen[pub.KeyID] = true
+			keys = append(keys, pub)
+		}
+	}
+
+	return &jose.JSONWebKeySet{Keys: keys}, nil
+}
@@ -0,0 +1,164 @@
+package session_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/jwksx"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func base64URL(t *testing.T, v []byte) string {
+	return "base64://" + base64.StdEncoding.EncodeToString(v)
+}
+
+func generateJWKS(t *testing.T, kid, alg string) string {
+	keys, err := jwksx.GenerateSigningKeys(kid, alg, 0)
+	require.NoError(t, err)
+	raw, err := json.Marshal(keys)
+	require.NoError(t, err)
+	return base64URL(t, raw)
+}
+
+func parseTokenizedSession(t *testing.T, keys *jose.JSONWebKeySet, token string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		found := keys.Key(token.Header["kid"].(string))
+		require.Len(t, found, 1)
+		return found[0].Key, nil
+	})
+	require.NoError(t, err)
+	return claims
+}
+
+func TestTokenizer(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+
+	conf.MustSet(config.ViperKeySessionTokenizerTemplates+".es256", map[string]interface{}{
+		"ttl":      "1m",
+		"jwks_url": generateJWKS(t, "es256-key", "ES256"),
+	})
+	conf.MustSet(config.ViperKeySessionTokenizerTemplates+".mapped", map[string]interface{}{
+		"ttl":               "1h",
+		"jwks_url":          generateJWKS(t, "rs256-key", "RS256"),
+		"claims_mapper_url": base64URL(t, []byte(`local s = std.extVar('session'); { claims: { email: s.identity.traits.email, sub: "not-allowed" } }`)),
+	})
+
+	i := &identity.Identity{ID: x.NewUUID(), State: identity.StateActive, Traits: identity.Traits(`{"email":"tokenizer@ory.sh"}`)}
+	s, err := session.NewActiveSession(i, conf, time.Now().UTC(), identity.CredentialsTypePassword, identity.AuthenticatorAssuranceLevel1)
+	require.NoError(t, err)
+
+	keys, err := reg.SessionTokenizer().PublicKeys(ctx)
+	require.NoError(t, err)
+
+	t.Run("case=publishes the public keys of all templates", func(t *testing.T) {
+		require.Len(t, keys.Keys, 2)
+		assert.Equal(t, "es256-key", keys.Keys[0].KeyID)
+		assert.Equal(t, "rs256-key", keys.Keys[1].KeyID)
+		for _, k := range keys.Keys {
+			assert.True(t, k.IsPublic())
+		}
+	})
+
+	t.Run("case=tokenizes session", func(t *testing.T) {
+		require.NoError(t, reg.SessionTokenizer().TokenizeSession(ctx, "es256", s))
+		require.NotEmpty(t, s.Tokenized)
+
+		claims := parseTokenizedSession(t, keys, s.Tokenized)
+		assert.Equal(t, i.ID.String(), claims["sub"])
+		assert.Equal(t, s.ID.String(), claims["sid"])
+		assert.Equal(t, "https://www.ory.sh/", claims["iss"])
+		assert.NotEmpty(t, claims["jti"])
+		assert.InDelta(t, time.Now().Add(time.Minute).Unix(), claims["exp"], 5)
+		assert.NotContains(t, claims, "email")
+	})
+
+	t.Run("case=renders claims using the mapper", func(t *testing.T) {
+		require.NoError(t, reg.SessionTokenizer().TokenizeSession(ctx, "mapped", s))
+
+		claims := parseTokenizedSession(t, keys, s.Tokenized)
+		assert.Equal(t, "tokenizer@ory.sh", claims["email"])
+		assert.Equal(t, i.ID.String(), claims["sub"], "reserved claims can not be overwritten")
+		assert.InDelta(t, time.Now().Add(time.Hour).Unix(), claims["exp"], 5)
+	})
+
+	t.Run("case=fails for unknown template", func(t *testing.T) {
+		err := reg.SessionTokenizer().TokenizeSession(ctx, "unknown", s)
+		require.ErrorIs(t, err, herodot.ErrBadRequest)
+	})
+
+	t.Run("case=reuses fetched key sets and claims mappers until they expire", func(t *testing.T) {
+		jwks, err := jwksx.GenerateSigningKeys("remote-key", "ES256", 0)
+		require.NoError(t, err)
+
+		var jwksRequests, mapperRequests int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/jwks":
+				atomic.AddInt32(&jwksRequests, 1)
+				require.NoError(t, json.NewEncoder(w).Encode(jwks))
+			case "/mapper":
+				atomic.AddInt32(&mapperRequests, 1)
+				_, _ = w.Write([]byte(`{ claims: { remote: true } }`))
+			}
+		}))
+		t.Cleanup(ts.Close)
+
+		conf.MustSet(config.ViperKeySessionTokenizerTemplates+".remote", map[string]interface{}{
+			"ttl":               "1m",
+			"jwks_url":          ts.URL + "/jwks",
+			"claims_mapper_url": ts.URL + "/mapper",
+		})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySessionTokenizerTemplates+".remote", nil)
+		})
+
+		c := clock.NewMock()
+		c.Set(time.Now())
+		tokenizer := session.NewTokenizer(&mockClockRegistry{RegistryDefault: reg, clock: c})
+
+		for k := 0; k < 3; k++ {
+			require.NoError(t, tokenizer.TokenizeSession(ctx, "remote", s))
+		}
+		keys, err := tokenizer.PublicKeys(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, true, parseTokenizedSession(t, keys, s.Tokenized)["remote"])
+		assert.EqualValues(t, 1, atomic.LoadInt32(&jwksRequests))
+		assert.EqualValues(t, 1, atomic.LoadInt32(&mapperRequests))
+
+		c.Add(10 * time.Minute)
+		require.NoError(t, tokenizer.TokenizeSession(ctx, "remote", s))
+		assert.EqualValues(t, 2, atomic.LoadInt32(&jwksRequests))
+		assert.EqualValues(t, 2, atomic.LoadInt32(&mapperRequests))
+	})
+}
+
+type mockClockRegistry struct {
+	*driver.RegistryDefault
+	clock clock.Clock
+}
+
+func (r *mockClockRegistry) Clock() clock.Clock {
+	return r.clock
+}
@@ -64,6 +64,7 @@ const (
 	InfoSelfServiceRecovery           ID = 1060000 + iota // 1060000
 	InfoSelfServiceRecoverySuccessful                     // 1060001
 	InfoSelfServiceRecoveryEmailSent                      // 1060002
+	InfoSelfServiceRecoveryPhoneSent                      // 1060003
 )
 
 const (
@@ -106,6 +107,9 @@ const (
 	ErrorValidationLookupInvalid
 	ErrorValidationInvalidCode
 	ErrorValidationCodeSent
+	ErrorValidationCodeResendTooSoon
+	ErrorValidationCodeSendLimitExceeded
+	ErrorValidationCodeFlowSendLimitExceeded
 )
 
 const (
@@ -116,6 +120,7 @@ const (
 	ErrorValidationSettingsNoStrategyFound                         // 4010004
 	ErrorValidationRecoveryNoStrategyFound                         // 4010005
 	ErrorValidationVerificationNoStrategyFound                     // 4010006
+	ErrorValidationLoginLockedOut                                  // 4010007
 )
 
 const (
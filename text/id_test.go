@@ -22,6 +22,7 @@ func TestIDs(t *testing.T) {
 	assert.Equal(t, 1060000, int(InfoSelfServiceRecovery))
 	assert.Equal(t, 1060001, int(InfoSelfServiceRecoverySuccessful))
 	assert.Equal(t, 1060002, int(InfoSelfServiceRecoveryEmailSent))
+	assert.Equal(t, 1060003, int(InfoSelfServiceRecoveryPhoneSent))
 
 	assert.Equal(t, 1070000, int(InfoNodeLabel))
 	assert.Equal(t, 1080000, int(InfoSelfServiceVerification))
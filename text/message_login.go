@@ -117,6 +117,17 @@ func NewErrorValidationLoginFlowExpired(ago time.Duration) *Message {
 	}
 }
 
+func NewErrorValidationLoginLockedOut(wait time.Duration) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginLockedOut,
+		Text: fmt.Sprintf("Too many failed login attempts. Please try again in %.2f minutes.", wait.Minutes()),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"locked_until": Now().UTC().Add(wait),
+		}),
+	}
+}
+
 func NewErrorValidationLoginNoStrategyFound() *Message {
 	return &Message{
 		ID:   ErrorValidationLoginNoStrategyFound,
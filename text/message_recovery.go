@@ -37,6 +37,15 @@ func NewRecoveryEmailSent() *Message {
 	}
 }
 
+func NewRecoveryPhoneSent() *Message {
+	return &Message{
+		ID:      InfoSelfServiceRecoveryPhoneSent,
+		Type:    Info,
+		Text:    "A message containing a recovery code has been sent to the phone number you provided.",
+		Context: context(nil),
+	}
+}
+
 func NewErrorValidationRecoveryTokenInvalidOrAlreadyUsed() *Message {
 	return &Message{
 		ID:      ErrorValidationRecoveryTokenInvalidOrAlreadyUsed,
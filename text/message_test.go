@@ -2,9 +2,11 @@ package text
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 )
 
 func TestMessage(t *testing.T) {
@@ -30,3 +32,28 @@ func TestMessages(t *testing.T) {
 
 	assert.EqualValues(t, expected, actual, v)
 }
+
+func TestFormatWait(t *testing.T) {
+	for _, tc := range []struct {
+		wait     time.Duration
+		expected string
+	}{
+		{wait: 0, expected: "0 seconds"},
+		{wait: 400 * time.Millisecond, expected: "1 second"},
+		{wait: 20 * time.Second, expected: "20 seconds"},
+		{wait: 59*time.Second + time.Millisecond, expected: "60 seconds"},
+		{wait: time.Minute, expected: "1 minute"},
+		{wait: time.Minute + time.Second, expected: "2 minutes"},
+		{wait: 14 * time.Hour, expected: "840 minutes"},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatWait(tc.wait))
+		})
+	}
+}
+
+func TestNewErrorValidationCodeSendLimitExceeded(t *testing.T) {
+	m := NewErrorValidationCodeSendLimitExceeded(90*time.Second + 300*time.Millisecond)
+	assert.Equal(t, "Too many codes have been requested. Please try again in 2 minutes.", m.Text)
+	assert.Equal(t, 90.3, gjson.GetBytes(m.Context, "wait_seconds").Float())
+}
@@ -2,6 +2,8 @@ package text
 
 import (
 	"fmt"
+	"math"
+	"time"
 )
 
 func NewValidationErrorGeneric(reason string) *Message {
@@ -165,6 +167,57 @@ func NewErrorValidationInvalidCode() *Message {
 	}
 }
 
+func NewErrorValidationCodeResendTooSoon(wait time.Duration) *Message {
+	return &Message{
+		ID:   ErrorValidationCodeResendTooSoon,
+		Text: fmt.Sprintf("A code has just been sent. Please wait %s before requesting a new code.", formatWait(wait)),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"resend_at":    Now().UTC().Add(wait),
+			"wait_seconds": wait.Seconds(),
+		}),
+	}
+}
+
+func NewErrorValidationCodeSendLimitExceeded(wait time.Duration) *Message {
+	return &Message{
+		ID:   ErrorValidationCodeSendLimitExceeded,
+		Text: fmt.Sprintf("Too many codes have been requested. Please try again in %s.", formatWait(wait)),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"resend_at":    Now().UTC().Add(wait),
+			"wait_seconds": wait.Seconds(),
+		}),
+	}
+}
+
+// formatWait rounds the wait time up to whole minutes, or to whole seconds if it is shorter than a minute, so that
+// users are never asked to retry too early.
+func formatWait(wait time.Duration) string {
+	if wait < time.Minute {
+		seconds := int(math.Ceil(wait.Seconds()))
+		if seconds == 1 {
+			return "1 second"
+		}
+		return fmt.Sprintf("%d seconds", seconds)
+	}
+
+	minutes := int(math.Ceil(wait.Minutes()))
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+func NewErrorValidationCodeFlowSendLimitExceeded() *Message {
+	return &Message{
+		ID:      ErrorValidationCodeFlowSendLimitExceeded,
+		Text:    "Too many codes have been requested in this flow. Please start over to request a new code.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
 func NewErrorCodeSent() *Message {
 	return &Message{
 		ID:      ErrorValidationCodeSent,
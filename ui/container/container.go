@@ -163,6 +163,13 @@ func (c *Container) ParseError(group node.Group, err error) error {
 			c.AddMessage(group, &e.Messages[i], pointer)
 		}
 		return nil
+	} else if e := new(schema.ValidationListError); errors.As(err, &e) {
+		for _, ee := range e.Validations {
+			if err := c.ParseError(group, ee); err != nil {
+				return err
+			}
+		}
+		return nil
 	} else if e := new(jsonschema.ValidationError); errors.As(err, &e) {
 		switch ctx := e.Context.(type) {
 		case *jsonschema.ValidationErrorContextRequired:
@@ -221,6 +221,12 @@ func TestContainer(t *testing.T) {
 				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "foo.bar.baz", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}, Meta: new(node.Meta)},
 			}}},
 			{err: &jsonschema.ValidationError{Message: "test", InstancePtr: ""}, expect: Container{Nodes: node.Nodes{}, Messages: text.Messages{*text.NewValidationErrorGeneric("test")}}},
+			{err: &schema.ValidationListError{Validations: []*schema.ValidationError{
+				{ValidationError: &jsonschema.ValidationError{Message: "test", InstancePtr: "#/traits/code"}, Messages: text.Messages{*text.NewValidationErrorGeneric("invalid code")}},
+				{ValidationError: &jsonschema.ValidationError{Message: "test", InstancePtr: ""}, Messages: text.Messages{*text.NewValidationErrorGeneric("try again")}},
+			}}, expect: Container{Nodes: node.Nodes{
+				&node.Node{Group: node.DefaultGroup, Type: node.Input, Attributes: &node.InputAttributes{Name: "traits.code", Type: node.InputAttributeTypeText}, Messages: text.Messages{*text.NewValidationErrorGeneric("invalid code")}, Meta: new(node.Meta)},
+			}, Messages: text.Messages{*text.NewValidationErrorGeneric("try again")}}},
 		} {
 			t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
 				for _, in := range []error{tc.err, errors.WithStack(tc.err)} {
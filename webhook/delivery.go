@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/corp"
+)
+
+// A Web Hook Delivery's Status
+//
+// It is `queued` until the web hook was delivered (`delivered`) or all retries were exhausted (`abandoned`).
+//
+// swagger:model webHookDeliveryStatus
+type DeliveryStatus string
+
+const (
+	DeliveryStatusQueued    DeliveryStatus = "queued"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusAbandoned DeliveryStatus = "abandoned"
+)
+
+// IsValid returns true if the status is known.
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryStatusQueued, DeliveryStatusDelivered, DeliveryStatusAbandoned:
+		return true
+	default:
+		return false
+	}
+}
+
+// Delivery is an asynchronous web hook call which is stored in the outbox until it was delivered.
+//
+// swagger:model webHookDelivery
+type Delivery struct {
+	// The ID of this delivery
+	//
+	// required: true
+	ID uuid.UUID `json:"id" faker:"-" db:"id"`
+
+	// The status of this delivery
+	//
+	// required: true
+	Status DeliveryStatus `json:"status" faker:"-" db:"status"`
+
+	// The HTTP method of the web hook
+	//
+	// required: true
+	Method string `json:"method" db:"method"`
+
+	// The URL of the web hook
+	//
+	// required: true
+	URL string `json:"url" db:"url"`
+
+	// Config is the web hook's configuration at the time the delivery was queued, encrypted with the configured
+	// cipher. It is used to render and authenticate the request on every attempt and is not exposed because it
+	// contains credentials.
+	Config string `json:"-" faker:"-" db:"config"`
+
+	// Payload is the context the web hook's body template is rendered with, encrypted with the configured cipher.
+	Payload string `json:"-" faker:"-" db:"payload"`
+
+	// How often the delivery was attempted
+	//
+	// required: true
+	SendCount int `json:"send_count" faker:"-" db:"send_count"`
+
+	// The HTTP status code returned by the last attempt. It is zero if no response was received.
+	//
+	// required: true
+	LastResponseCode int `json:"last_response_code" faker:"-" db:"last_response_code"`
+
+	// The error of the last attempt, if any
+	LastError sqlxx.NullString `json:"last_error,omitempty" faker:"-" db:"last_error"`
+
+	// When the next attempt is due
+	//
+	// required: true
+	NextAttemptAt time.Time `json:"next_attempt_at" faker:"-" db:"next_attempt_at"`
+
+	NID uuid.UUID `json:"-" faker:"-" db:"nid"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	//
+	// required: true
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+func (d Delivery) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "webhook_deliveries")
+}
+
+func (d *Delivery) GetID() uuid.UUID {
+	return d.ID
+}
+
+func (d *Delivery) GetNID() uuid.UUID {
+	return d.NID
+}
+
+// NewDelivery prepares the delivery of the payload to the web hook configured by conf. Both are encrypted
+// because the configuration contains the web hook's credentials and the payload personal data.
+func NewDelivery(ctx context.Context, c cipher.Cipher, conf json.RawMessage, payload []byte) (*Delivery, error) {
+	encryptedConf, err := c.Encrypt(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	encryptedPayload, err := c.Encrypt(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delivery{
+		Method:  gjson.GetBytes(conf, "method").String(),
+		URL:     gjson.GetBytes(conf, "url").String(),
+		Config:  encryptedConf,
+		Payload: encryptedPayload,
+	}, nil
+}
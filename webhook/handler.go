@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	AdminRouteWebHooks      = "/webhooks"
+	AdminRouteDeliveries    = AdminRouteWebHooks + "/deliveries"
+	AdminRouteDelivery      = AdminRouteDeliveries + "/:id"
+	AdminRouteDeliveryRetry = AdminRouteDelivery + "/retry"
+)
+
+type (
+	handlerDependencies interface {
+		PersistenceProvider
+		x.WriterProvider
+		x.LoggingProvider
+		config.Provider
+	}
+	HandlerProvider interface {
+		WebHookHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(AdminRouteDeliveries, h.adminListDeliveries)
+	admin.GET(AdminRouteDelivery, h.adminGetDelivery)
+	admin.POST(AdminRouteDeliveryRetry, h.adminRetryDelivery)
+}
+
+// A list of web hook deliveries.
+// swagger:model webHookDeliveryList
+// nolint:deadcode,unused
+type webHookDeliveryList []Delivery
+
+// swagger:parameters adminListWebHookDeliveries
+// nolint:deadcode,unused
+type adminListWebHookDeliveries struct {
+	x.PaginationParams
+
+	// Status filters deliveries by their status, which is either `queued`, `delivered` or `abandoned`.
+	//
+	// required: false
+	// in: query
+	Status string `json:"status"`
+}
+
+// swagger:route GET /admin/webhooks/deliveries v0alpha2 adminListWebHookDeliveries
+//
+// List Web Hook Deliveries
+//
+// Lists the deliveries of asynchronous web hooks, newest first. Use it to find deliveries which were
+// abandoned after all retries were exhausted.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: webHookDeliveryList
+//       400: jsonError
+//       500: jsonError
+func (h *Handler) adminListDeliveries(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	status := DeliveryStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		h.r.Writer().WriteError(w, r, errors.WithStack(ErrDeliveryStatusIsUnknown))
+		return
+	}
+
+	page, itemsPerPage := x.ParsePagination(r)
+	l, total, err := h.r.WebHookPersister().ListDeliveries(r.Context(), status, page, itemsPerPage)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), AdminRouteDeliveries), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, l)
+}
+
+// swagger:parameters adminGetWebHookDelivery adminRetryWebHookDelivery
+// nolint:deadcode,unused
+type webHookDeliveryID struct {
+	// The ID of the web hook delivery
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /admin/webhooks/deliveries/{id} v0alpha2 adminGetWebHookDelivery
+//
+// Get a Web Hook Delivery
+//
+// Returns a single delivery of an asynchronous web hook including the outcome of its last attempt.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: webHookDelivery
+//       400: jsonError
+//       404: jsonError
+//       500: jsonError
+func (h *Handler) adminGetDelivery(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	d, err := h.r.WebHookPersister().FetchDelivery(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, d)
+}
+
+// swagger:route POST /admin/webhooks/deliveries/{id}/retry v0alpha2 adminRetryWebHookDelivery
+//
+// Retry a Web Hook Delivery
+//
+// Queues an abandoned delivery again. Its send count is reset so that all retries are available again.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       oryAccessToken:
+//
+//     Responses:
+//       200: webHookDelivery
+//       400: jsonError
+//       404: jsonError
+//       409: jsonError
+//       500: jsonError
+func (h *Handler) adminRetryDelivery(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := uuid.FromString(ps.ByName("id"))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithError(err.Error()).WithDebug("could not parse UUID")))
+		return
+	}
+
+	if err := h.r.WebHookPersister().RetryDelivery(r.Context(), id); err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	d, err := h.r.WebHookPersister().FetchDelivery(r.Context(), id)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Logger().
+		WithField("web_hook_delivery_id", d.ID).
+		Info("Web hook delivery was queued again.")
+
+	h.r.Writer().Write(w, r, d)
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+var (
+	ErrQueueEmpty              = errors.New("queue is empty")
+	ErrDeliveryNotRetryable    = herodot.ErrConflict.WithReason("Only web hook deliveries which were abandoned can be retried.")
+	ErrDeliveryStatusIsUnknown = herodot.ErrBadRequest.WithReason("Web hook delivery status is not valid.")
+)
+
+type (
+	Persister interface {
+		// AddDelivery queues a web hook delivery. If the context carries a transaction, the delivery is
+		// only queued once the transaction is committed. A failing insert does not abort the transaction.
+		AddDelivery(context.Context, *Delivery) error
+
+		// NextDeliveries claims up to limit queued deliveries which are due by postponing their next attempt
+		// by lease. Deliveries which are not updated within the lease, for example because Kratos was
+		// restarted, are picked up again once the lease ran out. Each delivery is only claimed by one of
+		// several concurrent callers.
+		NextDeliveries(ctx context.Context, limit uint8, lease time.Duration) ([]Delivery, error)
+
+		// UpdateDelivery stores the outcome of a delivery attempt.
+		UpdateDelivery(context.Context, *Delivery) error
+
+		// ListDeliveries returns a page of deliveries, newest first, and the total number of deliveries
+		// with the given status. An empty status matches all deliveries.
+		ListDeliveries(ctx context.Context, status DeliveryStatus, page, itemsPerPage int) ([]Delivery, int64, error)
+
+		FetchDelivery(context.Context, uuid.UUID) (*Delivery, error)
+
+		// RetryDelivery queues an abandoned delivery again and resets its send count.
+		RetryDelivery(context.Context, uuid.UUID) error
+
+		// DeleteExpiredDeliveries deletes up to limit deliveries which were delivered or abandoned before expiresAt.
+		DeleteExpiredDeliveries(ctx context.Context, expiresAt time.Time, limit int) (int, error)
+	}
+	PersistenceProvider interface {
+		WebHookPersister() Persister
+	}
+)
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/persistence"
+	"github.com/ory/kratos/webhook"
+	"github.com/ory/kratos/x"
+)
+
+func TestPersister(ctx context.Context, p persistence.Persister) func(t *testing.T) {
+	return func(t *testing.T) {
+		newDelivery := func(t *testing.T) *webhook.Delivery {
+			d := &webhook.Delivery{
+				Method:  "POST",
+				URL:     "https://example.org/" + x.NewUUID().String(),
+				Config:  "encrypted-config",
+				Payload: "encrypted-payload",
+			}
+			require.NoError(t, p.AddDelivery(ctx, d))
+			return d
+		}
+
+		t.Run("case=no deliveries are due", func(t *testing.T) {
+			_, err := p.NextDeliveries(ctx, 10, time.Minute)
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty)
+		})
+
+		t.Run("case=add and fetch delivery", func(t *testing.T) {
+			expected := newDelivery(t)
+			assert.Equal(t, webhook.DeliveryStatusQueued, expected.Status)
+
+			actual, err := p.FetchDelivery(ctx, expected.ID)
+			require.NoError(t, err)
+			assert.Equal(t, expected.URL, actual.URL)
+			assert.Equal(t, expected.Method, actual.Method)
+			assert.Equal(t, expected.Config, actual.Config)
+			assert.Equal(t, expected.Payload, actual.Payload)
+			assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+
+			_, err = p.FetchDelivery(ctx, x.NewUUID())
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+		})
+
+		t.Run("case=claims due deliveries and stores the outcome", func(t *testing.T) {
+			claimed, err := p.NextDeliveries(ctx, 10, time.Minute)
+			require.NoError(t, err)
+			require.Len(t, claimed, 1)
+
+			_, err = p.NextDeliveries(ctx, 10, time.Minute)
+			require.ErrorIs(t, err, webhook.ErrQueueEmpty, "claimed deliveries are not due until the lease ran out")
+
+			d := claimed[0]
+			d.Status = webhook.DeliveryStatusAbandoned
+			d.SendCount = 3
+			d.LastResponseCode = 503
+			d.LastError = "web hook failed with status code 503"
+			require.NoError(t, p.UpdateDelivery(ctx, &d))
+
+			actual, err := p.FetchDelivery(ctx, d.ID)
+			require.NoError(t, err)
+			assert.Equal(t, webhook.DeliveryStatusAbandoned, actual.Status)
+			assert.Equal(t, 3, actual.SendCount)
+			assert.Equal(t, 503, actual.LastResponseCode)
+			assert.Equal(t, d.LastError, actual.LastError)
+
+			t.Run("case=list deliveries by status", func(t *testing.T) {
+				newDelivery(t)
+
+				l, total, err := p.ListDeliveries(ctx, webhook.DeliveryStatusAbandoned, 0, 10)
+				require.NoError(t, err)
+				assert.EqualValues(t, 1, total)
+				require.Len(t, l, 1)
+				assert.Equal(t, d.ID, l[0].ID)
+
+				_, total, err = p.ListDeliveries(ctx, "", 0, 10)
+				require.NoError(t, err)
+				assert.EqualValues(t, 2, total)
+			})
+
+			t.Run("case=retry abandoned delivery", func(t *testing.T) {
+				require.NoError(t, p.RetryDelivery(ctx, d.ID))
+
+				actual, err := p.FetchDelivery(ctx, d.ID)
+				require.NoError(t, err)
+				assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+				assert.Equal(t, 0, actual.SendCount)
+
+				require.ErrorIs(t, p.RetryDelivery(ctx, d.ID), webhook.ErrDeliveryNotRetryable)
+				require.ErrorIs(t, p.RetryDelivery(ctx, x.NewUUID()), sqlcon.ErrNoRows)
+			})
+		})
+
+		t.Run("case=delete delivered and abandoned deliveries", func(t *testing.T) {
+			delivered, abandoned, queued := newDelivery(t), newDelivery(t), newDelivery(t)
+			delivered.Status = webhook.DeliveryStatusDelivered
+			require.NoError(t, p.UpdateDelivery(ctx, delivered))
+			abandoned.Status = webhook.DeliveryStatusAbandoned
+			require.NoError(t, p.UpdateDelivery(ctx, abandoned))
+
+			count, err := p.DeleteExpiredDeliveries(ctx, time.Now().UTC().Add(time.Hour), 100)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			_, err = p.FetchDelivery(ctx, delivered.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.FetchDelivery(ctx, abandoned.ID)
+			require.ErrorIs(t, err, sqlcon.ErrNoRows)
+			_, err = p.FetchDelivery(ctx, queued.ID)
+			require.NoError(t, err, "queued deliveries must be kept")
+		})
+
+		t.Run("case=claims each delivery once with concurrent workers", func(t *testing.T) {
+			queued := map[uuid.UUID]bool{}
+			for i := 0; i < 20; i++ {
+				queued[newDelivery(t).ID] = true
+			}
+
+			var mu sync.Mutex
+			var wg sync.WaitGroup
+			claimed := map[uuid.UUID]int{}
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						deliveries, err := p.NextDeliveries(ctx, 3, time.Hour)
+						if errors.Is(err, webhook.ErrQueueEmpty) {
+							return
+						} else if !assert.NoError(t, err) {
+							return
+						}
+
+						mu.Lock()
+						for _, d := range deliveries {
+							claimed[d.ID]++
+						}
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			for id := range queued {
+				assert.Equal(t, 1, claimed[id], "delivery %s must be claimed exactly once", id)
+			}
+		})
+
+		t.Run("case=a failing delivery does not abort the transaction", func(t *testing.T) {
+			existing := newDelivery(t)
+			var added *webhook.Delivery
+			require.NoError(t, p.Transaction(ctx, func(ctx context.Context, _ *pop.Connection) error {
+				duplicate := *existing
+				require.Error(t, p.AddDelivery(ctx, &duplicate))
+
+				added = &webhook.Delivery{Method: "POST", URL: "https://example.org/", Config: "encrypted-config", Payload: "encrypted-payload"}
+				return p.AddDelivery(ctx, added)
+			}))
+
+			_, err := p.FetchDelivery(ctx, added.ID)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/request"
+	"github.com/ory/kratos/x"
+)
+
+// deliveryLease is how long a claimed delivery is hidden from other workers. It must be longer than a
+// single attempt.
+const deliveryLease = 5 * time.Minute
+
+type (
+	workerDependencies interface {
+		PersistenceProvider
+		x.LoggingProvider
+		x.HTTPClientProvider
+		config.Provider
+		cipher.Provider
+	}
+	WorkerProvider interface {
+		WebHookWorker() *Worker
+	}
+	Worker struct {
+		d workerDependencies
+	}
+)
+
+func NewWorker(d workerDependencies) *Worker {
+	return &Worker{d: d}
+}
+
+// Work delivers queued web hooks until the context is done.
+func (w *Worker) Work(ctx context.Context) error {
+	// The channel is never closed because watchDeliveries owns it. It is buffered so that watchDeliveries never
+	// blocks on sending its error if Work already returned.
+	errChan := make(chan error, 1)
+
+	go w.watchDeliveries(ctx, errChan)
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil
+		}
+		return ctx.Err()
+	case err := <-errChan:
+		return err
+	}
+}
+
+// watchDeliveries dispatches the queue every second until the context is done, or until dispatching fails even
+// after backing off.
+func (w *Worker) watchDeliveries(ctx context.Context, errChan chan<- error) {
+	for {
+		if err := backoff.RetryNotify(
+			func() error {
+				return w.DispatchQueue(ctx)
+			},
+			backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
+			func(err error, t time.Duration) {
+				w.d.Logger().WithError(err).Error("Web hook DispatchQueue error")
+			},
+		); err != nil {
+			if ctx.Err() == nil {
+				errChan <- err
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// DispatchQueue attempts to deliver all web hooks which are due.
+func (w *Worker) DispatchQueue(ctx context.Context) error {
+	deliveries, err := w.d.WebHookPersister().NextDeliveries(ctx, 10, deliveryLease)
+	if err != nil {
+		if errors.Is(err, ErrQueueEmpty) {
+			return nil
+		}
+		return err
+	}
+
+	for k := range deliveries {
+		if err := w.DispatchDelivery(ctx, &deliveries[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchDelivery makes a single attempt of delivering the web hook and stores the outcome. Failed
+// deliveries are scheduled again with an exponential backoff until all retries are exhausted, after
+// which they are abandoned. Only errors while storing the outcome are returned.
+func (w *Worker) DispatchDelivery(ctx context.Context, d *Delivery) error {
+	conf := w.d.Config(ctx).WebHookDelivery()
+
+	code, err := w.send(ctx, d)
+	d.SendCount++
+	d.LastResponseCode = code
+	d.LastError = ""
+
+	switch {
+	case err == nil:
+		d.Status = DeliveryStatusDelivered
+	case d.SendCount > conf.MaxRetries:
+		d.Status = DeliveryStatusAbandoned
+		d.LastError = sqlxx.NullString(err.Error())
+		w.d.Logger().
+			WithError(err).
+			WithField("web_hook_delivery_id", d.ID).
+			WithField("web_hook_delivery_send_count", d.SendCount).
+			Warn("Exhausted all retries for web hook delivery and abandoned it.")
+	default:
+		d.Status = DeliveryStatusQueued
+		d.LastError = sqlxx.NullString(err.Error())
+		d.NextAttemptAt = time.Now().UTC().Add(retryInterval(conf, d.SendCount))
+		w.d.Logger().
+			WithError(err).
+			WithField("web_hook_delivery_id", d.ID).
+			WithField("web_hook_delivery_send_count", d.SendCount).
+			WithField("web_hook_delivery_next_attempt_at", d.NextAttemptAt).
+			Info("Web hook delivery failed and will be retried.")
+	}
+
+	if err := w.d.WebHookPersister().UpdateDelivery(ctx, d); err != nil {
+		w.d.Logger().
+			WithError(err).
+			WithField("web_hook_delivery_id", d.ID).
+			Error("Unable to store the outcome of the web hook delivery.")
+		return err
+	}
+
+	return nil
+}
+
+// send renders the request from the stored configuration and payload and returns the upstream status code.
+func (w *Worker) send(ctx context.Context, d *Delivery) (int, error) {
+	// Retries are scheduled by the worker, so the client gives up after the first attempt and passes
+	// the response on.
+	client := w.d.HTTPClient(ctx)
+	client.RetryMax = 0
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	conf, err := w.d.Cipher().Decrypt(ctx, d.Config)
+	if err != nil {
+		return 0, err
+	}
+	payload, err := w.d.Cipher().Decrypt(ctx, d.Payload)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, err := request.NewBuilder(conf, client, w.d.Logger())
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := builder.BuildRequest(json.RawMessage(payload))
+	if errors.Is(err, request.ErrCancel) {
+		w.d.Logger().
+			WithField("web_hook_delivery_id", d.ID).
+			Debug("Web hook delivery was canceled by its template.")
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, fmt.Errorf("web hook failed with status code %v", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryInterval doubles the initial interval for every failed attempt, up to the maximum interval.
+func retryInterval(conf *config.WebHookDelivery, sendCount int) time.Duration {
+	interval := conf.InitialInterval
+	for i := 1; i < sendCount && interval < conf.MaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > conf.MaxInterval {
+		return conf.MaxInterval
+	}
+	return interval
+}
@@ -0,0 +1,243 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/webhook"
+)
+
+func TestWorker(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyWebHookDeliveryMaxRetries, 1)
+	conf.MustSet(config.ViperKeyWebHookDeliveryInitialInterval, "1ns")
+	conf.MustSet(config.ViperKeyWebHookDeliveryMaxInterval, "1ns")
+
+	var calls int32
+	var status int32 = http.StatusOK
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	t.Cleanup(ts.Close)
+
+	queue := func(t *testing.T, template string) *webhook.Delivery {
+		d, err := webhook.NewDelivery(ctx, reg.Cipher(),
+			[]byte(fmt.Sprintf(`{"url": "%s", "method": "POST", "body": "base64://%s"}`, ts.URL, template)),
+			[]byte(`{"flow": {"id": "some-flow"}}`))
+		require.NoError(t, err)
+		require.NoError(t, reg.WebHookPersister().AddDelivery(ctx, d))
+		return d
+	}
+	fetch := func(t *testing.T, d *webhook.Delivery) *webhook.Delivery {
+		actual, err := reg.WebHookPersister().FetchDelivery(ctx, d.ID)
+		require.NoError(t, err)
+		return actual
+	}
+	dispatch := func(t *testing.T) {
+		time.Sleep(time.Millisecond)
+		require.NoError(t, reg.WebHookWorker().DispatchQueue(ctx))
+	}
+	// function(ctx) { flow_id: ctx.flow.id }
+	flowIDTemplate := "ZnVuY3Rpb24oY3R4KSB7IGZsb3dfaWQ6IGN0eC5mbG93LmlkIH0="
+
+	t.Run("case=delivers queued web hook", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&status, http.StatusOK)
+		d := queue(t, flowIDTemplate)
+
+		dispatch(t)
+
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+		assert.JSONEq(t, `{"flow_id": "some-flow"}`, body)
+
+		actual := fetch(t, d)
+		assert.Equal(t, webhook.DeliveryStatusDelivered, actual.Status)
+		assert.Equal(t, 1, actual.SendCount)
+		assert.Equal(t, http.StatusOK, actual.LastResponseCode)
+		assert.Empty(t, actual.LastError)
+
+		dispatch(t)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "a delivered web hook must not be sent again")
+	})
+
+	t.Run("case=retries failed web hook and abandons it after all retries", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+		d := queue(t, flowIDTemplate)
+
+		dispatch(t)
+		actual := fetch(t, d)
+		assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+		assert.Equal(t, 1, actual.SendCount)
+		assert.Equal(t, http.StatusServiceUnavailable, actual.LastResponseCode)
+		assert.Contains(t, actual.LastError.String(), "503")
+
+		dispatch(t)
+		actual = fetch(t, d)
+		assert.Equal(t, webhook.DeliveryStatusAbandoned, actual.Status)
+		assert.Equal(t, 2, actual.SendCount)
+
+		dispatch(t)
+		assert.EqualValues(t, 2, atomic.LoadInt32(&calls), "an abandoned web hook must not be sent again")
+
+		t.Run("case=retries abandoned web hook", func(t *testing.T) {
+			atomic.StoreInt32(&status, http.StatusOK)
+			require.NoError(t, reg.WebHookPersister().RetryDelivery(ctx, d.ID))
+
+			dispatch(t)
+			actual := fetch(t, d)
+			assert.Equal(t, webhook.DeliveryStatusDelivered, actual.Status)
+			assert.Equal(t, 1, actual.SendCount)
+			assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+		})
+	})
+
+	t.Run("case=does not send web hook canceled by its template", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		// function(ctx) error "cancel"
+		d := queue(t, "ZnVuY3Rpb24oY3R4KSBlcnJvciAiY2FuY2VsIg==")
+
+		dispatch(t)
+
+		assert.EqualValues(t, 0, atomic.LoadInt32(&calls))
+		assert.Equal(t, webhook.DeliveryStatusDelivered, fetch(t, d).Status)
+	})
+
+	t.Run("case=postpones claimed deliveries", func(t *testing.T) {
+		d := queue(t, flowIDTemplate)
+
+		claimed, err := reg.WebHookPersister().NextDeliveries(ctx, 10, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, d.ID, claimed[0].ID)
+
+		_, err = reg.WebHookPersister().NextDeliveries(ctx, 10, time.Hour)
+		require.ErrorIs(t, err, webhook.ErrQueueEmpty)
+	})
+}
+
+type unavailablePersister struct {
+	webhook.Persister
+	calls int32
+}
+
+func (p *unavailablePersister) NextDeliveries(context.Context, uint8, time.Duration) ([]webhook.Delivery, error) {
+	atomic.AddInt32(&p.calls, 1)
+	return nil, errors.New("database is unavailable")
+}
+
+type unavailablePersisterRegistry struct {
+	*driver.RegistryDefault
+	p *unavailablePersister
+}
+
+func (r *unavailablePersisterRegistry) WebHookPersister() webhook.Persister {
+	return r.p
+}
+
+func TestWorkerWork(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	p := &unavailablePersister{Persister: reg.WebHookPersister()}
+	w := webhook.NewWorker(&unavailablePersisterRegistry{RegistryDefault: reg, p: p})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Work(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&p.calls) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the worker did not stop after the context was canceled")
+	}
+
+	calls := atomic.LoadInt32(&p.calls)
+	time.Sleep(2 * time.Second)
+	assert.Equal(t, calls, atomic.LoadInt32(&p.calls), "the worker must not dispatch the queue after the context was canceled")
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	_, reg := internal.NewFastRegistryWithMocks(t)
+
+	_, ts, _, _ := testhelpers.NewKratosServerWithCSRFAndRouters(t, reg)
+
+	queued := &webhook.Delivery{Method: "POST", URL: "https://example.org/queued"}
+	require.NoError(t, reg.WebHookPersister().AddDelivery(ctx, queued))
+	abandoned := &webhook.Delivery{Method: "POST", URL: "https://example.org/abandoned"}
+	require.NoError(t, reg.WebHookPersister().AddDelivery(ctx, abandoned))
+	abandoned.Status = webhook.DeliveryStatusAbandoned
+	abandoned.SendCount = 11
+	require.NoError(t, reg.WebHookPersister().UpdateDelivery(ctx, abandoned))
+
+	do := func(t *testing.T, method, path string, expectedCode int) []byte {
+		req, err := http.NewRequest(method, ts.URL+"/admin"+path, nil)
+		require.NoError(t, err)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectedCode, res.StatusCode, "%s", body)
+		return body
+	}
+
+	t.Run("case=lists deliveries by status", func(t *testing.T) {
+		var deliveries []webhook.Delivery
+		require.NoError(t, json.Unmarshal(do(t, "GET", webhook.AdminRouteDeliveries, http.StatusOK), &deliveries))
+		assert.Len(t, deliveries, 2)
+
+		require.NoError(t, json.Unmarshal(do(t, "GET", webhook.AdminRouteDeliveries+"?status=abandoned", http.StatusOK), &deliveries))
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, abandoned.ID, deliveries[0].ID)
+
+		do(t, "GET", webhook.AdminRouteDeliveries+"?status=unknown", http.StatusBadRequest)
+	})
+
+	t.Run("case=does not expose the configuration", func(t *testing.T) {
+		body := do(t, "GET", webhook.AdminRouteDeliveries+"/"+queued.ID.String(), http.StatusOK)
+		assert.Contains(t, string(body), queued.URL)
+		assert.NotContains(t, string(body), "config")
+		assert.NotContains(t, string(body), "payload")
+	})
+
+	t.Run("case=retries abandoned delivery", func(t *testing.T) {
+		do(t, "POST", webhook.AdminRouteDeliveries+"/"+queued.ID.String()+"/retry", http.StatusConflict)
+
+		var actual webhook.Delivery
+		require.NoError(t, json.Unmarshal(do(t, "POST", webhook.AdminRouteDeliveries+"/"+abandoned.ID.String()+"/retry", http.StatusOK), &actual))
+		assert.Equal(t, webhook.DeliveryStatusQueued, actual.Status)
+		assert.Equal(t, 0, actual.SendCount)
+	})
+
+	t.Run("case=returns 404 for unknown delivery", func(t *testing.T) {
+		do(t, "GET", webhook.AdminRouteDeliveries+"/2f5c8a3e-7b1d-4e6a-9c0f-3d8e1a5b7c24", http.StatusNotFound)
+	})
+}
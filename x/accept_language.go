@@ -0,0 +1,27 @@
+package x
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/golang/gddo/httputil/header"
+)
+
+// AcceptLanguages parses the value of an Accept-Language header and returns the
+// language tags it contains, ordered by their quality value. Wildcards and tags
+// with a quality of zero are omitted.
+func AcceptLanguages(value string) []string {
+	specs := header.ParseAccept(http.Header{"Accept-Language": {value}}, "Accept-Language")
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].Q > specs[j].Q
+	})
+
+	languages := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		if spec.Value == "*" || spec.Q <= 0 {
+			continue
+		}
+		languages = append(languages, spec.Value)
+	}
+	return languages
+}
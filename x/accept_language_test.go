@@ -0,0 +1,24 @@
+package x
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptLanguages(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected []string
+	}{
+		{in: "", expected: []string{}},
+		{in: "de", expected: []string{"de"}},
+		{in: "de-DE,de;q=0.9,en;q=0.8", expected: []string{"de-DE", "de", "en"}},
+		{in: "en;q=0.5, fr, *;q=0.1", expected: []string{"fr", "en"}},
+		{in: "es;q=0, pt-BR", expected: []string{"pt-BR"}},
+	} {
+		t.Run("case="+tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.expected, AcceptLanguages(tc.in))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package x
+
+import (
+	"context"
+	"sync"
+)
+
+type commitHooksContextKey struct{}
+
+type commitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// WithCommitHooks returns a context which collects the functions passed to OnCommit, and a function which runs them.
+// Transactions use it to defer side effects, which can not be rolled back, until they were committed.
+func WithCommitHooks(ctx context.Context) (context.Context, func()) {
+	h := new(commitHooks)
+	return context.WithValue(ctx, commitHooksContextKey{}, h), h.run
+}
+
+// OnCommit runs fn once the transaction of the context was committed, or right away if the context does not belong to
+// a transaction. fn never runs if the transaction is rolled back.
+func OnCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(commitHooksContextKey{}).(*commitHooks)
+	if !ok {
+		fn()
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
+
+func (h *commitHooks) run() {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/ory/x/httpx"
@@ -109,6 +111,55 @@ func (ct *TransportWithHost) RoundTrip(req *http.Request) (*http.Response, error
 	return ct.RoundTripper.RoundTrip(req)
 }
 
+// ClientIP returns the IP address of the client which sent the request. Addresses set by reverse proxies in
+// the True-Client-IP, X-Real-IP, and X-Forwarded-For headers are only used if the request was sent by one of
+// the trusted proxies and if they are valid IP addresses. Otherwise, the remote address is returned.
+func ClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrustedProxy(remote, trustedProxies) {
+		return remote
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("True-Client-IP"))); ip != nil {
+		return ip.String()
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+
+	// Proxies append to X-Forwarded-For, which is why the client is the right-most address not belonging to
+	// a trusted proxy. Everything left of it could have been set by the client itself.
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// An invalid hop was not appended by a trusted proxy, so nothing left of it can be trusted.
+			break
+		}
+		if !isTrustedProxy(ip.String(), trustedProxies) || i == 0 {
+			return ip.String()
+		}
+	}
+
+	return remote
+}
+
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
 func AcceptToRedirectOrJSON(
 	w http.ResponseWriter, r *http.Request, writer herodot.Writer, out interface{}, redirectTo string,
 ) {
@@ -3,8 +3,10 @@ package x
 import (
 	"crypto/tls"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -28,6 +30,58 @@ func TestRequestURL(t *testing.T) {
 	}).String(), "http://foobar/foo")
 }
 
+func TestClientIP(t *testing.T) {
+	_, trusted, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+	proxies := []*net.IPNet{trusted}
+
+	t.Run("case=untrusted remote address", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "203.0.113.1:1234"
+		r.Header.Set("X-Forwarded-For", "198.51.100.1")
+		r.Header.Set("X-Real-IP", "198.51.100.2")
+		r.Header.Set("True-Client-IP", "198.51.100.3")
+		assert.Equal(t, "203.0.113.1", ClientIP(r, proxies))
+		assert.Equal(t, "203.0.113.1", ClientIP(r, nil))
+	})
+
+	t.Run("case=trusted remote address", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		assert.Equal(t, "192.0.2.1", ClientIP(r, proxies))
+
+		r.Header.Set("X-Forwarded-For", "10.0.0.1, 198.51.100.1, 192.0.2.2")
+		assert.Equal(t, "198.51.100.1", ClientIP(r, proxies))
+
+		r.Header.Set("X-Real-IP", "198.51.100.2")
+		assert.Equal(t, "198.51.100.2", ClientIP(r, proxies))
+
+		r.Header.Set("True-Client-IP", "198.51.100.3")
+		assert.Equal(t, "198.51.100.3", ClientIP(r, proxies))
+	})
+
+	t.Run("case=only trusted proxies forwarded", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("X-Forwarded-For", "192.0.2.3, 192.0.2.2")
+		assert.Equal(t, "192.0.2.3", ClientIP(r, proxies))
+	})
+
+	t.Run("case=invalid forwarded addresses", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("True-Client-IP", strings.Repeat("a", 100))
+		r.Header.Set("X-Real-IP", "not-an-ip")
+		assert.Equal(t, "192.0.2.1", ClientIP(r, proxies))
+
+		r.Header.Set("X-Forwarded-For", "198.51.100.1, not-an-ip, 192.0.2.2")
+		assert.Equal(t, "192.0.2.1", ClientIP(r, proxies))
+
+		r.Header.Set("X-Forwarded-For", "not-an-ip, 2001:DB8::0001, 192.0.2.2")
+		assert.Equal(t, "2001:db8::1", ClientIP(r, proxies))
+	})
+}
+
 func TestAcceptToRedirectOrJSON(t *testing.T) {
 	wr := herodot.NewJSONWriter(logrusx.New("", ""))
 
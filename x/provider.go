@@ -5,6 +5,7 @@ import (
 
 	"github.com/ory/x/tracing"
 
+	"github.com/gobuffalo/pop/v6"
 	"github.com/gorilla/sessions"
 
 	"github.com/ory/herodot"
@@ -25,6 +26,15 @@ type CookieProvider interface {
 	ContinuityCookieManager(ctx context.Context) sessions.StoreExact
 }
 
+// TransactionPersister runs the writes of several persisters in a single database transaction.
+type TransactionPersister interface {
+	Transaction(ctx context.Context, callback func(ctx context.Context, connection *pop.Connection) error) error
+}
+
+type TransactionPersistenceProvider interface {
+	TransactionPersister() TransactionPersister
+}
+
 type TracingProvider interface {
 	Tracer(ctx context.Context) *tracing.Tracer
 }
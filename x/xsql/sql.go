@@ -19,14 +19,17 @@ import (
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/webhook"
 )
 
 func CleanSQL(t *testing.T, c *pop.Connection) {
 	ctx := context.Background()
 	for _, table := range []string{
 		new(continuity.Container).TableName(ctx),
+		new(courier.MessageDispatch).TableName(ctx),
 		new(courier.Message).TableName(ctx),
 
+		new(login.Attempt).TableName(ctx),
 		new(login.Flow).TableName(ctx),
 		new(registration.Flow).TableName(ctx),
 		new(settings.Flow).TableName(ctx),
@@ -40,6 +43,7 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 
 		new(errorx.ErrorContainer).TableName(ctx),
 
+		new(session.Device).TableName(ctx),
 		new(session.Session).TableName(ctx),
 		new(identity.CredentialIdentifierCollection).TableName(ctx),
 		new(identity.CredentialsCollection).TableName(ctx),
@@ -50,6 +54,8 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 
 		new(code.Code).TableName(ctx),
 
+		new(webhook.Delivery).TableName(ctx),
+
 		"networks",
 		"schema_migration",
 	} {